@@ -19,48 +19,88 @@ import (
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/cloud_bucket"
 	"beam.apache.org/playground/backend/internal/code_processing"
+	"beam.apache.org/playground/backend/internal/correlation"
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/errors"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/preparers"
+	"beam.apache.org/playground/backend/internal/run_history"
 	"beam.apache.org/playground/backend/internal/setup_tools/life_cycle"
 	"beam.apache.org/playground/backend/internal/utils"
 	"context"
+	"crypto/sha256"
+	"fmt"
 	"github.com/google/uuid"
 )
 
+// defaultRunHistoryLimit is the number of runs which ListRunHistory returns if the limit isn't provided
+const defaultRunHistoryLimit = 10
+
+// exampleSource returns the code of examples of the catalog
+type exampleSource interface {
+	GetPrecompiledObject(ctx context.Context, precompiledObjectPath string) (string, error)
+}
+
 // playgroundController processes `gRPC' requests from clients.
 // Contains methods to process receiving code, monitor current status of code processing and receive compile/run output.
 type playgroundController struct {
 	env          *environment.Environment
 	cacheService cache.Cache
+	// workspacePool keeps workspaces of pipelines which are created in advance, nil if workspaces are created for each run
+	workspacePool *life_cycle.WorkspacePool
+	// historyStore keeps the run history of examples of the catalog, nil if the run history is disabled
+	historyStore run_history.Store
+	// catalog is used to check that the code of the run is the example of the catalog before the run is recorded
+	catalog exampleSource
 
 	pb.UnimplementedPlaygroundServiceServer
 }
 
 // RunCode is running code from requests using a particular SDK
+// - In case of the server shuts down returns codes.Unavailable, so the request can be retried on another server
 // - In case of incorrect sdk returns codes.InvalidArgument
+// - In case of unknown preparers to skip or force returns codes.InvalidArgument
+// - In case of the example id which isn't a path of the example of the catalog returns codes.InvalidArgument
 // - In case of error during preparing files/folders returns codes.Internal
 // - In case of no errors saves playground.Status_STATUS_EXECUTING as cache.Status into cache and sets expiration time
 //   for all cache values which will be saved into cache during processing received code.
-//   Returns id of code processing (pipelineId)
+//   Returns id of code processing (pipelineId) and the correlation id of the request which is saved as cache.CorrelationId
 func (controller *playgroundController) RunCode(ctx context.Context, info *pb.RunCodeRequest) (*pb.RunCodeResponse, error) {
+	if code_processing.IsShuttingDown() {
+		logger.FromContext(ctx).Warnf("RunCode(): the server shuts down\n")
+		return nil, errors.UnavailableError("Error during preparing", "The server shuts down, run the code again")
+	}
 	// check for correct sdk
 	if info.Sdk != controller.env.BeamSdkEnvs.ApacheBeamSdk {
-		logger.Errorf("RunCode(): request contains incorrect sdk: %s\n", info.Sdk)
+		logger.FromContext(ctx).Errorf("RunCode(): request contains incorrect sdk: %s\n", info.Sdk)
 		return nil, errors.InvalidArgumentError("Error during preparing", "Incorrect sdk. Want to receive %s, but the request contains %s", controller.env.BeamSdkEnvs.ApacheBeamSdk.String(), info.Sdk.String())
 	}
 	switch info.Sdk {
 	case pb.Sdk_SDK_UNSPECIFIED, pb.Sdk_SDK_SCIO:
-		logger.Errorf("RunCode(): unimplemented sdk: %s\n", info.Sdk)
+		logger.FromContext(ctx).Errorf("RunCode(): unimplemented sdk: %s\n", info.Sdk)
 		return nil, errors.InvalidArgumentError("Error during preparing", "Sdk is not implemented yet: %s", info.Sdk.String())
 	}
+	preparerOverrides := preparers.Overrides{Skip: info.SkipPreparers, Force: info.ForcePreparers}
+	if err := utils.ValidatePreparerOverrides(info.Sdk, preparerOverrides); err != nil {
+		logger.FromContext(ctx).Errorf("RunCode(): incorrect preparers: %s\n", err.Error())
+		return nil, errors.InvalidArgumentError("Error during preparing", "Incorrect preparers: %s", err.Error())
+	}
+	if info.ExampleId != "" && !cloud_bucket.IsExamplePath(info.Sdk, info.ExampleId) {
+		logger.FromContext(ctx).Errorf("RunCode(): incorrect example id: %s\n", info.ExampleId)
+		return nil, errors.InvalidArgumentError("Error during preparing", "Incorrect example id: %s", info.ExampleId)
+	}
+	historyStore := controller.runHistoryStore(info)
+	exampleId := ""
+	if historyStore != nil {
+		exampleId = info.ExampleId
+	}
 
 	cacheExpirationTime := controller.env.ApplicationEnvs.CacheEnvs().KeyExpirationTime()
 	pipelineId := uuid.New()
 
-	lc, err := life_cycle.Setup(info.Sdk, info.Code, pipelineId, controller.env.ApplicationEnvs.WorkingDir(), controller.env.ApplicationEnvs.PipelinesFolder(), controller.env.BeamSdkEnvs.PreparedModDir())
+	lc, err := life_cycle.Setup(info.Sdk, info.Code, pipelineId, controller.env.ApplicationEnvs.WorkingDir(), controller.env.ApplicationEnvs.PipelinesFolder(), controller.env.BeamSdkEnvs.PreparedModDir(), controller.workspacePool)
 	if err != nil {
-		logger.Errorf("RunCode(): error during setup file system: %s\n", err.Error())
+		logger.FromContext(ctx).Errorf("RunCode(): error during setup file system: %s\n", err.Error())
 		return nil, errors.InternalError("Error during preparing", "Error during setup file system for the code processing: %s", err.Error())
 	}
 
@@ -80,31 +120,82 @@ func (controller *playgroundController) RunCode(ctx context.Context, info *pb.Ru
 		code_processing.DeleteFolders(pipelineId, lc)
 		return nil, errors.InternalError("Error during preparing", "Error during saving initial cancel flag")
 	}
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.CorrelationId, correlation.FromContext(ctx)); err != nil {
+		code_processing.DeleteFolders(pipelineId, lc)
+		return nil, errors.InternalError("Error during preparing", "Error during saving correlation id")
+	}
+	if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.ExampleId, exampleId); err != nil {
+		code_processing.DeleteFolders(pipelineId, lc)
+		return nil, errors.InternalError("Error during preparing", "Error during saving example id")
+	}
+	for _, subKey := range []cache.SubKey{cache.RunOutputTruncated, cache.RunErrorTruncated} {
+		if err = utils.SetToCache(ctx, controller.cacheService, pipelineId, subKey, false); err != nil {
+			code_processing.DeleteFolders(pipelineId, lc)
+			return nil, errors.InternalError("Error during preparing", "Error during saving initial truncation flags")
+		}
+	}
 	if err = controller.cacheService.SetExpTime(ctx, pipelineId, cacheExpirationTime); err != nil {
-		logger.Errorf("%s: RunCode(): cache.SetExpTime(): %s\n", pipelineId, err.Error())
+		logger.FromContext(ctx).Errorf("%s: RunCode(): cache.SetExpTime(): %s\n", pipelineId, err.Error())
 		code_processing.DeleteFolders(pipelineId, lc)
 		return nil, errors.InternalError("Error during preparing", "Internal error")
 	}
 
-	go code_processing.Process(context.Background(), controller.cacheService, lc, pipelineId, &controller.env.ApplicationEnvs, &controller.env.BeamSdkEnvs, info.PipelineOptions)
+	// the code processing outlives the request, so only the contextual logger of the request is passed to it
+	processCtx := logger.NewContext(context.Background(), logger.FromContext(ctx))
+	go code_processing.Process(processCtx, controller.cacheService, historyStore, lc, pipelineId, &controller.env.ApplicationEnvs, &controller.env.BeamSdkEnvs, info.PipelineOptions, preparerOverrides)
 
-	pipelineInfo := pb.RunCodeResponse{PipelineUuid: pipelineId.String()}
+	pipelineInfo := pb.RunCodeResponse{PipelineUuid: pipelineId.String(), CorrelationId: correlation.FromContext(ctx)}
 	return &pipelineInfo, nil
 }
 
+// runHistoryStore returns the run history which the run of the request is added to, nil if the run isn't recorded.
+// Only runs of the unchanged code of the example are recorded, so the run history isn't affected by edited code.
+// The code is compared with the example of the catalog when the record is added after the run, so RunCode doesn't wait for the catalog.
+func (controller *playgroundController) runHistoryStore(info *pb.RunCodeRequest) run_history.Store {
+	if info.ExampleId == "" || controller.historyStore == nil || controller.catalog == nil {
+		return nil
+	}
+	return &catalogRunStore{Store: controller.historyStore, catalog: controller.catalog, codeHash: sha256.Sum256([]byte(info.Code))}
+}
+
+// catalogRunStore adds the record of the run to the run history only if the code of the run is the code of the example of the catalog
+type catalogRunStore struct {
+	run_history.Store
+	catalog  exampleSource
+	codeHash [sha256.Size]byte
+}
+
+// Add adds the record to the run history if the hash of the code of the run is the hash of the code of the example of the record
+func (s *catalogRunStore) Add(ctx context.Context, record *pb.RunRecord) error {
+	exampleCode, err := s.catalog.GetPrecompiledObject(ctx, record.ExampleId)
+	if err != nil {
+		return fmt.Errorf("error during get the code of the example %s, the run isn't recorded: %w", record.ExampleId, err)
+	}
+	if sha256.Sum256([]byte(exampleCode)) != s.codeHash {
+		logger.FromContext(ctx).Infof("%s: the code differs from the example %s, the run isn't recorded\n", record.PipelineUuid, record.ExampleId)
+		return nil
+	}
+	return s.Store.Add(ctx, record)
+}
+
 // CheckStatus is checking status for the specific pipeline by PipelineUuid
 func (controller *playgroundController) CheckStatus(ctx context.Context, info *pb.CheckStatusRequest) (*pb.CheckStatusResponse, error) {
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	errorMessage := "Error during getting status of the code processing"
 	if err != nil {
-		logger.Errorf("%s: CheckStatus(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		logger.FromContext(ctx).Errorf("%s: CheckStatus(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError(errorMessage, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
 	status, err := code_processing.GetProcessingStatus(ctx, controller.cacheService, pipelineId, errorMessage)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.CheckStatusResponse{Status: status}, nil
+	outputTruncated := code_processing.IsOutputTruncated(ctx, controller.cacheService, pipelineId, cache.RunOutputTruncated) ||
+		code_processing.IsOutputTruncated(ctx, controller.cacheService, pipelineId, cache.RunErrorTruncated)
+	correlationId := code_processing.GetCorrelationId(ctx, controller.cacheService, pipelineId)
+	metadata := code_processing.GetRunMetadata(ctx, controller.cacheService, pipelineId)
+	testReport := code_processing.GetTestReport(ctx, controller.cacheService, pipelineId)
+	return &pb.CheckStatusResponse{Status: status, OutputTruncated: outputTruncated, CorrelationId: correlationId, Metadata: metadata, TestReport: testReport}, nil
 }
 
 // GetRunOutput is returning output of execution for specific pipeline by PipelineUuid
@@ -112,7 +203,7 @@ func (controller *playgroundController) GetRunOutput(ctx context.Context, info *
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	errorMessage := "Error during getting run output of the code processing"
 	if err != nil {
-		logger.Errorf("%s: GetRunOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		logger.FromContext(ctx).Errorf("%s: GetRunOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError(errorMessage, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
 	lastIndex, err := code_processing.GetLastIndex(ctx, controller.cacheService, pipelineId, cache.RunOutputIndex, errorMessage)
@@ -131,7 +222,7 @@ func (controller *playgroundController) GetRunOutput(ctx context.Context, info *
 		}
 	}
 
-	pipelineResult := pb.GetRunOutputResponse{Output: newRunOutput}
+	pipelineResult := pb.GetRunOutputResponse{Output: newRunOutput, Truncated: code_processing.IsOutputTruncated(ctx, controller.cacheService, pipelineId, cache.RunOutputTruncated)}
 
 	return &pipelineResult, nil
 }
@@ -142,7 +233,7 @@ func (controller *playgroundController) GetLogs(ctx context.Context, info *pb.Ge
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	errorMessage := "Error during getting logs of the code processing"
 	if err != nil {
-		logger.Errorf("%s: %s: pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, errorTitle, err.Error())
+		logger.FromContext(ctx).Errorf("%s: %s: pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, errorTitle, err.Error())
 		return nil, errors.InvalidArgumentError(errorMessage, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
 	lastIndex, err := code_processing.GetLastIndex(ctx, controller.cacheService, pipelineId, cache.LogsIndex, errorMessage)
@@ -171,14 +262,14 @@ func (controller *playgroundController) GetRunError(ctx context.Context, info *p
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	errorMessage := "Error during getting error output of the code processing"
 	if err != nil {
-		logger.Errorf("%s: GetRunError(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		logger.FromContext(ctx).Errorf("%s: GetRunError(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError(errorMessage, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
 	runError, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.RunError, errorMessage)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GetRunErrorResponse{Output: runError}, nil
+	return &pb.GetRunErrorResponse{Output: runError, Truncated: code_processing.IsOutputTruncated(ctx, controller.cacheService, pipelineId, cache.RunErrorTruncated)}, nil
 }
 
 //GetValidationOutput is returning output of validation for specific pipeline by PipelineUuid
@@ -186,7 +277,7 @@ func (controller *playgroundController) GetValidationOutput(ctx context.Context,
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	errorMessage := "Error during getting compilation output"
 	if err != nil {
-		logger.Errorf("%s: GetValidationOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		logger.FromContext(ctx).Errorf("%s: GetValidationOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError(errorMessage, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
 	validationOutput, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.ValidationOutput, errorMessage)
@@ -201,7 +292,7 @@ func (controller *playgroundController) GetPreparationOutput(ctx context.Context
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	errorMessage := "Error during getting compilation output"
 	if err != nil {
-		logger.Errorf("%s: GetPreparationOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		logger.FromContext(ctx).Errorf("%s: GetPreparationOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError(errorMessage, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
 	preparationOutput, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.PreparationOutput, errorMessage)
@@ -216,7 +307,7 @@ func (controller *playgroundController) GetCompileOutput(ctx context.Context, in
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	errorMessage := "Error during getting compilation output"
 	if err != nil {
-		logger.Errorf("%s: GetCompileOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		logger.FromContext(ctx).Errorf("%s: GetCompileOutput(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError(errorMessage, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
 	compileOutput, err := code_processing.GetProcessingOutput(ctx, controller.cacheService, pipelineId, cache.CompileOutput, errorMessage)
@@ -231,7 +322,7 @@ func (controller *playgroundController) Cancel(ctx context.Context, info *pb.Can
 	pipelineId, err := uuid.Parse(info.PipelineUuid)
 	errorMessage := "Error during canceling the code processing"
 	if err != nil {
-		logger.Errorf("%s: Cancel(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
+		logger.FromContext(ctx).Errorf("%s: Cancel(): pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid, err.Error())
 		return nil, errors.InvalidArgumentError(errorMessage, "pipelineId has incorrect value and couldn't be parsed as uuid value: %s", info.PipelineUuid)
 	}
 	if err := utils.SetToCache(ctx, controller.cacheService, pipelineId, cache.Canceled, true); err != nil {
@@ -245,7 +336,7 @@ func (controller *playgroundController) GetPrecompiledObjects(ctx context.Contex
 	bucket := cloud_bucket.New()
 	sdkToCategories, err := bucket.GetPrecompiledObjects(ctx, info.Sdk, info.Category)
 	if err != nil {
-		logger.Errorf("GetPrecompiledObjects(): cloud storage error: %s", err.Error())
+		logger.FromContext(ctx).Errorf("GetPrecompiledObjects(): cloud storage error: %s", err.Error())
 		return nil, errors.InternalError("Error during getting Precompiled Objects", "Error with cloud connection")
 	}
 	response := pb.GetPrecompiledObjectsResponse{SdkCategories: make([]*pb.Categories, 0)}
@@ -264,7 +355,7 @@ func (controller *playgroundController) GetPrecompiledObjectCode(ctx context.Con
 	cd := cloud_bucket.New()
 	codeString, err := cd.GetPrecompiledObject(ctx, info.GetCloudPath())
 	if err != nil {
-		logger.Errorf("GetPrecompiledObjectCode(): cloud storage error: %s", err.Error())
+		logger.FromContext(ctx).Errorf("GetPrecompiledObjectCode(): cloud storage error: %s", err.Error())
 		return nil, errors.InternalError("Error during getting Precompiled Object's code", "Error with cloud connection")
 	}
 	response := pb.GetPrecompiledObjectCodeResponse{Code: codeString}
@@ -276,10 +367,15 @@ func (controller *playgroundController) GetPrecompiledObjectOutput(ctx context.C
 	cd := cloud_bucket.New()
 	output, err := cd.GetPrecompiledObjectOutput(ctx, info.GetCloudPath())
 	if err != nil {
-		logger.Errorf("GetPrecompiledObjectOutput(): cloud storage error: %s", err.Error())
+		logger.FromContext(ctx).Errorf("GetPrecompiledObjectOutput(): cloud storage error: %s", err.Error())
 		return nil, errors.InternalError("Error during getting Precompiled Object's output", "Error with cloud connection")
 	}
-	response := pb.GetPrecompiledObjectOutputResponse{Output: output}
+	// objects which are stored before the metadata is introduced don't have it
+	metadata, err := cd.GetPrecompiledObjectMetadata(ctx, info.GetCloudPath())
+	if err != nil {
+		logger.FromContext(ctx).Warnf("GetPrecompiledObjectOutput(): metadata of the object isn't available: %s", err.Error())
+	}
+	response := pb.GetPrecompiledObjectOutputResponse{Output: output, Metadata: metadata}
 	return &response, nil
 }
 
@@ -288,9 +384,36 @@ func (controller *playgroundController) GetPrecompiledObjectLogs(ctx context.Con
 	cd := cloud_bucket.New()
 	logs, err := cd.GetPrecompiledObjectLogs(ctx, info.GetCloudPath())
 	if err != nil {
-		logger.Errorf("GetPrecompiledObjectLogs(): cloud storage error: %s", err.Error())
+		logger.FromContext(ctx).Errorf("GetPrecompiledObjectLogs(): cloud storage error: %s", err.Error())
 		return nil, errors.InternalError("Error during getting Precompiled Object's logs", "Error with cloud connection")
 	}
 	response := pb.GetPrecompiledObjectLogsResponse{Output: logs}
 	return &response, nil
 }
+
+// ListRunHistory returns the most recent runs of the example of the catalog, the most recent run first
+// - In case of the run history is disabled returns codes.NotFound
+// - In case of empty example id or negative limit returns codes.InvalidArgument
+// - In case of zero limit returns defaultRunHistoryLimit runs
+func (controller *playgroundController) ListRunHistory(ctx context.Context, info *pb.ListRunHistoryRequest) (*pb.ListRunHistoryResponse, error) {
+	errorMessage := "Error during getting run history"
+	if controller.historyStore == nil {
+		return nil, errors.NotFoundError(errorMessage, "Run history is disabled")
+	}
+	if info.ExampleId == "" {
+		return nil, errors.InvalidArgumentError(errorMessage, "Example id should be provided")
+	}
+	if info.Limit < 0 {
+		return nil, errors.InvalidArgumentError(errorMessage, "Limit should be positive, but the request contains %d", info.Limit)
+	}
+	limit := int(info.Limit)
+	if limit == 0 {
+		limit = defaultRunHistoryLimit
+	}
+	records, err := controller.historyStore.List(ctx, info.ExampleId, limit)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("ListRunHistory(): error during list records of %s: %s\n", info.ExampleId, err.Error())
+		return nil, errors.InternalError(errorMessage, "Error during reading run history")
+	}
+	return &pb.ListRunHistoryResponse{Records: records}, nil
+}
@@ -18,13 +18,22 @@ import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/cache/local"
+	"beam.apache.org/playground/backend/internal/correlation"
 	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/run_history"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/goleak"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 	"io/fs"
 	"log"
 	"net"
@@ -32,6 +41,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -46,6 +56,8 @@ const (
 
 var lis *bufconn.Listener
 var cacheService cache.Cache
+var historyStore run_history.Store
+var bufnetController *playgroundController
 var opt goleak.Option
 
 func TestMain(m *testing.M) {
@@ -58,7 +70,6 @@ func TestMain(m *testing.M) {
 
 func setup() *grpc.Server {
 	lis = bufconn.Listen(bufSize)
-	s := grpc.NewServer()
 
 	// create configs for java
 	err := os.MkdirAll(configFolder, fs.ModePerm)
@@ -79,6 +90,7 @@ func setup() *grpc.Server {
 
 	// setup cache
 	cacheService = local.New(context.Background())
+	historyStore = run_history.NewLocalStore(20)
 
 	path, err := os.Getwd()
 	if err != nil {
@@ -103,10 +115,13 @@ func setup() *grpc.Server {
 	if err != nil {
 		panic(err)
 	}
-	pb.RegisterPlaygroundServiceServer(s, &playgroundController{
+	s := grpc.NewServer(getGrpcServerOptions(context.Background(), *appEnv)...)
+	bufnetController = &playgroundController{
 		env:          environment.NewEnvironment(*networkEnv, *sdkEnv, *appEnv),
 		cacheService: cacheService,
-	})
+		historyStore: historyStore,
+	}
+	pb.RegisterPlaygroundServiceServer(s, bufnetController)
 	go func() {
 		if err := s.Serve(lis); err != nil {
 			log.Fatalf("Server exited with error: %v", err)
@@ -157,6 +172,20 @@ func TestPlaygroundController_RunCode(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			// Test case with calling RunCode method with the example id which isn't a path of the example of the catalog.
+			// As a result, want to receive an error.
+			name: "RunCode with incorrect example id",
+			args: args{
+				ctx: context.Background(),
+				request: &pb.RunCodeRequest{
+					Code:      "MOCK_CODE",
+					Sdk:       pb.Sdk_SDK_JAVA,
+					ExampleId: "SDK_JAVA/../Example",
+				},
+			},
+			wantErr: true,
+		},
 		{
 			// Test case with calling RunCode method with correct SDK.
 			// As a result, want to receive response with pipelineId and status into cache should be set as Status_STATUS_COMPILING.
@@ -202,11 +231,99 @@ func TestPlaygroundController_RunCode(t *testing.T) {
 	}
 }
 
+// lockedBuffer is a buffer which can be written by the code processing and read by the test at the same time
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestPlaygroundController_RunCodeCorrelationId(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	out := &lockedBuffer{}
+	// the handler is passed to the code processing with the contextual logger of the call, since handlers of the root logger
+	// are used by code processings which are started by other tests
+	withLogHandler := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(logger.NewContext(ctx, logger.WithHandlers(logger.NewJsonHandler(out))), req)
+	}
+	logLis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(withLogHandler, correlation.UnaryInterceptor()))
+	pb.RegisterPlaygroundServiceServer(s, bufnetController)
+	go func() {
+		_ = s.Serve(logLis)
+	}()
+	defer s.Stop()
+	correlationId := "test-correlation-id"
+	ctx := metadata.AppendToOutgoingContext(context.Background(), correlation.Header, correlationId)
+	logDialer := func(context.Context, string) (net.Conn, error) {
+		return logLis.Dial()
+	}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(logDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	// Test that the correlation id of the request is attached to the log line of the preparation error and returned back
+	response, err := client.RunCode(ctx, &pb.RunCodeRequest{Code: "module org.apache.beam.examples {}", Sdk: pb.Sdk_SDK_JAVA})
+	if err != nil {
+		t.Fatalf("RunCode() unexpected error = %v", err)
+	}
+	if response.CorrelationId != correlationId {
+		t.Errorf("RunCode() correlationId = %v, want %v", response.CorrelationId, correlationId)
+	}
+	var status *pb.CheckStatusResponse
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(100 * time.Millisecond) {
+		status, err = client.CheckStatus(context.Background(), &pb.CheckStatusRequest{PipelineUuid: response.PipelineUuid})
+		if err == nil && status.Status == pb.Status_STATUS_PREPARATION_ERROR {
+			break
+		}
+	}
+	if status == nil || status.Status != pb.Status_STATUS_PREPARATION_ERROR {
+		t.Fatalf("CheckStatus() status = %v, want %v", status, pb.Status_STATUS_PREPARATION_ERROR)
+	}
+	if status.CorrelationId != correlationId {
+		t.Errorf("CheckStatus() correlationId = %v, want %v", status.CorrelationId, correlationId)
+	}
+
+	found := false
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %s isn't JSON: %v", line, err)
+		}
+		message, _ := entry["message"].(string)
+		if entry["pipelineId"] == response.PipelineUuid && strings.HasPrefix(message, "Prepare():") {
+			found = true
+			if entry["correlationId"] != correlationId {
+				t.Errorf("preparation error log line %s doesn't contain correlationId %v", line, correlationId)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no preparation error log line for the pipeline %s in %s", response.PipelineUuid, out.String())
+	}
+}
+
 func TestPlaygroundController_CheckStatus(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	ctx := context.Background()
 	pipelineId := uuid.New()
 	wantStatus := pb.Status_STATUS_FINISHED
+	wantMetadata := &pb.RunMetadata{SdkVersion: "openjdk version \"11.0.12\"", BeamVersion: "2.33.0", ResourceUsage: []*pb.ResourceUsage{{Stage: "Run", UserCpuMs: 1200, SystemCpuMs: 80, MaxRssKb: 262144}}}
+	wantTestReport := &pb.TestReport{TestsRun: 2, Passed: 1, Failed: 1, Failures: []*pb.TestFailure{{Name: "org.example.CalculatorTest.testDivide", Message: "java.lang.ArithmeticException: / by zero"}}}
 	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
@@ -219,11 +336,13 @@ func TestPlaygroundController_CheckStatus(t *testing.T) {
 		request *pb.CheckStatusRequest
 	}
 	tests := []struct {
-		name       string
-		prepare    func()
-		args       args
-		wantStatus *pb.Status
-		wantErr    bool
+		name           string
+		prepare        func()
+		args           args
+		wantStatus     *pb.Status
+		wantMetadata   *pb.RunMetadata
+		wantTestReport *pb.TestReport
+		wantErr        bool
 	}{
 		{
 			// Test case with calling CheckStatus method with incorrect pipelineId.
@@ -263,6 +382,37 @@ func TestPlaygroundController_CheckStatus(t *testing.T) {
 			wantStatus: &wantStatus,
 			wantErr:    false,
 		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which contains status and run metadata.
+			// As a result, want to receive an expected status and metadata.
+			name: "run metadata exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunMetadata, wantMetadata)
+			},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantStatus:   &wantStatus,
+			wantMetadata: wantMetadata,
+			wantErr:      false,
+		},
+		{
+			// Test case with calling CheckStatus method with pipelineId which contains status and results of unit tests.
+			// As a result, want to receive an expected status and results of unit tests.
+			name: "test report exists",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.TestReport, wantTestReport)
+			},
+			args: args{
+				ctx:     ctx,
+				request: &pb.CheckStatusRequest{PipelineUuid: pipelineId.String()},
+			},
+			wantStatus:     &wantStatus,
+			wantMetadata:   wantMetadata,
+			wantTestReport: wantTestReport,
+			wantErr:        false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -277,6 +427,14 @@ func TestPlaygroundController_CheckStatus(t *testing.T) {
 			if got != nil && !reflect.DeepEqual(got.Status, *tt.wantStatus) {
 				t.Errorf("PlaygroundController_CheckStatus() return status = %v, want status %v", got.Status, tt.wantStatus)
 			}
+			if got != nil && (got.GetMetadata().GetSdkVersion() != tt.wantMetadata.GetSdkVersion() ||
+				got.GetMetadata().GetBeamVersion() != tt.wantMetadata.GetBeamVersion() ||
+				!proto.Equal(&pb.RunMetadata{ResourceUsage: got.GetMetadata().GetResourceUsage()}, &pb.RunMetadata{ResourceUsage: tt.wantMetadata.GetResourceUsage()})) {
+				t.Errorf("PlaygroundController_CheckStatus() return metadata = %v, want metadata %v", got.Metadata, tt.wantMetadata)
+			}
+			if got != nil && got.GetTestReport().String() != tt.wantTestReport.String() {
+				t.Errorf("PlaygroundController_CheckStatus() return test report = %v, want test report %v", got.TestReport, tt.wantTestReport)
+			}
 		})
 	}
 }
@@ -451,6 +609,22 @@ func TestPlaygroundController_GetRunOutput(t *testing.T) {
 			want:    &pb.GetRunOutputResponse{Output: runOutput[1:]},
 			wantErr: false,
 		},
+		{
+			// Test case with calling GetRunOutput method with pipelineId which contains truncated run output.
+			// As a result want to receive response with the truncated flag.
+			name: "truncated run output",
+			prepare: func() {
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputIndex, 0)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, runOutput)
+				_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputTruncated, true)
+			},
+			args: args{
+				ctx:  ctx,
+				info: &pb.GetRunOutputRequest{PipelineUuid: pipelineId.String()},
+			},
+			want:    &pb.GetRunOutputResponse{Output: runOutput, Truncated: true},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -464,6 +638,9 @@ func TestPlaygroundController_GetRunOutput(t *testing.T) {
 				if !strings.EqualFold(got.Output, tt.want.Output) {
 					t.Errorf("GetRunOutput() got = %v, want %v", got.Output, tt.want.Output)
 				}
+				if got.Truncated != tt.want.Truncated {
+					t.Errorf("GetRunOutput() truncated = %v, want %v", got.Truncated, tt.want.Truncated)
+				}
 			}
 		})
 	}
@@ -737,3 +914,173 @@ func TestPlaygroundController_Cancel(t *testing.T) {
 		})
 	}
 }
+
+func TestPlaygroundController_ListRunHistory(t *testing.T) {
+	defer goleak.VerifyNone(t, opt)
+	ctx := context.Background()
+	exampleId := "SDK_JAVA/ListRunHistory"
+	for i := 0; i < defaultRunHistoryLimit+2; i++ {
+		if err := historyStore.Add(ctx, &pb.RunRecord{PipelineUuid: fmt.Sprint(i), ExampleId: exampleId, Status: pb.Status_STATUS_FINISHED}); err != nil {
+			t.Fatalf("error during add the run record: %s", err.Error())
+		}
+	}
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(bufDialer), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewPlaygroundServiceClient(conn)
+
+	tests := []struct {
+		name      string
+		info      *pb.ListRunHistoryRequest
+		wantCount int
+		wantFirst string
+		wantErr   bool
+	}{
+		{
+			// Test case with calling ListRunHistory method without example id.
+			// As a result, want to receive an error.
+			name:    "example id isn't provided",
+			info:    &pb.ListRunHistoryRequest{},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ListRunHistory method with negative limit.
+			// As a result, want to receive an error.
+			name:    "negative limit",
+			info:    &pb.ListRunHistoryRequest{ExampleId: exampleId, Limit: -1},
+			wantErr: true,
+		},
+		{
+			// Test case with calling ListRunHistory method with limit.
+			// As a result, want to receive the most recent runs up to the limit.
+			name:      "runs up to the limit",
+			info:      &pb.ListRunHistoryRequest{ExampleId: exampleId, Limit: 3},
+			wantCount: 3,
+			wantFirst: fmt.Sprint(defaultRunHistoryLimit + 1),
+		},
+		{
+			// Test case with calling ListRunHistory method without limit.
+			// As a result, want to receive the default number of the most recent runs.
+			name:      "default limit",
+			info:      &pb.ListRunHistoryRequest{ExampleId: exampleId},
+			wantCount: defaultRunHistoryLimit,
+			wantFirst: fmt.Sprint(defaultRunHistoryLimit + 1),
+		},
+		{
+			// Test case with calling ListRunHistory method for the example which wasn't run.
+			// As a result, want to receive no runs.
+			name:      "example without runs",
+			info:      &pb.ListRunHistoryRequest{ExampleId: "SDK_JAVA/NotRun"},
+			wantCount: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ListRunHistory(ctx, tt.info)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListRunHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got.Records) != tt.wantCount {
+				t.Errorf("ListRunHistory() got %d records, want %d", len(got.Records), tt.wantCount)
+			}
+			if tt.wantCount > 0 && got.Records[0].PipelineUuid != tt.wantFirst {
+				t.Errorf("ListRunHistory() the most recent record = %v, want the record of %s", got.Records[0], tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestPlaygroundController_ListRunHistoryDisabled(t *testing.T) {
+	controller := &playgroundController{}
+	if _, err := controller.ListRunHistory(context.Background(), &pb.ListRunHistoryRequest{ExampleId: "SDK_JAVA/Example"}); status.Code(err) != codes.NotFound {
+		t.Errorf("ListRunHistory() error = %v, want %s", err, codes.NotFound)
+	}
+}
+
+// fakeCatalog returns the code of examples by their paths
+type fakeCatalog map[string]string
+
+func (fc fakeCatalog) GetPrecompiledObject(_ context.Context, precompiledObjectPath string) (string, error) {
+	code, ok := fc[precompiledObjectPath]
+	if !ok {
+		return "", fmt.Errorf("example %s isn't found", precompiledObjectPath)
+	}
+	return code, nil
+}
+
+func TestPlaygroundController_runHistoryStore(t *testing.T) {
+	const exampleId = "SDK_JAVA/HelloWorld"
+	catalog := fakeCatalog{exampleId: "MOCK_CODE"}
+	tests := []struct {
+		name       string
+		controller *playgroundController
+		request    *pb.RunCodeRequest
+		wantStore  bool
+		wantRecord bool
+		wantErr    bool
+	}{
+		{
+			name:       "unchanged code of the example",
+			controller: &playgroundController{historyStore: run_history.NewLocalStore(1), catalog: catalog},
+			request:    &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, ExampleId: exampleId},
+			wantStore:  true,
+			wantRecord: true,
+		},
+		{
+			name:       "edited code of the example",
+			controller: &playgroundController{historyStore: run_history.NewLocalStore(1), catalog: catalog},
+			request:    &pb.RunCodeRequest{Code: "EDITED_CODE", Sdk: pb.Sdk_SDK_JAVA, ExampleId: exampleId},
+			wantStore:  true,
+			wantRecord: false,
+		},
+		{
+			name:       "example which isn't in the catalog",
+			controller: &playgroundController{historyStore: run_history.NewLocalStore(1), catalog: catalog},
+			request:    &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, ExampleId: "SDK_JAVA/Unknown"},
+			wantStore:  true,
+			wantRecord: false,
+			wantErr:    true,
+		},
+		{
+			name:       "code without example id",
+			controller: &playgroundController{historyStore: run_history.NewLocalStore(1), catalog: catalog},
+			request:    &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA},
+			wantStore:  false,
+		},
+		{
+			name:       "run history is disabled",
+			controller: &playgroundController{catalog: catalog},
+			request:    &pb.RunCodeRequest{Code: "MOCK_CODE", Sdk: pb.Sdk_SDK_JAVA, ExampleId: exampleId},
+			wantStore:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := tt.controller.runHistoryStore(tt.request)
+			if (store != nil) != tt.wantStore {
+				t.Fatalf("runHistoryStore() = %v, want store %v", store, tt.wantStore)
+			}
+			if store == nil {
+				return
+			}
+			ctx := context.Background()
+			err := store.Add(ctx, &pb.RunRecord{PipelineUuid: "1", ExampleId: tt.request.ExampleId, Status: pb.Status_STATUS_FINISHED})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			records, err := tt.controller.historyStore.List(ctx, tt.request.ExampleId, 1)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if (len(records) == 1) != tt.wantRecord {
+				t.Errorf("List() = %v, want record %v", records, tt.wantRecord)
+			}
+		})
+	}
+}
@@ -20,14 +20,31 @@ import (
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/cache/local"
 	"beam.apache.org/playground/backend/internal/cache/redis"
+	"beam.apache.org/playground/backend/internal/cloud_bucket"
+	"beam.apache.org/playground/backend/internal/code_processing"
+	"beam.apache.org/playground/backend/internal/correlation"
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/metrics"
+	"beam.apache.org/playground/backend/internal/precompiled_refresh"
+	"beam.apache.org/playground/backend/internal/rate_limiter"
+	"beam.apache.org/playground/backend/internal/run_history"
+	"beam.apache.org/playground/backend/internal/setup_tools/life_cycle"
 	"context"
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"google.golang.org/grpc"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
-// runServer is starting http server wrapped on grpc
+// rateLimitedMethods are methods which submit code runs
+var rateLimitedMethods = []string{
+	"/api.v1.PlaygroundService/RunCode",
+}
+
+// runServer is starting http server wrapped on grpc.
+// On SIGTERM or SIGINT stops accepting new code runs and drains the code processing in progress before returning.
 func runServer() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -37,20 +54,43 @@ func runServer() error {
 		return err
 	}
 
-	logger.SetupLogger(ctx, envService.ApplicationEnvs.LaunchSite(), envService.ApplicationEnvs.GoogleProjectId())
+	logger.SetupLogger(ctx, envService.ApplicationEnvs.LaunchSite(), envService.ApplicationEnvs.GoogleProjectId(), envService.ApplicationEnvs.LogFormat())
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(getGrpcServerOptions(ctx, envService.ApplicationEnvs)...)
 
 	cacheService, err := setupCache(ctx, envService.ApplicationEnvs)
 	if err != nil {
 		return err
 	}
+
+	historyStore, err := setupRunHistory(ctx, envService.ApplicationEnvs)
+	if err != nil {
+		return err
+	}
+
+	errChan := make(chan error)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(signals)
+
+	if envService.ApplicationEnvs.MetricsEnvs().Enabled() {
+		metrics.Setup()
+		cacheService = metrics.NewCache(cacheService)
+		go listenMetrics(ctx, errChan, envService.ApplicationEnvs.MetricsEnvs())
+	}
+
 	pb.RegisterPlaygroundServiceServer(grpcServer, &playgroundController{
-		env:          envService,
-		cacheService: cacheService,
+		env:           envService,
+		cacheService:  cacheService,
+		workspacePool: setupWorkspacePool(ctx, envService),
+		historyStore:  historyStore,
+		catalog:       cloud_bucket.New(),
 	})
 
-	errChan := make(chan error)
+	if envService.ApplicationEnvs.RefreshPrecompiledObjects() {
+		go refreshPrecompiledObjects(ctx, envService, historyStore)
+	}
 
 	switch envService.NetworkEnvs.Protocol() {
 	case "TCP":
@@ -64,6 +104,12 @@ func runServer() error {
 		select {
 		case err := <-errChan:
 			return err
+		case sig := <-signals:
+			drainPeriod := envService.ApplicationEnvs.ShutdownDrainPeriod()
+			logger.Infof("%s signal received; draining code processing for %s...\n", sig, drainPeriod)
+			canceled := code_processing.Shutdown(drainPeriod)
+			logger.Infof("code processing is drained, %d runs were canceled; stopping...\n", canceled)
+			return nil
 		case <-ctx.Done():
 			logger.Info("interrupt signal received; stopping...")
 			return nil
@@ -87,6 +133,18 @@ func setupEnvironment() (*environment.Environment, error) {
 	return environment.NewEnvironment(*networkEnvs, *beamEnvs, *appEnvs), nil
 }
 
+// getGrpcServerOptions returns grpc server options according to application environment.
+// Adds interceptor which attaches the correlation id to each call.
+// If rate limiting is enabled, adds interceptor which limits code runs of each client.
+func getGrpcServerOptions(ctx context.Context, appEnv environment.ApplicationEnvs) []grpc.ServerOption {
+	interceptors := []grpc.UnaryServerInterceptor{correlation.UnaryInterceptor()}
+	if rateLimitEnvs := appEnv.RateLimitEnvs(); rateLimitEnvs.Enabled() {
+		limiter := rate_limiter.New(ctx, rateLimitEnvs.Rate(), rateLimitEnvs.Burst(), rateLimitEnvs.ClientKeys(), rateLimitEnvs.Exemptions(), rateLimitEnvs.TrustedProxies())
+		interceptors = append(interceptors, limiter.UnaryInterceptor(rateLimitedMethods...))
+	}
+	return []grpc.ServerOption{grpc.ChainUnaryInterceptor(interceptors...)}
+}
+
 // getGrpcWebOptions returns grpcweb options needed to configure wrapper
 func getGrpcWebOptions() []grpcweb.Option {
 	return []grpcweb.Option{
@@ -99,6 +157,22 @@ func getGrpcWebOptions() []grpcweb.Option {
 
 }
 
+// setupWorkspacePool creates the pool of pipeline workspaces if it is enabled by application environment
+func setupWorkspacePool(ctx context.Context, envService *environment.Environment) *life_cycle.WorkspacePool {
+	appEnv, sdkEnv := envService.ApplicationEnvs, envService.BeamSdkEnvs
+	if appEnv.WorkspacePoolSize() <= 0 {
+		return nil
+	}
+	return life_cycle.NewWorkspacePool(ctx, sdkEnv.ApacheBeamSdk, appEnv.WorkspacePoolSize(), appEnv.WorkingDir(), appEnv.PipelinesFolder(), sdkEnv.PreparedModDir())
+}
+
+// refreshPrecompiledObjects reruns examples of the catalog and stores their outputs into the cloud storage
+func refreshPrecompiledObjects(ctx context.Context, envService *environment.Environment, historyStore run_history.Store) {
+	if _, err := precompiled_refresh.Run(ctx, cloud_bucket.New(), historyStore, &envService.ApplicationEnvs, &envService.BeamSdkEnvs); err != nil {
+		logger.Errorf("error during refresh precompiled objects: %s\n", err.Error())
+	}
+}
+
 // setupCache constructs required cache by application environment
 func setupCache(ctx context.Context, appEnv environment.ApplicationEnvs) (cache.Cache, error) {
 	switch appEnv.CacheEnvs().CacheType() {
@@ -109,6 +183,21 @@ func setupCache(ctx context.Context, appEnv environment.ApplicationEnvs) (cache.
 	}
 }
 
+// setupRunHistory constructs the store of the run history of examples if it is enabled by application environment.
+// The run history is kept in Redis if the remote cache is used, so it outlives restarts of the server.
+func setupRunHistory(ctx context.Context, appEnv environment.ApplicationEnvs) (run_history.Store, error) {
+	runHistoryEnvs := appEnv.RunHistoryEnvs()
+	if !runHistoryEnvs.Enabled() {
+		return nil, nil
+	}
+	switch appEnv.CacheEnvs().CacheType() {
+	case "remote":
+		return run_history.NewRedisStore(ctx, appEnv.CacheEnvs().Address(), runHistoryEnvs.Retention())
+	default:
+		return run_history.NewLocalStore(runHistoryEnvs.Retention()), nil
+	}
+}
+
 func main() {
 	err := runServer()
 	if err != nil {
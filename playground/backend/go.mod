@@ -24,9 +24,12 @@ require (
 	github.com/go-redis/redismock/v8 v8.0.6
 	github.com/google/uuid v1.3.0
 	github.com/improbable-eng/grpc-web v0.14.1
+	github.com/prometheus/client_golang v1.11.0
 	github.com/rs/cors v1.8.0
 	go.uber.org/goleak v1.1.12
 	google.golang.org/api v0.58.0
+	google.golang.org/genproto v0.0.0-20211016002631-37fc39342514
 	google.golang.org/grpc v1.41.0
 	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v3 v3.0.1
 )
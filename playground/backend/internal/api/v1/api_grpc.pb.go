@@ -61,6 +61,8 @@ type PlaygroundServiceClient interface {
 	GetPrecompiledObjectOutput(ctx context.Context, in *GetPrecompiledObjectOutputRequest, opts ...grpc.CallOption) (*GetPrecompiledObjectOutputResponse, error)
 	// Get the logs of an PrecompiledObject.
 	GetPrecompiledObjectLogs(ctx context.Context, in *GetPrecompiledObjectLogsRequest, opts ...grpc.CallOption) (*GetPrecompiledObjectLogsResponse, error)
+	// Get the most recent runs of the example of the catalog.
+	ListRunHistory(ctx context.Context, in *ListRunHistoryRequest, opts ...grpc.CallOption) (*ListRunHistoryResponse, error)
 }
 
 type playgroundServiceClient struct {
@@ -188,6 +190,15 @@ func (c *playgroundServiceClient) GetPrecompiledObjectLogs(ctx context.Context,
 	return out, nil
 }
 
+func (c *playgroundServiceClient) ListRunHistory(ctx context.Context, in *ListRunHistoryRequest, opts ...grpc.CallOption) (*ListRunHistoryResponse, error) {
+	out := new(ListRunHistoryResponse)
+	err := c.cc.Invoke(ctx, "/api.v1.PlaygroundService/ListRunHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlaygroundServiceServer is the server API for PlaygroundService service.
 // All implementations should embed UnimplementedPlaygroundServiceServer
 // for forward compatibility
@@ -218,6 +229,8 @@ type PlaygroundServiceServer interface {
 	GetPrecompiledObjectOutput(context.Context, *GetPrecompiledObjectOutputRequest) (*GetPrecompiledObjectOutputResponse, error)
 	// Get the logs of an PrecompiledObject.
 	GetPrecompiledObjectLogs(context.Context, *GetPrecompiledObjectLogsRequest) (*GetPrecompiledObjectLogsResponse, error)
+	// Get the most recent runs of the example of the catalog.
+	ListRunHistory(context.Context, *ListRunHistoryRequest) (*ListRunHistoryResponse, error)
 }
 
 // UnimplementedPlaygroundServiceServer should be embedded to have forward compatible implementations.
@@ -263,6 +276,9 @@ func (UnimplementedPlaygroundServiceServer) GetPrecompiledObjectOutput(context.C
 func (UnimplementedPlaygroundServiceServer) GetPrecompiledObjectLogs(context.Context, *GetPrecompiledObjectLogsRequest) (*GetPrecompiledObjectLogsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPrecompiledObjectLogs not implemented")
 }
+func (UnimplementedPlaygroundServiceServer) ListRunHistory(context.Context, *ListRunHistoryRequest) (*ListRunHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRunHistory not implemented")
+}
 
 // UnsafePlaygroundServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PlaygroundServiceServer will
@@ -509,6 +525,24 @@ func _PlaygroundService_GetPrecompiledObjectLogs_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaygroundService_ListRunHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRunHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaygroundServiceServer).ListRunHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.v1.PlaygroundService/ListRunHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaygroundServiceServer).ListRunHistory(ctx, req.(*ListRunHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PlaygroundService_ServiceDesc is the grpc.ServiceDesc for PlaygroundService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -568,6 +602,10 @@ var PlaygroundService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPrecompiledObjectLogs",
 			Handler:    _PlaygroundService_GetPrecompiledObjectLogs_Handler,
 		},
+		{
+			MethodName: "ListRunHistory",
+			Handler:    _PlaygroundService_ListRunHistory_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/api.proto",
@@ -35,6 +35,12 @@ const (
 	// RunError is used to keep run code error value
 	RunError SubKey = "RUN_ERROR"
 
+	// RunOutputTruncated is used to keep the flag that the run code output value is truncated
+	RunOutputTruncated SubKey = "RUN_OUTPUT_TRUNCATED"
+
+	// RunErrorTruncated is used to keep the flag that the run code error value is truncated
+	RunErrorTruncated SubKey = "RUN_ERROR_TRUNCATED"
+
 	// ValidationOutput is used to keep validation output value
 	ValidationOutput SubKey = "VALIDATION_OUTPUT"
 
@@ -44,6 +50,24 @@ const (
 	// CompileOutput is used to keep compilation output value
 	CompileOutput SubKey = "COMPILE_OUTPUT"
 
+	// CorrelationId is used to keep the correlation id of the request which started the code processing
+	CorrelationId SubKey = "CORRELATION_ID"
+
+	// ExampleId is used to keep the id of the example of the catalog which the code is from
+	ExampleId SubKey = "EXAMPLE_ID"
+
+	// RunMetadata is used to keep versions of the sdk and the runner which are captured at the start of the run
+	RunMetadata SubKey = "RUN_METADATA"
+
+	// TestReport is used to keep results of unit tests which are parsed after the run
+	TestReport SubKey = "TEST_REPORT"
+
+	// SourceMap is used to keep the map from lines of the prepared code to lines of the original code
+	SourceMap SubKey = "SOURCE_MAP"
+
+	// SetupRetries is used to keep the number of retries of the setup command after transient failures
+	SetupRetries SubKey = "SETUP_RETRIES"
+
 	// Canceled is used to keep the canceled status
 	Canceled SubKey = "CANCELED"
 
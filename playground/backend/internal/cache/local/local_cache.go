@@ -17,6 +17,7 @@ package local
 
 import (
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/metrics"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
@@ -65,6 +66,7 @@ func (lc *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cach
 		delete(lc.items[pipelineId], subKey)
 		delete(lc.pipelinesExpiration, pipelineId)
 		lc.Unlock()
+		metrics.CacheEvicted(1)
 		return nil, fmt.Errorf("value with pipelineId: %s and subKey: %s is expired", pipelineId, subKey)
 	}
 
@@ -142,4 +144,5 @@ func (lc *Cache) clearItems(pipelines []uuid.UUID) {
 		delete(lc.items, pipeline)
 		delete(lc.pipelinesExpiration, pipeline)
 	}
+	metrics.CacheEvicted(len(pipelines))
 }
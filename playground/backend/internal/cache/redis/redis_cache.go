@@ -19,6 +19,7 @@ import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/cache"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/source_map"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -101,12 +102,18 @@ func unmarshalBySubKey(subKey cache.SubKey, value string) (result interface{}, e
 	switch subKey {
 	case cache.Status:
 		result = new(pb.Status)
-	case cache.RunOutput, cache.RunError, cache.ValidationOutput, cache.PreparationOutput, cache.CompileOutput, cache.Logs:
+	case cache.RunOutput, cache.RunError, cache.ValidationOutput, cache.PreparationOutput, cache.CompileOutput, cache.Logs, cache.CorrelationId, cache.ExampleId:
 		result = ""
-	case cache.Canceled:
+	case cache.Canceled, cache.RunOutputTruncated, cache.RunErrorTruncated:
 		result = false
-	case cache.RunOutputIndex, cache.LogsIndex:
+	case cache.RunOutputIndex, cache.LogsIndex, cache.SetupRetries:
 		result = 0
+	case cache.RunMetadata:
+		result = new(pb.RunMetadata)
+	case cache.TestReport:
+		result = new(pb.TestReport)
+	case cache.SourceMap:
+		result = new(source_map.SourceMap)
 	}
 	err = json.Unmarshal([]byte(value), &result)
 	if err != nil {
@@ -18,6 +18,7 @@ package redis
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/source_map"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -292,6 +293,12 @@ func Test_unmarshalBySubKey(t *testing.T) {
 	statusValue, _ := json.Marshal(status)
 	output := "MOCK_OUTPUT"
 	outputValue, _ := json.Marshal(output)
+	metadata := &pb.RunMetadata{SdkVersion: "go version go1.16.4 linux/amd64", BeamVersion: "2.33.0"}
+	metadataValue, _ := json.Marshal(metadata)
+	testReport := &pb.TestReport{TestsRun: 2, Passed: 1, Failed: 1, Failures: []*pb.TestFailure{{Name: "TestDivide", Message: "integer divide by zero"}}}
+	testReportValue, _ := json.Marshal(testReport)
+	sourceMap := &source_map.SourceMap{Files: map[string]map[int]int{"Main.java": {1: 1, 2: 1, 3: 2}}}
+	sourceMapValue, _ := json.Marshal(sourceMap)
 	type args struct {
 		ctx    context.Context
 		subKey cache.SubKey
@@ -331,6 +338,33 @@ func Test_unmarshalBySubKey(t *testing.T) {
 			want:    output,
 			wantErr: false,
 		},
+		{
+			name: "runMetadata subKey",
+			args: args{
+				subKey: cache.RunMetadata,
+				value:  string(metadataValue),
+			},
+			want:    metadata,
+			wantErr: false,
+		},
+		{
+			name: "testReport subKey",
+			args: args{
+				subKey: cache.TestReport,
+				value:  string(testReportValue),
+			},
+			want:    testReport,
+			wantErr: false,
+		},
+		{
+			name: "sourceMap subKey",
+			args: args{
+				subKey: cache.SourceMap,
+				value:  string(sourceMapValue),
+			},
+			want:    sourceMap,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
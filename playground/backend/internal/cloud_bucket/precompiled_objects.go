@@ -27,23 +27,33 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 )
 
 const (
-	BucketName       = "playground-precompiled-objects"
-	OutputExtension  = "output"
-	LogsExtension    = "log"
-	MetaInfoName     = "meta.info"
-	Timeout          = time.Second * 10
-	javaExtension    = "java"
-	goExtension      = "go"
-	pyExtension      = "py"
-	scioExtension    = "scala"
-	separatorsNumber = 2
+	BucketName        = "playground-precompiled-objects"
+	OutputExtension   = "output"
+	LogsExtension     = "log"
+	ErrorExtension    = "error"
+	MetadataExtension = "metadata"
+	MetaInfoName      = "meta.info"
+	Timeout           = time.Second * 10
+	javaExtension     = "java"
+	goExtension       = "go"
+	pyExtension       = "py"
+	scioExtension     = "scala"
+	yamlExtension     = "yaml"
+	kotlinExtension   = "kt"
+	separatorsNumber  = 2
 )
 
+var exampleNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][\w.-]*$`)
+
+// ErrObjectNotExist is wrapped by errors of getting files of the example which don't exist in the bucket
+var ErrObjectNotExist = storage.ErrObjectNotExist
+
 type ObjectInfo struct {
 	Name            string
 	CloudPath       string
@@ -128,6 +138,53 @@ func (cd *CloudStorage) GetPrecompiledObjectLogs(ctx context.Context, precompile
 	return result, nil
 }
 
+// GetPrecompiledObjectError returns the error of the last refresh of the example, empty if the example passed
+func (cd *CloudStorage) GetPrecompiledObjectError(ctx context.Context, precompiledObjectPath string) (string, error) {
+	data, err := cd.getFileFromBucket(ctx, precompiledObjectPath, ErrorExtension)
+	if err != nil {
+		return "", err
+	}
+	result := string(data)
+	return result, nil
+}
+
+// GetPrecompiledObjectMetadata returns versions of the sdk and the runner which produced the run output of the example
+func (cd *CloudStorage) GetPrecompiledObjectMetadata(ctx context.Context, precompiledObjectPath string) (*pb.RunMetadata, error) {
+	data, err := cd.getFileFromBucket(ctx, precompiledObjectPath, MetadataExtension)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &pb.RunMetadata{}
+	if err = json.Unmarshal(data, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// PutPrecompiledObjectFile writes data as the file of the example with the extension.
+// Writing to the bucket requires application default credentials.
+func (cd *CloudStorage) PutPrecompiledObjectFile(ctx context.Context, precompiledObjectPath string, extension string, data []byte) error {
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return fmt.Errorf("storage.NewClient: %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	filePath := getFullFilePath(precompiledObjectPath, extension)
+	wc := client.Bucket(BucketName).Object(filePath).NewWriter(ctx)
+	if _, err = wc.Write(data); err != nil {
+		_ = wc.Close()
+		return fmt.Errorf("Object(%q).NewWriter: %v", filePath, err)
+	}
+	if err = wc.Close(); err != nil {
+		return fmt.Errorf("Object(%q).Close: %v", filePath, err)
+	}
+	return nil
+}
+
 // GetPrecompiledObjects returns stored at the cloud storage bucket precompiled objects for the target category
 func (cd *CloudStorage) GetPrecompiledObjects(ctx context.Context, targetSdk pb.Sdk, targetCategory string) (*SdkToCategories, error) {
 	client, err := storage.NewClient(ctx, option.WithoutAuthentication())
@@ -220,6 +277,13 @@ func appendPrecompiledObject(objectInfo ObjectInfo, sdkToCategories *SdkToCatego
 	categoryToPrecompiledObjects[categoryName] = append(objects, objectInfo)
 }
 
+// IsExamplePath checks that the path is a well-formed path of the example of the catalog of the sdk,
+// i.e. the sdk name and the name of the example folder, e.g. "SDK_JAVA/HelloWorld"
+func IsExamplePath(sdk pb.Sdk, path string) bool {
+	parts := strings.Split(path, string(os.PathSeparator))
+	return len(parts) == 2 && parts[0] == sdk.String() && exampleNameRegexp.MatchString(parts[1])
+}
+
 // getFileFromBucket receives the file from the bucket by its name
 func (cd *CloudStorage) getFileFromBucket(ctx context.Context, pathToObject string, extension string) ([]byte, error) {
 	client, err := storage.NewClient(ctx, option.WithoutAuthentication())
@@ -236,7 +300,7 @@ func (cd *CloudStorage) getFileFromBucket(ctx context.Context, pathToObject stri
 	filePath := getFullFilePath(pathToObject, extension)
 	rc, err := bucket.Object(filePath).NewReader(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Object(%q).NewReader: %v", filePath, err)
+		return nil, fmt.Errorf("Object(%q).NewReader: %w", filePath, err)
 	}
 	defer rc.Close()
 
@@ -260,6 +324,10 @@ func getFileExtensionBySdk(precompiledObjectPath string) (string, error) {
 		extension = goExtension
 	case pb.Sdk_SDK_SCIO.String():
 		extension = scioExtension
+	case pb.Sdk_SDK_YAML.String():
+		extension = yamlExtension
+	case pb.Sdk_SDK_KOTLIN.String():
+		extension = kotlinExtension
 	default:
 		return "", fmt.Errorf("")
 	}
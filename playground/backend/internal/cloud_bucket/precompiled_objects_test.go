@@ -149,6 +149,51 @@ func Test_isPathToPrecompiledObjectFile(t *testing.T) {
 	}
 }
 
+func TestIsExamplePath(t *testing.T) {
+	type args struct {
+		sdk  pb.Sdk
+		path string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Test if path is valid",
+			args: args{sdk: pb.Sdk_SDK_JAVA, path: "SDK_JAVA/HelloWorld"},
+			want: true,
+		},
+		{
+			name: "Test if path is of another sdk",
+			args: args{sdk: pb.Sdk_SDK_JAVA, path: "SDK_GO/HelloWorld"},
+			want: false,
+		},
+		{
+			name: "Test if path is a file of the example",
+			args: args{sdk: pb.Sdk_SDK_JAVA, path: "SDK_JAVA/HelloWorld/HelloWorld.java"},
+			want: false,
+		},
+		{
+			name: "Test if path goes out of the sdk folder",
+			args: args{sdk: pb.Sdk_SDK_JAVA, path: "SDK_JAVA/.."},
+			want: false,
+		},
+		{
+			name: "Test if path is arbitrary text",
+			args: args{sdk: pb.Sdk_SDK_JAVA, path: "SDK_JAVA/Hello World!"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsExamplePath(tt.args.sdk, tt.args.path); got != tt.want {
+				t.Errorf("IsExamplePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_appendPrecompiledObject(t *testing.T) {
 	type args struct {
 		objectInfo      ObjectInfo
@@ -23,24 +23,39 @@ import (
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/metrics"
+	"beam.apache.org/playground/backend/internal/preparers"
+	"beam.apache.org/playground/backend/internal/run_history"
 	"beam.apache.org/playground/backend/internal/setup_tools/builder"
+	"beam.apache.org/playground/backend/internal/source_map"
 	"beam.apache.org/playground/backend/internal/streaming"
+	"beam.apache.org/playground/backend/internal/test_report"
 	"beam.apache.org/playground/backend/internal/utils"
 	"beam.apache.org/playground/backend/internal/validators"
 	"bytes"
 	"context"
 	"fmt"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
 	"io"
 	"os"
 	"os/exec"
 	"reflect"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
 const (
 	pauseDuration = 500 * time.Millisecond
+	sdkField      = "sdk"
+	stageField    = "stage"
+	validateStage = "Validate"
+	prepareStage  = "Prepare"
+	compileStage  = "Compile"
+	runStage      = "Run"
+	cleanupStage  = "Cleanup"
 )
 
 // Process validates, compiles and runs code by pipelineId.
@@ -48,30 +63,65 @@ const (
 // - In case of processing works more that timeout duration saves playground.Status_STATUS_RUN_TIMEOUT as cache.Status into cache.
 // - In case of code processing has been canceled saves playground.Status_STATUS_CANCELED as cache.Status into cache.
 // - In case of validation step is failed saves playground.Status_STATUS_VALIDATION_ERROR as cache.Status into cache.
+// - In case of prepare step is completed with no errors saves the summary of changes of the code and warnings about skipped preparers as cache.PreparationOutput
+//   and the map from lines of the prepared code to lines of the original code as cache.SourceMap into cache.
+//   Warnings of preparers about the code are added to cache.RunMetadata.
+// - Line numbers of the prepared code in compile logs and run logs of failed steps are replaced with lines of the original code.
 // - In case of compile step is failed saves playground.Status_STATUS_COMPILE_ERROR as cache.Status and compile logs as cache.CompileOutput into cache.
 // - In case of compile step is completed with no errors saves compile output as cache.CompileOutput into cache.
 // - In case of run step is failed saves playground.Status_STATUS_RUN_ERROR as cache.Status and run logs as cache.RunError into cache.
 // - In case of run step is completed with no errors saves playground.Status_STATUS_FINISHED as cache.Status and run output as cache.RunOutput into cache.
+// - Once processes of the compile step or the run step are finished adds their CPU time and peak memory to cache.RunMetadata
+//   before the status of the step is saved.
+// - In case of the server shuts down before the code processing starts saves playground.Status_STATUS_REJECTED_BY_SHUTDOWN as cache.Status into cache.
+// - In case of the server shuts down and the code processing isn't finished during the drain period kills its processes and
+//   saves playground.Status_STATUS_CANCELED_BY_SHUTDOWN as cache.Status and the partial error output of the run as cache.RunError into cache.
 // At the end of this method deletes all created folders.
-func Process(ctx context.Context, cacheService cache.Cache, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs, pipelineOptions string) {
+// Each log line of the code processing carries pipelineId, sdk and stage fields
+// together with fields of the contextual logger of ctx (e.g. the correlation id of the request).
+// Records metrics of the code processing: duration of each step, resources of compile and run processes and the terminal status.
+// If the code is an example of the catalog (cache.ExampleId) and historyStore isn't nil, adds the record of the code processing
+// with its terminal status, durations of steps and the beginning of the error output to the run history of the example.
+func Process(ctx context.Context, cacheService cache.Cache, historyStore run_history.Store, lc *fs_tool.LifeCycle, pipelineId uuid.UUID, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs, pipelineOptions string, overrides preparers.Overrides) {
+	runLogger := logger.FromContext(ctx).WithPipelineId(pipelineId.String()).WithFields(logger.Fields{sdkField: sdkEnv.ApacheBeamSdk.String()})
+	ctx = logger.NewContext(ctx, runLogger)
+	durations := &stageDurations{}
+	ctx = withStageDurations(ctx, durations)
 	pipelineLifeCycleCtx, finishCtxFunc := context.WithTimeout(ctx, appEnv.PipelineExecuteTimeout())
+	metrics.RunStarted(sdkEnv.ApacheBeamSdk.String())
 	defer func(lc *fs_tool.LifeCycle) {
 		finishCtxFunc()
-		DeleteFolders(pipelineId, lc)
+		if inFlight.isCanceled(pipelineId) {
+			_ = finishByShutdown(ctx, pipelineId, cacheService)
+		}
+		deleteFolders(runLogger.WithFields(logger.Fields{stageField: cleanupStage}), lc)
+		metrics.RunFinished(sdkEnv.ApacheBeamSdk.String(), terminalStatus(ctx, cacheService, pipelineId).String())
+		recordRunHistory(ctx, cacheService, historyStore, pipelineId, sdkEnv, durations)
+		inFlight.finish(pipelineId)
 	}(lc)
 
+	if !inFlight.start(pipelineId, finishCtxFunc) {
+		_ = rejectByShutdown(ctx, pipelineId, cacheService)
+		return
+	}
+
 	cancelChannel := make(chan bool, 1)
 
 	var validationResults sync.Map
 
 	go cancelCheck(pipelineLifeCycleCtx, pipelineId, cancelChannel, cacheService)
 
+	// versions are captured at the start of the run, so the result of the run can be traced to them later
+	if metadata := sdkEnv.RunMetadata(); metadata != nil {
+		_ = utils.SetToCache(ctx, cacheService, pipelineId, cache.RunMetadata, metadata)
+	}
+
 	executor := validateStep(ctx, cacheService, &lc.Paths, pipelineId, sdkEnv, pipelineLifeCycleCtx, &validationResults, cancelChannel)
 	if executor == nil {
 		return
 	}
 
-	executor = prepareStep(ctx, cacheService, &lc.Paths, pipelineId, sdkEnv, pipelineLifeCycleCtx, &validationResults, cancelChannel)
+	executor = prepareStep(ctx, cacheService, &lc.Paths, pipelineId, sdkEnv, pipelineLifeCycleCtx, &validationResults, overrides, cancelChannel)
 	if executor == nil {
 		return
 	}
@@ -86,10 +136,12 @@ func Process(ctx context.Context, cacheService cache.Cache, lc *fs_tool.LifeCycl
 	}
 
 	// Run/RunTest
-	runStep(ctx, cacheService, &lc.Paths, pipelineId, isUnitTest, sdkEnv, pipelineOptions, pipelineLifeCycleCtx, cancelChannel)
+	runStep(ctx, cacheService, &lc.Paths, pipelineId, isUnitTest, sdkEnv, appEnv.OutputLimitEnvs(), pipelineOptions, pipelineLifeCycleCtx, cancelChannel)
 }
 
-func runStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeCyclePaths, pipelineId uuid.UUID, isUnitTest bool, sdkEnv *environment.BeamEnvs, pipelineOptions string, pipelineLifeCycleCtx context.Context, cancelChannel chan bool) {
+func runStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeCyclePaths, pipelineId uuid.UUID, isUnitTest bool, sdkEnv *environment.BeamEnvs, outputLimitEnvs *environment.OutputLimitEnvs, pipelineOptions string, pipelineLifeCycleCtx context.Context, cancelChannel chan bool) {
+	ctx, pipelineLifeCycleCtx = withStage(ctx, runStage), withStage(pipelineLifeCycleCtx, runStage)
+	defer observeStage(ctx, sdkEnv.ApacheBeamSdk.String(), runStage, time.Now())
 	errorChannel, successChannel := createStatusChannels()
 	stopReadLogsChannel := make(chan bool, 1)
 	finishReadLogsChannel := make(chan bool, 1)
@@ -101,16 +153,23 @@ func runStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeC
 	} else {
 		executorBuilder, err = builder.Runner(paths, utils.ReduceWhiteSpacesToSinge(pipelineOptions), sdkEnv)
 	}
+	if preparers.IsMainClassError(err) {
+		_ = processMainClassError(err, pipelineId, cacheService, pipelineLifeCycleCtx)
+		return
+	}
 	if err != nil {
 		_ = processSetupError(err, pipelineId, cacheService, pipelineLifeCycleCtx)
 		return
 	}
 
 	executor := executorBuilder.Build()
-	logger.Infof("%s: Run()/Test() ...\n", pipelineId)
+	logger.FromContext(pipelineLifeCycleCtx).Infof("Run()/Test() ...\n")
 	runCmd := getExecuteCmd(isUnitTest, &executor, pipelineLifeCycleCtx)
-	var runError bytes.Buffer
-	runOutput := streaming.RunOutputWriter{Ctx: pipelineLifeCycleCtx, CacheService: cacheService, PipelineId: pipelineId}
+	var runErrorBuffer bytes.Buffer
+	runOutputWriter := streaming.RunOutputWriter{Ctx: pipelineLifeCycleCtx, CacheService: cacheService, PipelineId: pipelineId}
+	// stdout and stderr are limited independently
+	runOutput := limitOutput(pipelineLifeCycleCtx, &runOutputWriter, outputLimitEnvs, cacheService, pipelineId, cache.RunOutputTruncated, runCmd)
+	runError := limitOutput(pipelineLifeCycleCtx, &runErrorBuffer, outputLimitEnvs, cacheService, pipelineId, cache.RunErrorTruncated, runCmd)
 	go readLogFile(pipelineLifeCycleCtx, ctx, cacheService, paths.AbsoluteLogFilePath, pipelineId, stopReadLogsChannel, finishReadLogsChannel)
 
 	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_GO {
@@ -118,28 +177,38 @@ func runStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeC
 		file, err := os.Create(paths.AbsoluteLogFilePath)
 		if err != nil {
 			// If some error with creating a log file do the same as with other SDK.
-			logger.Errorf("%s: error during create log file (go sdk): %s", pipelineId, err.Error())
-			runCmdWithOutput(runCmd, &runOutput, &runError, successChannel, errorChannel)
+			logger.FromContext(pipelineLifeCycleCtx).Errorf("error during create log file (go sdk): %s", err.Error())
+			runCmdWithOutput(pipelineLifeCycleCtx, runCmd, runOutput, runError, successChannel, errorChannel)
 		} else {
 			// Use the log file to write all stdErr into it.
-			runCmdWithOutput(runCmd, &runOutput, file, successChannel, errorChannel)
+			runCmdWithOutput(pipelineLifeCycleCtx, runCmd, runOutput, limitOutput(pipelineLifeCycleCtx, file, outputLimitEnvs, cacheService, pipelineId, cache.RunErrorTruncated, runCmd), successChannel, errorChannel)
 		}
 	} else {
 		// Other SDKs write logs to the log file on their own.
-		runCmdWithOutput(runCmd, &runOutput, &runError, successChannel, errorChannel)
+		runCmdWithOutput(pipelineLifeCycleCtx, runCmd, runOutput, runError, successChannel, errorChannel)
 	}
 
 	// Start of the monitoring of background tasks (run step/cancellation/timeout)
 	ok, err := reconcileBackgroundTask(pipelineLifeCycleCtx, ctx, pipelineId, cacheService, cancelChannel, successChannel)
 	if err != nil {
+		if inFlight.isCanceled(pipelineId) {
+			// the command is killed by the shutdown, its partial error output is kept for the user
+			<-successChannel
+			_ = utils.SetToCache(ctx, cacheService, pipelineId, cache.RunError, string(toOriginalLines(ctx, cacheService, pipelineId, runErrorBuffer.Bytes())))
+			recordResourceUsage(ctx, cacheService, pipelineId, sdkEnv.ApacheBeamSdk.String(), runStage, commandUsage(runCmd))
+		}
 		return
 	}
+	recordResourceUsage(pipelineLifeCycleCtx, cacheService, pipelineId, sdkEnv.ApacheBeamSdk.String(), runStage, commandUsage(runCmd))
+	if isUnitTest {
+		saveTestReport(pipelineLifeCycleCtx, cacheService, paths, pipelineId, sdkEnv.ApacheBeamSdk)
+	}
 	if !ok {
 		// If unit test has some error then error output is placed as RunOutput
 		if isUnitTest {
 			output, err := GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunOutput, "")
 			if err == nil {
-				runError.Write([]byte(output))
+				runErrorBuffer.Write([]byte(output))
 			}
 
 		}
@@ -148,11 +217,12 @@ func runStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeC
 			// For Go SDK stdErr was redirected to the log file.
 			errData, err := os.ReadFile(paths.AbsoluteLogFilePath)
 			if err != nil {
-				logger.Errorf("%s: error during read errors from log file (go sdk): %s", pipelineId, err.Error())
+				logger.FromContext(pipelineLifeCycleCtx).Errorf("error during read errors from log file (go sdk): %s", err.Error())
 			}
-			runError.Write(errData)
+			runErrorBuffer.Write(errData)
 		}
-		_ = processRunError(pipelineLifeCycleCtx, errorChannel, runError.Bytes(), pipelineId, cacheService, stopReadLogsChannel, finishReadLogsChannel)
+		errorOutput := toOriginalLines(pipelineLifeCycleCtx, cacheService, pipelineId, runErrorBuffer.Bytes())
+		_ = processRunError(pipelineLifeCycleCtx, errorChannel, errorOutput, pipelineId, cacheService, stopReadLogsChannel, finishReadLogsChannel)
 		return
 	}
 	// Run step is finished and code is executed
@@ -160,30 +230,55 @@ func runStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeC
 }
 
 func compileStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeCyclePaths, pipelineId uuid.UUID, sdkEnv *environment.BeamEnvs, isUnitTest bool, pipelineLifeCycleCtx context.Context, cancelChannel chan bool) *executors.Executor {
+	ctx, pipelineLifeCycleCtx = withStage(ctx, compileStage), withStage(pipelineLifeCycleCtx, compileStage)
+	defer observeStage(ctx, sdkEnv.ApacheBeamSdk.String(), compileStage, time.Now())
 	errorChannel, successChannel := createStatusChannels()
 	var executor = executors.Executor{}
-	// This condition is used for cases when the playground doesn't compile source files. For the Python code and the Go Unit Tests
-	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_PYTHON || (sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_GO && isUnitTest) {
+	// This condition is used for cases when the playground doesn't compile source files. For the Python code, YAML pipelines and the Go Unit Tests
+	if sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_PYTHON || sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_YAML || (sdkEnv.ApacheBeamSdk == pb.Sdk_SDK_GO && isUnitTest) {
 		if err := processCompileSuccess(pipelineLifeCycleCtx, []byte(""), pipelineId, cacheService); err != nil {
 			return nil
 		}
 	} else { // in case of Java, Go (not unit test), Scala - need compile step
-		executorBuilder := builder.Compiler(paths, sdkEnv)
+		executorBuilder, err := builder.Compiler(paths, sdkEnv)
+		if err != nil {
+			_ = processSetupError(err, pipelineId, cacheService, pipelineLifeCycleCtx)
+			return nil
+		}
 		executor := executorBuilder.Build()
-		logger.Infof("%s: Compile() ...\n", pipelineId)
-		compileCmd := executor.Compile(pipelineLifeCycleCtx)
+		logger.FromContext(pipelineLifeCycleCtx).Infof("Compile() ...\n")
+		compileUsage := &stageUsage{}
+		compile := func(ctx context.Context, stdout, stderr io.Writer) error {
+			compileCmd := executor.Compile(ctx)
+			compileCmd.Stdout = stdout
+			compileCmd.Stderr = stderr
+			// the compiler is the leader of its process group, so the usage of processes forked by it is checked to be complete
+			compileCmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+			err := compileCmd.Start()
+			if err != nil {
+				return err
+			}
+			// the compiler daemons forked by the compiler are killed with it when the pipeline is finished
+			stopKill := killProcessGroupOnDone(ctx, compileCmd.Process.Pid)
+			err = compileCmd.Wait()
+			stopKill()
+			compileUsage.add(compileCmd)
+			return err
+		}
 		var compileError bytes.Buffer
 		var compileOutput bytes.Buffer
-		runCmdWithOutput(compileCmd, &compileOutput, &compileError, successChannel, errorChannel)
+		runSetupWithOutput(pipelineLifeCycleCtx, compile, sdkEnv.SetupRetries(), &compileOutput, &compileError, pipelineId, cacheService, successChannel, errorChannel)
 
 		// Start of the monitoring of background tasks (compile step/cancellation/timeout)
 		ok, err := reconcileBackgroundTask(pipelineLifeCycleCtx, ctx, pipelineId, cacheService, cancelChannel, successChannel)
 		if err != nil {
 			return nil
 		}
+		recordResourceUsage(pipelineLifeCycleCtx, cacheService, pipelineId, sdkEnv.ApacheBeamSdk.String(), compileStage, compileUsage.get())
 		if !ok { // Compile step is finished, but code couldn't be compiled (some typos for example)
 			err := <-errorChannel
-			_ = processErrorWithSavingOutput(pipelineLifeCycleCtx, err, compileError.Bytes(), pipelineId, cache.CompileOutput, cacheService, "Compile", pb.Status_STATUS_COMPILE_ERROR)
+			errorOutput := toOriginalLines(pipelineLifeCycleCtx, cacheService, pipelineId, compileError.Bytes())
+			_ = processErrorWithSavingOutput(pipelineLifeCycleCtx, err, errorOutput, pipelineId, cache.CompileOutput, cacheService, "Compile", pb.Status_STATUS_COMPILE_ERROR)
 			return nil
 		} // Compile step is finished and code is compiled
 		if err := processCompileSuccess(pipelineLifeCycleCtx, compileOutput.Bytes(), pipelineId, cacheService); err != nil {
@@ -193,15 +288,20 @@ func compileStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.L
 	return &executor
 }
 
-func prepareStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeCyclePaths, pipelineId uuid.UUID, sdkEnv *environment.BeamEnvs, pipelineLifeCycleCtx context.Context, validationResults *sync.Map, cancelChannel chan bool) *executors.Executor {
+func prepareStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeCyclePaths, pipelineId uuid.UUID, sdkEnv *environment.BeamEnvs, pipelineLifeCycleCtx context.Context, validationResults *sync.Map, overrides preparers.Overrides, cancelChannel chan bool) *executors.Executor {
+	ctx, pipelineLifeCycleCtx = withStage(ctx, prepareStage), withStage(pipelineLifeCycleCtx, prepareStage)
+	defer observeStage(ctx, sdkEnv.ApacheBeamSdk.String(), prepareStage, time.Now())
 	errorChannel, successChannel := createStatusChannels()
-	executorBuilder, err := builder.Preparer(paths, sdkEnv, validationResults)
+	executorBuilder, summary, err := builder.Preparer(paths, sdkEnv, validationResults, overrides, logger.FromContext(pipelineLifeCycleCtx))
 	if err != nil {
 		_ = processSetupError(err, pipelineId, cacheService, pipelineLifeCycleCtx)
 		return nil
 	}
+	if len(summary.Warnings) != 0 {
+		logger.FromContext(pipelineLifeCycleCtx).Warnf("Prepare(): %s\n", strings.Join(summary.Warnings, "; "))
+	}
 	executor := executorBuilder.Build()
-	logger.Infof("%s: Prepare() ...\n", pipelineId)
+	logger.FromContext(pipelineLifeCycleCtx).Infof("Prepare() ...\n")
 	prepareFunc := executor.Prepare()
 	go prepareFunc(successChannel, errorChannel, validationResults)
 
@@ -217,6 +317,21 @@ func prepareStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.L
 		return nil
 	}
 	// Prepare step is finished and code is prepared
+	if output := summary.String(); output != "" {
+		if err := utils.SetToCache(pipelineLifeCycleCtx, cacheService, pipelineId, cache.PreparationOutput, output); err != nil {
+			return nil
+		}
+	}
+	if summary.SourceMap != nil {
+		if err := utils.SetToCache(pipelineLifeCycleCtx, cacheService, pipelineId, cache.SourceMap, summary.SourceMap); err != nil {
+			return nil
+		}
+	}
+	if len(summary.Warnings) != 0 {
+		if err := saveWarnings(pipelineLifeCycleCtx, cacheService, pipelineId, summary.Warnings); err != nil {
+			return nil
+		}
+	}
 	if err := processSuccess(pipelineLifeCycleCtx, pipelineId, cacheService, "Prepare", pb.Status_STATUS_COMPILING); err != nil {
 		return nil
 	}
@@ -224,6 +339,8 @@ func prepareStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.L
 }
 
 func validateStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeCyclePaths, pipelineId uuid.UUID, sdkEnv *environment.BeamEnvs, pipelineLifeCycleCtx context.Context, validationResults *sync.Map, cancelChannel chan bool) *executors.Executor {
+	ctx, pipelineLifeCycleCtx = withStage(ctx, validateStage), withStage(pipelineLifeCycleCtx, validateStage)
+	defer observeStage(ctx, sdkEnv.ApacheBeamSdk.String(), validateStage, time.Now())
 	errorChannel, successChannel := createStatusChannels()
 	executorBuilder, err := builder.Validator(paths, sdkEnv)
 	if err != nil {
@@ -231,9 +348,17 @@ func validateStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.
 		return nil
 	}
 	executor := executorBuilder.Build()
-	logger.Infof("%s: Validate() ...\n", pipelineId)
+	logger.FromContext(pipelineLifeCycleCtx).Infof("Validate() ...\n")
 	validateFunc := executor.Validate()
-	go validateFunc(successChannel, errorChannel, validationResults)
+	go func() {
+		// gzip-compressed code is decompressed before validators read it, in the background so timeout and cancel are still checked
+		if err := preparers.DecompressGzip(paths.AbsoluteSourceFilePath, logger.FromContext(pipelineLifeCycleCtx)); err != nil {
+			errorChannel <- err
+			successChannel <- false
+			return
+		}
+		validateFunc(successChannel, errorChannel, validationResults)
+	}()
 
 	// Start of the monitoring of background tasks (validate function/cancellation/timeout)
 	ok, err := reconcileBackgroundTask(pipelineLifeCycleCtx, ctx, pipelineId, cacheService, cancelChannel, successChannel)
@@ -254,6 +379,22 @@ func validateStep(ctx context.Context, cacheService cache.Cache, paths *fs_tool.
 	return &executor
 }
 
+// terminalStatus returns the status of the code processing saved into cache.
+// The status is re-read after the code processing is finished, so the read isn't recorded as a cache hit.
+func terminalStatus(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) pb.Status {
+	value, err := cacheService.GetValue(metrics.WithoutCacheMetrics(ctx), pipelineId, cache.Status)
+	if err != nil {
+		return pb.Status_STATUS_UNSPECIFIED
+	}
+	status, _ := value.(pb.Status)
+	return status
+}
+
+// withStage returns a copy of ctx which carries the contextual logger with the stage field
+func withStage(ctx context.Context, stage string) context.Context {
+	return logger.NewContext(ctx, logger.FromContext(ctx).WithFields(logger.Fields{stageField: stage}))
+}
+
 func createStatusChannels() (chan error, chan bool) {
 	errorChannel := make(chan error, 1)
 	successChannel := make(chan bool, 1)
@@ -272,13 +413,23 @@ func getExecuteCmd(isUnitTest bool, executor *executors.Executor, ctxWithTimeout
 
 // processSetupError processes errors during the setting up an executor builder
 func processSetupError(err error, pipelineId uuid.UUID, cacheService cache.Cache, ctxWithTimeout context.Context) error {
-	logger.Errorf("%s: error during setup builder: %s\n", pipelineId, err.Error())
+	logger.FromContext(ctxWithTimeout).Errorf("error during setup builder: %s\n", err.Error())
 	if err = utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.Status, pb.Status_STATUS_ERROR); err != nil {
 		return err
 	}
 	return nil
 }
 
+// processMainClassError processes error about the class with main method of the code.
+// This method saves the error as cache.RunError and sets playground.Status_STATUS_RUN_ERROR status to the cache.
+func processMainClassError(err error, pipelineId uuid.UUID, cacheService cache.Cache, ctxWithTimeout context.Context) error {
+	logger.FromContext(ctxWithTimeout).Errorf("Run(): %s\n", err.Error())
+	if err := utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.RunError, err.Error()); err != nil {
+		return err
+	}
+	return utils.SetToCache(ctxWithTimeout, cacheService, pipelineId, cache.Status, pb.Status_STATUS_RUN_ERROR)
+}
+
 // GetProcessingOutput gets processing output value from cache by key and subKey.
 // In case key doesn't exist in cache - returns an errors.NotFoundError.
 // In case subKey doesn't exist in cache for the key - returns an errors.NotFoundError.
@@ -314,6 +465,73 @@ func GetProcessingStatus(ctx context.Context, cacheService cache.Cache, key uuid
 	return statusValue, nil
 }
 
+// IsOutputTruncated returns true if the output saved into the cache by key is truncated according to the flag with subKey.
+// In case the flag doesn't exist in cache - returns false.
+func IsOutputTruncated(ctx context.Context, cacheService cache.Cache, key uuid.UUID, subKey cache.SubKey) bool {
+	value, err := cacheService.GetValue(ctx, key, subKey)
+	if err != nil {
+		return false
+	}
+	truncated, _ := value.(bool)
+	return truncated
+}
+
+// GetCorrelationId returns the correlation id of the request which started the code processing.
+// In case the correlation id doesn't exist in cache - returns an empty string.
+func GetCorrelationId(ctx context.Context, cacheService cache.Cache, key uuid.UUID) string {
+	value, err := cacheService.GetValue(ctx, key, cache.CorrelationId)
+	if err != nil {
+		return ""
+	}
+	correlationId, _ := value.(string)
+	return correlationId
+}
+
+// GetRunMetadata returns versions of the sdk and the runner which are captured at the start of the code processing.
+// In case the metadata doesn't exist in cache - returns nil.
+func GetRunMetadata(ctx context.Context, cacheService cache.Cache, key uuid.UUID) *pb.RunMetadata {
+	value, err := cacheService.GetValue(ctx, key, cache.RunMetadata)
+	if err != nil {
+		return nil
+	}
+	metadata, _ := value.(*pb.RunMetadata)
+	return metadata
+}
+
+// saveWarnings adds warnings of preparers about the code to cache.RunMetadata of the code processing,
+// so they are returned by CheckStatus with the status of the run
+func saveWarnings(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, warnings []string) error {
+	metadata := &pb.RunMetadata{}
+	if cached := GetRunMetadata(ctx, cacheService, pipelineId); cached != nil {
+		// metadata of the sdk is shared by all runs, so the cached value is never changed in place
+		metadata = proto.Clone(cached).(*pb.RunMetadata)
+	}
+	metadata.Warnings = append(metadata.Warnings, warnings...)
+	return utils.SetToCache(ctx, cacheService, pipelineId, cache.RunMetadata, metadata)
+}
+
+// GetTestReport returns results of unit tests which are parsed after the run.
+// In case the report doesn't exist in cache - returns nil.
+func GetTestReport(ctx context.Context, cacheService cache.Cache, key uuid.UUID) *pb.TestReport {
+	value, err := cacheService.GetValue(ctx, key, cache.TestReport)
+	if err != nil {
+		return nil
+	}
+	report, _ := value.(*pb.TestReport)
+	return report
+}
+
+// GetSourceMap returns the map from lines of the prepared code to lines of the original code.
+// In case the map doesn't exist in cache - returns nil.
+func GetSourceMap(ctx context.Context, cacheService cache.Cache, key uuid.UUID) *source_map.SourceMap {
+	value, err := cacheService.GetValue(ctx, key, cache.SourceMap)
+	if err != nil {
+		return nil
+	}
+	sourceMap, _ := value.(*source_map.SourceMap)
+	return sourceMap
+}
+
 // GetLastIndex gets last index for run output or logs from cache by key.
 // In case key doesn't exist in cache - returns an errors.NotFoundError.
 // In case value from cache by key and subKey couldn't be converted to int - returns an errors.InternalError.
@@ -331,12 +549,47 @@ func GetLastIndex(ctx context.Context, cacheService cache.Cache, key uuid.UUID,
 	return int(convertedValue), nil
 }
 
-// runCmdWithOutput runs command with keeping stdOut and stdErr
-func runCmdWithOutput(cmd *exec.Cmd, stdOutput io.Writer, stdError io.Writer, successChannel chan bool, errorChannel chan error) {
+// limitOutput returns the writer which keeps at most the limit of outputLimitEnvs bytes of the output stream of cmd.
+// If the stream is truncated the truncated flag is saved into the cache with subKey.
+// If the stream exceeds the hard limit of outputLimitEnvs cmd is killed. Nil outputLimitEnvs means that the stream isn't limited.
+func limitOutput(ctx context.Context, writer io.Writer, outputLimitEnvs *environment.OutputLimitEnvs, cacheService cache.Cache, pipelineId uuid.UUID, subKey cache.SubKey, cmd *exec.Cmd) *streaming.TruncatingWriter {
+	truncatingWriter := &streaming.TruncatingWriter{
+		Writer: writer,
+		OnTruncate: func() {
+			logger.FromContext(ctx).Warnf("Run(): output is truncated, %s\n", subKey)
+			_ = utils.SetToCache(ctx, cacheService, pipelineId, subKey, true)
+		},
+		OnHardLimit: func() {
+			logger.FromContext(ctx).Warnf("Run(): output exceeds the hard limit, the run is stopped, %s\n", subKey)
+			if cmd.Process != nil {
+				_ = cmd.Process.Kill()
+			}
+		},
+	}
+	if outputLimitEnvs != nil {
+		truncatingWriter.Limit = outputLimitEnvs.Limit()
+		truncatingWriter.HardLimit = outputLimitEnvs.HardLimit()
+	}
+	return truncatingWriter
+}
+
+// runCmdWithOutput runs command with keeping stdOut and stdErr.
+// The command is started in its own process group which is killed when ctx is done,
+// so processes started by the code don't outlive the code processing.
+func runCmdWithOutput(ctx context.Context, cmd *exec.Cmd, stdOutput io.Writer, stdError io.Writer, successChannel chan bool, errorChannel chan error) {
 	cmd.Stdout = stdOutput
 	cmd.Stderr = stdError
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
 	go func(cmd *exec.Cmd, successChannel chan bool, errChannel chan error) {
-		err := cmd.Run()
+		err := cmd.Start()
+		if err == nil {
+			stopKill := killProcessGroupOnDone(ctx, cmd.Process.Pid)
+			err = cmd.Wait()
+			stopKill()
+		}
 		if err != nil {
 			errChannel <- err
 			successChannel <- false
@@ -346,8 +599,22 @@ func runCmdWithOutput(cmd *exec.Cmd, stdOutput io.Writer, stdError io.Writer, su
 	}(cmd, successChannel, errorChannel)
 }
 
+// killProcessGroupOnDone kills the process group of pid when ctx is done until the returned function is called
+func killProcessGroupOnDone(ctx context.Context, pid int) func() {
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = syscall.Kill(-pid, syscall.SIGKILL)
+		case <-stop:
+		}
+	}()
+	return func() { close(stop) }
+}
+
 // reconcileBackgroundTask waits when first background task finishes.
 // If finishes by canceling, timeout or context is done - returns error.
+// The status of the code processing canceled by the shutdown is set by Process after its partial output is saved.
 // If cmd operation (Validate/Prepare/Compile/Run/RunTest) finishes successfully with no error
 //  during step processing - returns true.
 // If cmd operation (Validate/Prepare/Compile/Run/RunTest) finishes successfully but with some error
@@ -355,6 +622,9 @@ func runCmdWithOutput(cmd *exec.Cmd, stdOutput io.Writer, stdError io.Writer, su
 func reconcileBackgroundTask(pipelineLifeCycleCtx, backgroundCtx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, cancelChannel, successChannel chan bool) (bool, error) {
 	select {
 	case <-pipelineLifeCycleCtx.Done():
+		if inFlight.isCanceled(pipelineId) {
+			return false, fmt.Errorf("%s: code processing was canceled by the shutdown", pipelineId)
+		}
 		_ = finishByTimeout(backgroundCtx, pipelineId, cacheService)
 		return false, fmt.Errorf("%s: context was done", pipelineId)
 	case <-cancelChannel:
@@ -379,7 +649,7 @@ func cancelCheck(ctx context.Context, pipelineId uuid.UUID, cancelChannel chan b
 		case <-ticker.C:
 			cancel, err := cacheService.GetValue(ctx, pipelineId, cache.Canceled)
 			if err != nil {
-				logger.Errorf("%s: Error during getting value from the cache: %s", pipelineId, err.Error())
+				logger.FromContext(ctx).Errorf("Error during getting value from the cache: %s", err.Error())
 			}
 			if cancel.(bool) {
 				cancelChannel <- true
@@ -432,7 +702,7 @@ func writeLogsToCache(ctx context.Context, cacheService cache.Cache, logFilePath
 	}
 	logs, err := os.ReadFile(logFilePath)
 	if err != nil {
-		logger.Errorf("%s: writeLogsToCache(): error during read from logs file: %s", pipelineId, err.Error())
+		logger.FromContext(ctx).Errorf("writeLogsToCache(): error during read from logs file: %s", err.Error())
 		return err
 	}
 	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Logs, string(logs))
@@ -440,17 +710,36 @@ func writeLogsToCache(ctx context.Context, cacheService cache.Cache, logFilePath
 
 // DeleteFolders removes all prepared folders for received LifeCycle
 func DeleteFolders(pipelineId uuid.UUID, lc *fs_tool.LifeCycle) {
-	logger.Infof("%s: DeleteFolders() ...\n", pipelineId)
+	deleteFolders(logger.WithPipelineId(pipelineId.String()).WithFields(logger.Fields{stageField: cleanupStage}), lc)
+}
+
+// deleteFolders removes all prepared folders for received LifeCycle with logging via received contextual logger
+func deleteFolders(log *logger.Entry, lc *fs_tool.LifeCycle) {
+	log.Info("DeleteFolders() ...\n")
 	if err := lc.DeleteFolders(); err != nil {
-		logger.Error("%s: DeleteFolders(): %s\n", pipelineId, err.Error())
+		log.Errorf("DeleteFolders(): %s\n", err.Error())
 	}
-	logger.Infof("%s: DeleteFolders() complete\n", pipelineId)
-	logger.Infof("%s: complete\n", pipelineId)
+	log.Info("DeleteFolders() complete\n")
+	log.Info("complete\n")
+}
+
+// finishByShutdown is used in case of the code processing was canceled because the server shuts down
+func finishByShutdown(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache) error {
+	logger.FromContext(ctx).Warnf("code processing was canceled by the shutdown of the server\n")
+
+	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_CANCELED_BY_SHUTDOWN)
+}
+
+// rejectByShutdown is used in case of the code processing wasn't started because the server shuts down
+func rejectByShutdown(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache) error {
+	logger.FromContext(ctx).Warnf("code processing was rejected by the shutdown of the server\n")
+
+	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_REJECTED_BY_SHUTDOWN)
 }
 
 // finishByTimeout is used in case of runCode method finished by timeout
 func finishByTimeout(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache) error {
-	logger.Errorf("%s: code processing finishes because of timeout\n", pipelineId)
+	logger.FromContext(ctx).Errorf("code processing finishes because of timeout\n")
 
 	// set to cache pipelineId: cache.SubKey_Status: Status_STATUS_RUN_TIMEOUT
 	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_RUN_TIMEOUT)
@@ -458,7 +747,7 @@ func finishByTimeout(ctx context.Context, pipelineId uuid.UUID, cacheService cac
 
 // processErrorWithSavingOutput processes error with saving to cache received error output.
 func processErrorWithSavingOutput(ctx context.Context, err error, errorOutput []byte, pipelineId uuid.UUID, subKey cache.SubKey, cacheService cache.Cache, errorTitle string, newStatus pb.Status) error {
-	logger.Errorf("%s: %s(): err: %s, output: %s\n", pipelineId, errorTitle, err.Error(), errorOutput)
+	logger.FromContext(ctx).Errorf("%s(): err: %s, output: %s\n", errorTitle, err.Error(), errorOutput)
 
 	if err := utils.SetToCache(ctx, cacheService, pipelineId, subKey, fmt.Sprintf("error: %s\noutput: %s", err.Error(), errorOutput)); err != nil {
 		return err
@@ -473,7 +762,7 @@ func processErrorWithSavingOutput(ctx context.Context, err error, errorOutput []
 //	sets corresponding status to the cache.
 func processRunError(ctx context.Context, errorChannel chan error, errorOutput []byte, pipelineId uuid.UUID, cacheService cache.Cache, stopReadLogsChannel, finishReadLogsChannel chan bool) error {
 	err := <-errorChannel
-	logger.Errorf("%s: Run(): err: %s, output: %s\n", pipelineId, err.Error(), errorOutput)
+	logger.FromContext(ctx).Errorf("Run(): err: %s, output: %s\n", err.Error(), errorOutput)
 
 	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.RunError, fmt.Sprintf("error: %s\noutput: %s", err.Error(), string(errorOutput))); err != nil {
 		return err
@@ -488,7 +777,7 @@ func processRunError(ctx context.Context, errorChannel chan error, errorOutput [
 // processSuccess processes case after successful process validation or preparation steps.
 // This method sets corresponding status to the cache.
 func processSuccess(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, successTitle string, newStatus pb.Status) error {
-	logger.Infof("%s: %s(): finish\n", pipelineId, successTitle)
+	logger.FromContext(ctx).Infof("%s(): finish\n", successTitle)
 
 	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, newStatus)
 }
@@ -497,7 +786,7 @@ func processSuccess(ctx context.Context, pipelineId uuid.UUID, cacheService cach
 // This method sets output of the compile step, sets empty string as output of the run step and
 //	sets corresponding status to the cache.
 func processCompileSuccess(ctx context.Context, output []byte, pipelineId uuid.UUID, cacheService cache.Cache) error {
-	logger.Infof("%s: Compile() finish\n", pipelineId)
+	logger.FromContext(ctx).Infof("Compile() finish\n")
 
 	if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.CompileOutput, string(output)); err != nil {
 		return err
@@ -516,7 +805,7 @@ func processCompileSuccess(ctx context.Context, output []byte, pipelineId uuid.U
 //	After receiving a signal that goroutine was finished (read value from finishReadLogsChannel) this method
 //	sets corresponding status to the cache.
 func processRunSuccess(ctx context.Context, pipelineId uuid.UUID, cacheService cache.Cache, stopReadLogsChannel, finishReadLogsChannel chan bool) error {
-	logger.Infof("%s: Run() finish\n", pipelineId)
+	logger.FromContext(ctx).Infof("Run() finish\n")
 
 	stopReadLogsChannel <- true
 	<-finishReadLogsChannel
@@ -524,9 +813,33 @@ func processRunSuccess(ctx context.Context, pipelineId uuid.UUID, cacheService c
 	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_FINISHED)
 }
 
+// toOriginalLines returns the error output with line numbers of the prepared code replaced with lines of the original code,
+// so errors point to lines which the user sees in the editor
+func toOriginalLines(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, errorOutput []byte) []byte {
+	sourceMap := GetSourceMap(ctx, cacheService, pipelineId)
+	if sourceMap == nil {
+		return errorOutput
+	}
+	return []byte(sourceMap.Translate(string(errorOutput)))
+}
+
+// saveTestReport parses results of unit tests from the run output or the report of the test runner and sets them to the cache.
+// Results of the truncated output aren't parsed since counts of tests would be wrong.
+func saveTestReport(ctx context.Context, cacheService cache.Cache, paths *fs_tool.LifeCyclePaths, pipelineId uuid.UUID, sdk pb.Sdk) {
+	report := &pb.TestReport{ParseFailed: true}
+	output, err := GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunOutput, "")
+	if err == nil && !IsOutputTruncated(ctx, cacheService, pipelineId, cache.RunOutputTruncated) {
+		report = test_report.Parse(sdk, output, test_report.ReportFilePath(paths.AbsoluteBaseFolderPath))
+	}
+	if report.ParseFailed {
+		logger.FromContext(ctx).Warnf("results of unit tests couldn't be parsed\n")
+	}
+	_ = utils.SetToCache(ctx, cacheService, pipelineId, cache.TestReport, report)
+}
+
 // processCancel process case when code processing was canceled
 func processCancel(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID) error {
-	logger.Infof("%s: was canceled\n", pipelineId)
+	logger.FromContext(ctx).Infof("was canceled\n")
 
 	// set to cache pipelineId: cache.SubKey_Status: pb.Status_STATUS_CANCELED
 	return utils.SetToCache(ctx, cacheService, pipelineId, cache.Status, pb.Status_STATUS_CANCELED)
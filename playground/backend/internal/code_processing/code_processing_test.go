@@ -22,19 +22,27 @@ import (
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/preparers"
+	"beam.apache.org/playground/backend/internal/run_history"
+	"beam.apache.org/playground/backend/internal/streaming"
 	"beam.apache.org/playground/backend/internal/utils"
 	"beam.apache.org/playground/backend/internal/validators"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/goleak"
+	"io"
 	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -42,6 +50,8 @@ import (
 const (
 	javaConfig      = "{\n  \"compile_cmd\": \"javac\",\n  \"run_cmd\": \"java\",\n  \"test_cmd\": \"java\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"JUnit\"\n  ]\n}"
 	pythonConfig    = "{\n  \"compile_cmd\": \"\",\n  \"run_cmd\": \"python3\",\n  \"compile_args\": [],\n  \"run_args\": []\n}"
+	kotlinConfig    = "{\n  \"compile_cmd\": \"kotlinc\",\n  \"run_cmd\": \"kotlin\",\n  \"test_cmd\": \"kotlin\",\n  \"compile_args\": [\n    \"-d\",\n    \"bin\",\n    \"-classpath\"\n  ],\n  \"run_args\": [\n    \"-cp\",\n    \"bin:\"\n  ],\n  \"test_args\": [\n    \"-cp\",\n    \"bin:\",\n    \"org.junit.runner.JUnitCore\"\n  ]\n}"
+	yamlConfig      = "{\n  \"compile_cmd\": \"\",\n  \"run_cmd\": \"python3\",\n  \"compile_args\": [],\n  \"run_args\": [\n    \"-m\",\n    \"apache_beam.yaml.main\"\n  ]\n}"
 	goConfig        = "{\n  \"compile_cmd\": \"go\",\n  \"run_cmd\": \"\",\n  \"compile_args\": [\n    \"build\",\n    \"-o\",\n    \"bin\"\n  ],\n  \"run_args\": [\n  ]\n}"
 	fileName        = "fakeFileName"
 	pipelinesFolder = "executable_files"
@@ -256,7 +266,7 @@ func Test_Process(t *testing.T) {
 					cacheService.SetValue(ctx, pipelineId, cache.Canceled, true)
 				}(tt.args.ctx, tt.args.pipelineId)
 			}
-			Process(tt.args.ctx, cacheService, lc, tt.args.pipelineId, tt.args.appEnv, tt.args.sdkEnv, tt.args.pipelineOptions)
+			Process(tt.args.ctx, cacheService, nil, lc, tt.args.pipelineId, tt.args.appEnv, tt.args.sdkEnv, tt.args.pipelineOptions, preparers.Overrides{})
 
 			status, _ := cacheService.GetValue(tt.args.ctx, tt.args.pipelineId, cache.Status)
 			if !reflect.DeepEqual(status, tt.expectedStatus) {
@@ -287,6 +297,82 @@ func Test_Process(t *testing.T) {
 	}
 }
 
+func Test_ProcessYaml(t *testing.T) {
+	// Test that a simple Beam YAML pipeline is validated, prepared and executed
+	if err := exec.Command("python3", "-c", "import apache_beam.yaml.main").Run(); err != nil {
+		t.Skip("Beam YAML SDK isn't installed")
+	}
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	executorConfig := &environment.ExecutorConfig{}
+	if err = json.Unmarshal([]byte(yamlConfig), executorConfig); err != nil {
+		panic(err)
+	}
+	sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_YAML, executorConfig, "", 1, environment.BeamEnvsOptions{})
+	code := "pipeline:\n  transforms:\n    - type: Create\n      config:\n        elements: [1, 2, 3]\n    - type: LogForTesting\n      input: Create\n"
+	ctx := context.Background()
+	pipelineId := uuid.New()
+
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_YAML, pipelineId, filepath.Join(os.Getenv("APP_WORK_DIR"), pipelinesFolder))
+	if err = lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_ = lc.CreateSourceCodeFile(code)
+	if err = utils.SetToCache(ctx, cacheService, pipelineId, cache.Canceled, false); err != nil {
+		t.Fatal("error during set cancel flag to cache")
+	}
+	Process(ctx, cacheService, nil, lc, pipelineId, appEnvs, sdkEnv, "", preparers.Overrides{})
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+		runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+		t.Errorf("processCode() set status: %s, but expectes: %s, run error: %v", status, pb.Status_STATUS_FINISHED, runError)
+	}
+}
+
+func Test_ProcessKotlin(t *testing.T) {
+	// Test that a simple Kotlin pipeline is validated, prepared, compiled and executed
+	if _, err := exec.LookPath("kotlinc"); err != nil {
+		t.Skip("Kotlin compiler isn't installed")
+	}
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	executorConfig := &environment.ExecutorConfig{}
+	if err = json.Unmarshal([]byte(kotlinConfig), executorConfig); err != nil {
+		panic(err)
+	}
+	jars, err := environment.ConcatBeamJarsToString()
+	if err != nil {
+		t.Fatalf("error during concat Beam jars: %s", err.Error())
+	}
+	executorConfig.CompileArgs = append(executorConfig.CompileArgs, jars)
+	executorConfig.RunArgs[1] += jars
+	sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_KOTLIN, executorConfig, "", 1, environment.BeamEnvsOptions{})
+	code := "package org.apache.beam.examples\n\nfun main(args: Array<String>) {\n    println(\"Hello, Kotlin\")\n}\n"
+	ctx := context.Background()
+	pipelineId := uuid.New()
+
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_KOTLIN, pipelineId, filepath.Join(os.Getenv("APP_WORK_DIR"), pipelinesFolder))
+	if err = lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_ = lc.CreateSourceCodeFile(code)
+	if err = utils.SetToCache(ctx, cacheService, pipelineId, cache.Canceled, false); err != nil {
+		t.Fatal("error during set cancel flag to cache")
+	}
+	Process(ctx, cacheService, nil, lc, pipelineId, appEnvs, sdkEnv, "", preparers.Overrides{})
+
+	status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+	if !reflect.DeepEqual(status, pb.Status_STATUS_FINISHED) {
+		runError, _ := cacheService.GetValue(ctx, pipelineId, cache.RunError)
+		t.Errorf("processCode() set status: %s, but expectes: %s, run error: %v", status, pb.Status_STATUS_FINISHED, runError)
+	}
+}
+
 func TestGetProcessingOutput(t *testing.T) {
 	defer goleak.VerifyNone(t, opt)
 	pipelineId := uuid.New()
@@ -664,7 +750,7 @@ func Benchmark_ProcessJava(b *testing.B) {
 		}
 		b.StartTimer()
 
-		Process(ctx, cacheService, lc, pipelineId, appEnv, sdkEnv, "")
+		Process(ctx, cacheService, nil, lc, pipelineId, appEnv, sdkEnv, "", preparers.Overrides{})
 	}
 }
 
@@ -694,7 +780,7 @@ func Benchmark_ProcessPython(b *testing.B) {
 		}
 		b.StartTimer()
 
-		Process(ctx, cacheService, lc, pipelineId, appEnv, sdkEnv, "")
+		Process(ctx, cacheService, nil, lc, pipelineId, appEnv, sdkEnv, "", preparers.Overrides{})
 	}
 }
 
@@ -724,7 +810,7 @@ func Benchmark_ProcessGo(b *testing.B) {
 		}
 		b.StartTimer()
 
-		Process(ctx, cacheService, lc, pipelineId, appEnv, sdkEnv, "")
+		Process(ctx, cacheService, nil, lc, pipelineId, appEnv, sdkEnv, "", preparers.Overrides{})
 	}
 }
 
@@ -811,7 +897,7 @@ func Test_validateStep(t *testing.T) {
 				validationResults:    &sync.Map{},
 				cancelChannel:        make(chan bool, 1),
 			},
-			want: 3,
+			want: 4,
 			code: "class HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(\"Hello world!\");\n    }\n}",
 		},
 	}
@@ -844,6 +930,7 @@ func Test_prepareStep(t *testing.T) {
 	validationResults := sync.Map{}
 	validationResults.Store(validators.UnitTestValidatorName, false)
 	validationResults.Store(validators.KatasValidatorName, false)
+	validationResults.Store(validators.JavaModuleValidatorName, false)
 	type args struct {
 		ctx                  context.Context
 		cacheService         cache.Cache
@@ -881,13 +968,46 @@ func Test_prepareStep(t *testing.T) {
 				t.Fatalf("error during prepare folders: %s", err.Error())
 			}
 			_ = lc.CreateSourceCodeFile(tt.code)
-			if got := prepareStep(tt.args.ctx, tt.args.cacheService, &lc.Paths, tt.args.pipelineId, tt.args.sdkEnv, tt.args.pipelineLifeCycleCtx, tt.args.validationResults, tt.args.cancelChannel); got == nil {
+			if got := prepareStep(tt.args.ctx, tt.args.cacheService, &lc.Paths, tt.args.pipelineId, tt.args.sdkEnv, tt.args.pipelineLifeCycleCtx, tt.args.validationResults, preparers.Overrides{}, tt.args.cancelChannel); got == nil {
 				t.Errorf("prepareStep(): got nil instead of preparer executor")
 			}
 		})
 	}
 }
 
+func Test_prepareStepWarnings(t *testing.T) {
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	sdkEnv, err := environment.ConfigureBeamEnvs(appEnvs.WorkingDir())
+	if err != nil {
+		panic(err)
+	}
+	validationResults := sync.Map{}
+	validationResults.Store(validators.UnitTestValidatorName, false)
+	validationResults.Store(validators.KatasValidatorName, false)
+	validationResults.Store(validators.JavaModuleValidatorName, true)
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, pipelineId, filepath.Join(os.Getenv("APP_WORK_DIR"), pipelinesFolder))
+	if err = lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_ = lc.CreateSourceCodeFile("module org.example {\n    requires java.base;\n}\n\nclass HelloWorld {\n    public static void main(String[] args) {\n        System.out.println(\"Hello world!\");\n    }\n}")
+
+	if got := prepareStep(ctx, cacheService, &lc.Paths, pipelineId, sdkEnv, ctx, &validationResults, preparers.Overrides{}, make(chan bool, 1)); got == nil {
+		t.Fatalf("prepareStep(): got nil instead of preparer executor")
+	}
+	want := fmt.Sprintf(preparers.ModuleInfoWarning, "1")
+	if warnings := GetRunMetadata(ctx, cacheService, pipelineId).GetWarnings(); len(warnings) != 1 || warnings[0] != want {
+		t.Errorf("GetRunMetadata() warnings = %v, want [%s]", warnings, want)
+	}
+	if sdkEnv.RunMetadata().GetWarnings() != nil {
+		t.Errorf("RunMetadata() of the sdk warnings = %v, want nil", sdkEnv.RunMetadata().GetWarnings())
+	}
+}
+
 func Test_compileStep(t *testing.T) {
 	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
 	if err != nil {
@@ -991,7 +1111,7 @@ func Test_runStep(t *testing.T) {
 				t.Fatalf("error during prepare folders: %s", err.Error())
 			}
 			_ = lc.CreateSourceCodeFile(tt.code)
-			runStep(tt.args.ctx, tt.args.cacheService, &lc.Paths, tt.args.pipelineId, tt.args.isUnitTest, tt.args.sdkEnv, tt.args.pipelineOptions, tt.args.pipelineLifeCycleCtx, tt.args.cancelChannel)
+			runStep(tt.args.ctx, tt.args.cacheService, &lc.Paths, tt.args.pipelineId, tt.args.isUnitTest, tt.args.sdkEnv, appEnvs.OutputLimitEnvs(), tt.args.pipelineOptions, tt.args.pipelineLifeCycleCtx, tt.args.cancelChannel)
 		})
 	}
 }
@@ -1004,3 +1124,558 @@ func syncMapLen(syncMap *sync.Map) int {
 	})
 	return length
 }
+
+// fakeSetupRunner fails with the received outputs one by one and succeeds after that
+type fakeSetupRunner struct {
+	failures []string
+	attempts int
+}
+
+func (r *fakeSetupRunner) run(ctx context.Context, stdout, stderr io.Writer) error {
+	r.attempts++
+	if r.attempts > len(r.failures) {
+		_, _ = stdout.Write([]byte("success"))
+		return nil
+	}
+	_, _ = stderr.Write([]byte(r.failures[r.attempts-1]))
+	return fmt.Errorf("exit status 1")
+}
+
+func Test_runSetupWithRetries(t *testing.T) {
+	proxyTimeout := "main.go:6:2: github.com/google/uuid@v1.3.0: Get \"https://proxy.golang.org/github.com/google/uuid/@v/v1.3.0.zip\": dial tcp 142.250.74.81:443: i/o timeout"
+	proxyUnavailable := "go: github.com/google/uuid@v1.3.0: reading https://proxy.golang.org/github.com/google/uuid/@v/v1.3.0.info: 503 Service Unavailable"
+	indexRefused := "WARNING: Retrying after connection broken by 'NewConnectionError(': Failed to establish a new connection: [Errno 111] Connection refused')'"
+	badImport := "main.go:4:2: no required module provides package example.com/unknown; to add it:\n\tgo get example.com/unknown"
+	syntaxError := "Main.java:5: error: ';' expected\n        System.out.println(\"connection refused\")\n                                                 ^\n1 error"
+	tests := []struct {
+		name        string
+		failures    []string
+		maxRetries  int
+		wantRetries int
+		wantErr     bool
+	}{
+		{
+			name:        "Success without retries",
+			failures:    nil,
+			maxRetries:  3,
+			wantRetries: 0,
+			wantErr:     false,
+		},
+		{
+			name:        "Success after transient failures",
+			failures:    []string{proxyTimeout, proxyUnavailable, indexRefused},
+			maxRetries:  3,
+			wantRetries: 3,
+			wantErr:     false,
+		},
+		{
+			name:        "Transient failures exceed max retries",
+			failures:    []string{proxyTimeout, proxyTimeout, proxyTimeout},
+			maxRetries:  2,
+			wantRetries: 2,
+			wantErr:     true,
+		},
+		{
+			name:        "Retries are disabled",
+			failures:    []string{proxyTimeout},
+			maxRetries:  0,
+			wantRetries: 0,
+			wantErr:     true,
+		},
+		{
+			name:        "Bad import path is not retried",
+			failures:    []string{badImport},
+			maxRetries:  3,
+			wantRetries: 0,
+			wantErr:     true,
+		},
+		{
+			name:        "Syntax error is not retried",
+			failures:    []string{syntaxError},
+			maxRetries:  3,
+			wantRetries: 0,
+			wantErr:     true,
+		},
+		{
+			name:        "User error after transient failure is not retried",
+			failures:    []string{proxyTimeout, syntaxError, proxyTimeout},
+			maxRetries:  3,
+			wantRetries: 1,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeSetupRunner{failures: tt.failures}
+			var stdout, stderr bytes.Buffer
+			retries, err := runSetupWithRetries(context.Background(), runner.run, tt.maxRetries, time.Millisecond, &stdout, &stderr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runSetupWithRetries() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if retries != tt.wantRetries {
+				t.Errorf("runSetupWithRetries() retries = %d, want %d", retries, tt.wantRetries)
+			}
+			if runner.attempts != tt.wantRetries+1 {
+				t.Errorf("runSetupWithRetries() attempts = %d, want %d", runner.attempts, tt.wantRetries+1)
+			}
+			if !tt.wantErr && stdout.String() != "success" {
+				t.Errorf("runSetupWithRetries() stdout = %q, want output of the last attempt", stdout.String())
+			}
+			if tt.wantErr && stderr.String() != tt.failures[runner.attempts-1] {
+				t.Errorf("runSetupWithRetries() stderr = %q, want output of the last attempt", stderr.String())
+			}
+		})
+	}
+}
+
+func Test_runSetupWithRetriesCanceled(t *testing.T) {
+	runner := &fakeSetupRunner{failures: []string{"dial tcp: i/o timeout", "dial tcp: i/o timeout"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var stdout, stderr bytes.Buffer
+	retries, err := runSetupWithRetries(ctx, runner.run, 3, time.Hour, &stdout, &stderr)
+	if err == nil {
+		t.Errorf("runSetupWithRetries() expected error after cancellation")
+	}
+	if retries != 1 || runner.attempts != 1 {
+		t.Errorf("runSetupWithRetries() retries = %d, attempts = %d, want no new attempts after cancellation", retries, runner.attempts)
+	}
+}
+
+func Test_isTransientSetupFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   bool
+	}{
+		{name: "Network timeout", output: "dial tcp 142.250.74.81:443: i/o timeout", want: true},
+		{name: "TLS handshake timeout", output: "net/http: TLS handshake timeout", want: true},
+		{name: "Proxy bad gateway", output: "reading https://proxy.golang.org/x/@v/list: 502 Bad Gateway", want: true},
+		{name: "Connection refused", output: "Failed to establish a new connection: [Errno 111] Connection refused", want: true},
+		{name: "Module download at import position", output: "main.go:6:2: github.com/x/y@v1.0.0: dial tcp: i/o timeout", want: true},
+		{name: "Empty output", output: "", want: false},
+		{name: "Unknown module", output: "go: example.com/unknown@v1.0.0: reading https://proxy.golang.org/example.com/unknown/@v/v1.0.0.info: 404 Not Found", want: false},
+		{name: "Compilation error", output: "Main.java:503: error: cannot find symbol", want: false},
+		{name: "Compilation error with transient text in the source line", output: "Main.java:5: error: ';' expected\n  String s = \"i/o timeout\"", want: false},
+		{name: "Kotlin compilation error with transient text in the source line", output: "Main.kt:5:20: error: unresolved reference: timeout\n    val s = \"i/o timeout\" + timeout", want: false},
+		{name: "Kotlin compilation error in the old format", output: "e: /tmp/src/Main.kt: (5, 20): unresolved reference: timeout\n    val s = \"i/o timeout\" + timeout", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientSetupFailure(tt.output); got != tt.want {
+				t.Errorf("isTransientSetupFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_limitOutput(t *testing.T) {
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, "")
+	_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputTruncated, false)
+	// the command streams far past the hard limit, so it should be killed
+	cmd := exec.Command("yes", "output")
+	runOutput := &streaming.RunOutputWriter{Ctx: ctx, CacheService: cacheService, PipelineId: pipelineId}
+	var runError bytes.Buffer
+	cmd.Stdout = limitOutput(ctx, runOutput, environment.NewOutputLimitEnvs(1024, 1<<20), cacheService, pipelineId, cache.RunOutputTruncated, cmd)
+	cmd.Stderr = limitOutput(ctx, &runError, environment.NewOutputLimitEnvs(1024, 1<<20), cacheService, pipelineId, cache.RunErrorTruncated, cmd)
+
+	if err := cmd.Run(); err == nil {
+		t.Fatalf("limitOutput() command should be killed after the hard limit")
+	}
+	output, err := GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunOutput, "")
+	if err != nil {
+		t.Fatalf("GetProcessingOutput() error = %v", err)
+	}
+	if !strings.HasPrefix(output, "output\noutput\n") || !strings.Contains(output, "[output truncated after 1024 bytes]") {
+		t.Errorf("limitOutput() run output should contain the truncation marker, got %q", output)
+	}
+	if len(output) > 2048 {
+		t.Errorf("limitOutput() run output should be bounded by the limit, got %d bytes", len(output))
+	}
+	if !IsOutputTruncated(ctx, cacheService, pipelineId, cache.RunOutputTruncated) {
+		t.Errorf("limitOutput() run output should be marked as truncated")
+	}
+	if IsOutputTruncated(ctx, cacheService, pipelineId, cache.RunErrorTruncated) {
+		t.Errorf("limitOutput() run error shouldn't be marked as truncated")
+	}
+}
+
+func Test_saveTestReport(t *testing.T) {
+	ctx := context.Background()
+	paths := &fs_tool.LifeCyclePaths{AbsoluteBaseFolderPath: t.TempDir()}
+	goOutput := "=== RUN   TestAdd\n--- PASS: TestAdd (0.00s)\nPASS\nok  \tcommand-line-arguments\t0.003s\n"
+	tests := []struct {
+		name      string
+		output    string
+		truncated bool
+		want      *pb.TestReport
+	}{
+		{
+			// Test case with the complete output of unit tests.
+			// As a result, want to receive parsed results of tests.
+			name:   "output is parsed",
+			output: goOutput,
+			want:   &pb.TestReport{TestsRun: 1, Passed: 1},
+		},
+		{
+			// Test case with the truncated output of unit tests.
+			// As a result, want to receive the report which isn't parsed.
+			name:      "output is truncated",
+			output:    goOutput,
+			truncated: true,
+			want:      &pb.TestReport{ParseFailed: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelineId := uuid.New()
+			_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutput, tt.output)
+			_ = cacheService.SetValue(ctx, pipelineId, cache.RunOutputTruncated, tt.truncated)
+			saveTestReport(ctx, cacheService, paths, pipelineId, pb.Sdk_SDK_GO)
+			if got := GetTestReport(ctx, cacheService, pipelineId); got.String() != tt.want.String() {
+				t.Errorf("saveTestReport() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	// Test that the shutdown waits for the fast run, cancels the slow run with killing its processes
+	// and rejects the run which starts after the shutdown has been started
+	inFlight = newRunRegistry()
+	defer func() { inFlight = newRunRegistry() }()
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	executorConfig := &environment.ExecutorConfig{}
+	if err = json.Unmarshal([]byte(pythonConfig), executorConfig); err != nil {
+		panic(err)
+	}
+	sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, executorConfig, "", 1, environment.BeamEnvsOptions{})
+	ctx := context.Background()
+	fastCode := "import time\nprint(\"fast started\", flush=True)\ntime.sleep(1)\nprint(\"fast finished\")\n"
+	slowCode := "import subprocess, sys, time\nchild = subprocess.Popen([\"sleep\", \"60\"])\nprint(\"child\", child.pid, flush=True)\nprint(\"partial error\", file=sys.stderr, flush=True)\ntime.sleep(60)\nprint(\"slow finished\")\n"
+
+	startProcess := func(code string, wg *sync.WaitGroup) uuid.UUID {
+		pipelineId := uuid.New()
+		lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, filepath.Join(os.Getenv("APP_WORK_DIR"), pipelinesFolder))
+		if err := lc.CreateFolders(); err != nil {
+			t.Fatalf("error during prepare folders: %s", err.Error())
+		}
+		_ = lc.CreateSourceCodeFile(code)
+		if err := utils.SetToCache(ctx, cacheService, pipelineId, cache.Canceled, false); err != nil {
+			t.Fatal("error during set cancel flag to cache")
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Process(ctx, cacheService, nil, lc, pipelineId, appEnvs, sdkEnv, "", preparers.Overrides{})
+		}()
+		return pipelineId
+	}
+	waitForOutput := func(pipelineId uuid.UUID, output string) {
+		for deadline := time.Now().Add(30 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+			if runOutput, _ := GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunOutput, ""); strings.Contains(runOutput, output) {
+				return
+			}
+		}
+		status, _ := cacheService.GetValue(ctx, pipelineId, cache.Status)
+		t.Fatalf("run output doesn't contain %q, status: %v", output, status)
+	}
+
+	var wg sync.WaitGroup
+	fastId := startProcess(fastCode, &wg)
+	slowId := startProcess(slowCode, &wg)
+	waitForOutput(fastId, "fast started")
+	waitForOutput(slowId, "child")
+
+	shutdownStart := time.Now()
+	if canceled := Shutdown(5 * time.Second); canceled != 1 {
+		t.Errorf("Shutdown() canceled = %d, want 1", canceled)
+	}
+	if !IsShuttingDown() {
+		t.Errorf("IsShuttingDown() = false after Shutdown()")
+	}
+	rejectedId := startProcess(fastCode, &wg)
+	wg.Wait()
+	// the slow run is done only when the child process which keeps its output open is killed too
+	if elapsed := time.Since(shutdownStart); elapsed > 20*time.Second {
+		t.Errorf("code processing is finished in %s after Shutdown(), the child process of the slow run wasn't killed", elapsed)
+	}
+
+	tests := []struct {
+		name       string
+		pipelineId uuid.UUID
+		wantStatus pb.Status
+		wantOutput string
+		wantError  string
+	}{
+		{
+			// The fast run finishes during the drain period.
+			name:       "fast run is finished",
+			pipelineId: fastId,
+			wantStatus: pb.Status_STATUS_FINISHED,
+			wantOutput: "fast finished",
+		},
+		{
+			// The slow run is canceled after the drain period and keeps its partial output.
+			name:       "slow run is canceled",
+			pipelineId: slowId,
+			wantStatus: pb.Status_STATUS_CANCELED_BY_SHUTDOWN,
+			wantOutput: "child",
+			wantError:  "partial error",
+		},
+		{
+			// The run which starts during the shutdown isn't started.
+			name:       "run after shutdown is rejected",
+			pipelineId: rejectedId,
+			wantStatus: pb.Status_STATUS_REJECTED_BY_SHUTDOWN,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _ := cacheService.GetValue(ctx, tt.pipelineId, cache.Status)
+			if !reflect.DeepEqual(status, tt.wantStatus) {
+				t.Errorf("Process() set status: %v, but expects: %s", status, tt.wantStatus)
+			}
+			runOutput, _ := GetProcessingOutput(ctx, cacheService, tt.pipelineId, cache.RunOutput, "")
+			if !strings.Contains(runOutput, tt.wantOutput) {
+				t.Errorf("Process() set run output: %q, but expects it to contain %q", runOutput, tt.wantOutput)
+			}
+			runError, _ := GetProcessingOutput(ctx, cacheService, tt.pipelineId, cache.RunError, "")
+			if !strings.Contains(runError, tt.wantError) {
+				t.Errorf("Process() set run error: %q, but expects it to contain %q", runError, tt.wantError)
+			}
+		})
+	}
+
+	// the child process of the slow run is killed together with the run
+	runOutput, _ := GetProcessingOutput(ctx, cacheService, slowId, cache.RunOutput, "")
+	var childPid int
+	if _, err := fmt.Sscanf(strings.TrimSpace(runOutput), "child %d", &childPid); err != nil {
+		t.Fatalf("error during read pid of the child process: %s", err.Error())
+	}
+	if isProcessAlive(childPid) {
+		t.Errorf("child process %d of the canceled run is alive", childPid)
+	}
+}
+
+// isProcessAlive returns true if the process exists and isn't a zombie waiting to be reaped
+func isProcessAlive(pid int) bool {
+	stat, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+	fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+	return len(fields) > 0 && fields[0] != "Z"
+}
+
+func Test_recordRunHistory(t *testing.T) {
+	ctx := context.Background()
+	sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, &environment.ExecutorConfig{}, "", 1, environment.BeamEnvsOptions{RunMetadata: &pb.RunMetadata{BeamVersion: "2.33.0"}})
+	durations := &stageDurations{}
+	observeStage(withStageDurations(ctx, durations), sdkEnv.ApacheBeamSdk.String(), validateStage, time.Now())
+	tests := []struct {
+		name        string
+		exampleId   string
+		status      pb.Status
+		runError    string
+		wantRecord  bool
+		wantSummary string
+	}{
+		{
+			// Test case with the finished run of the example.
+			// As a result, want to receive the record without the error summary.
+			name:       "run of the example is finished",
+			exampleId:  "SDK_PYTHON/Finished",
+			status:     pb.Status_STATUS_FINISHED,
+			wantRecord: true,
+		},
+		{
+			// Test case with the failed run of the example.
+			// As a result, want to receive the record with the beginning of the run error.
+			name:        "run of the example is failed",
+			exampleId:   "SDK_PYTHON/Failed",
+			status:      pb.Status_STATUS_RUN_ERROR,
+			runError:    "error: ValueError",
+			wantRecord:  true,
+			wantSummary: "error: ValueError",
+		},
+		{
+			// Test case with the run of the code which isn't an example.
+			// As a result, want to receive no records.
+			name:   "run of the code of the user",
+			status: pb.Status_STATUS_FINISHED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			historyStore := run_history.NewLocalStore(10)
+			pipelineId := uuid.New()
+			_ = cacheService.SetValue(ctx, pipelineId, cache.ExampleId, tt.exampleId)
+			_ = cacheService.SetValue(ctx, pipelineId, cache.Status, tt.status)
+			_ = cacheService.SetValue(ctx, pipelineId, cache.RunError, tt.runError)
+			recordRunHistory(ctx, cacheService, historyStore, pipelineId, sdkEnv, durations)
+			if !tt.wantRecord {
+				time.Sleep(50 * time.Millisecond)
+				if records, _ := historyStore.List(ctx, tt.exampleId, 1); len(records) != 0 {
+					t.Errorf("recordRunHistory() added %v, want no records", records)
+				}
+				return
+			}
+			var records []*pb.RunRecord
+			for deadline := time.Now().Add(5 * time.Second); len(records) == 0 && time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+				records, _ = historyStore.List(ctx, tt.exampleId, 1)
+			}
+			want := &pb.RunRecord{PipelineUuid: pipelineId.String(), ExampleId: tt.exampleId, Sdk: pb.Sdk_SDK_PYTHON, BeamVersion: "2.33.0", Status: tt.status, StageDurations: durations.list(), ErrorSummary: tt.wantSummary}
+			if len(records) != 1 {
+				t.Fatalf("recordRunHistory() didn't add the record")
+			}
+			want.FinishedAtMs = records[0].FinishedAtMs
+			if records[0].String() != want.String() {
+				t.Errorf("recordRunHistory() added %v, want %v", records[0], want)
+			}
+		})
+	}
+}
+
+const busyLoopScript = "i=0; while [ $i -lt 200000 ]; do i=$((i+1)); done"
+
+func Test_commandUsage(t *testing.T) {
+	tests := []struct {
+		name        string
+		script      string
+		setpgid     bool
+		wantPartial bool
+	}{
+		{
+			name:    "busy loop in own process group",
+			script:  busyLoopScript,
+			setpgid: true,
+		},
+		{
+			// Test that the usage of the process whose child outlives it is partial
+			name:        "child outlives the process",
+			script:      busyLoopScript + "; sleep 5 &",
+			setpgid:     true,
+			wantPartial: true,
+		},
+		{
+			// Test that the usage of the process which shares the process group of the backend is partial
+			name:        "process isn't a group leader",
+			script:      busyLoopScript,
+			wantPartial: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command("sh", "-c", tt.script)
+			if commandUsage(cmd) != nil {
+				t.Fatalf("commandUsage() of the command which isn't started isn't nil")
+			}
+			cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: tt.setpgid}
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("error during run the command: %s", err.Error())
+			}
+			usage := commandUsage(cmd)
+			if tt.setpgid {
+				_ = syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+			}
+			if usage == nil {
+				t.Fatalf("commandUsage() = nil")
+			}
+			if cpu := usage.UserCpuMs + usage.SystemCpuMs; cpu < 50 {
+				t.Errorf("commandUsage() CPU time = %dms of the busy loop, want at least 50ms", cpu)
+			}
+			if usage.MaxRssKb <= 0 {
+				t.Errorf("commandUsage() MaxRssKb = %d, want positive", usage.MaxRssKb)
+			}
+			if usage.Partial != tt.wantPartial {
+				t.Errorf("commandUsage() Partial = %v, want %v", usage.Partial, tt.wantPartial)
+			}
+		})
+	}
+}
+
+func Test_compileStepResourceUsage(t *testing.T) {
+	tests := []struct {
+		name string
+		sdk  pb.Sdk
+	}{
+		{name: "java", sdk: pb.Sdk_SDK_JAVA},
+		{name: "go", sdk: pb.Sdk_SDK_GO},
+		{name: "kotlin", sdk: pb.Sdk_SDK_KOTLIN},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the busy loop stands in for the compiler, so the test doesn't depend on the toolchain of the sdk
+			executorConfig := &environment.ExecutorConfig{CompileCmd: "sh", CompileArgs: []string{"-c", busyLoopScript, "sh"}}
+			sdkEnv := environment.NewBeamEnvs(tt.sdk, executorConfig, "", 1, environment.BeamEnvsOptions{RunMetadata: &pb.RunMetadata{BeamVersion: "2.33.0"}})
+			pipelineId := uuid.New()
+			lc, _ := fs_tool.NewLifeCycle(tt.sdk, pipelineId, filepath.Join(os.Getenv("APP_WORK_DIR"), pipelinesFolder))
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("error during prepare folders: %s", err.Error())
+			}
+			defer deleteFolders(nil, lc)
+			_ = lc.CreateSourceCodeFile("MOCK_CODE")
+			ctx := context.Background()
+			_ = utils.SetToCache(ctx, cacheService, pipelineId, cache.RunMetadata, sdkEnv.RunMetadata())
+
+			if executor := compileStep(ctx, cacheService, &lc.Paths, pipelineId, sdkEnv, false, ctx, make(chan bool, 1)); executor == nil {
+				t.Fatalf("compileStep() = nil, status: %v", terminalStatus(ctx, cacheService, pipelineId))
+			}
+			metadata := GetRunMetadata(ctx, cacheService, pipelineId)
+			if metadata.GetBeamVersion() != "2.33.0" || len(metadata.GetResourceUsage()) != 1 {
+				t.Fatalf("GetRunMetadata() = %v, want the beam version and the usage of the compile step", metadata)
+			}
+			usage := metadata.ResourceUsage[0]
+			if usage.Stage != compileStage || usage.UserCpuMs+usage.SystemCpuMs < 50 || usage.MaxRssKb <= 0 || usage.Partial {
+				t.Errorf("GetRunMetadata() resource usage = %v, want complete usage of the compile step with the busy loop", usage)
+			}
+			if len(sdkEnv.RunMetadata().ResourceUsage) != 0 {
+				t.Errorf("metadata of the sdk is changed: %v", sdkEnv.RunMetadata())
+			}
+		})
+	}
+}
+
+func TestProcessResourceUsage(t *testing.T) {
+	appEnvs, err := environment.GetApplicationEnvsFromOsEnvs()
+	if err != nil {
+		panic(err)
+	}
+	executorConfig := &environment.ExecutorConfig{}
+	if err = json.Unmarshal([]byte(pythonConfig), executorConfig); err != nil {
+		panic(err)
+	}
+	sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, executorConfig, "", 1, environment.BeamEnvsOptions{RunMetadata: &pb.RunMetadata{BeamVersion: "2.33.0"}})
+	ctx := context.Background()
+	pipelineId := uuid.New()
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, pipelineId, filepath.Join(os.Getenv("APP_WORK_DIR"), pipelinesFolder))
+	if err = lc.CreateFolders(); err != nil {
+		t.Fatalf("error during prepare folders: %s", err.Error())
+	}
+	_ = lc.CreateSourceCodeFile("import time\nstart = time.process_time()\nwhile time.process_time() - start < 0.2:\n    pass\nprint(\"done\")\n")
+	if err = utils.SetToCache(ctx, cacheService, pipelineId, cache.Canceled, false); err != nil {
+		t.Fatal("error during set cancel flag to cache")
+	}
+
+	Process(ctx, cacheService, nil, lc, pipelineId, appEnvs, sdkEnv, "", preparers.Overrides{})
+
+	if status := terminalStatus(ctx, cacheService, pipelineId); status != pb.Status_STATUS_FINISHED {
+		t.Fatalf("Process() status = %v, want %v", status, pb.Status_STATUS_FINISHED)
+	}
+	usages := GetRunMetadata(ctx, cacheService, pipelineId).GetResourceUsage()
+	if len(usages) != 1 || usages[0].Stage != runStage {
+		t.Fatalf("GetRunMetadata() resource usage = %v, want the usage of the run step", usages)
+	}
+	if cpu := usages[0].UserCpuMs + usages[0].SystemCpuMs; cpu < 200 {
+		t.Errorf("GetRunMetadata() CPU time = %dms of the busy loop, want at least 200ms", cpu)
+	}
+	if usages[0].MaxRssKb <= 0 || usages[0].Partial {
+		t.Errorf("GetRunMetadata() resource usage = %v, want complete usage with the peak memory", usages[0])
+	}
+}
@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/metrics"
+	"beam.apache.org/playground/backend/internal/utils"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// stageUsage accumulates resource usage of finished commands of the step, e.g. of all attempts of the retried setup command
+type stageUsage struct {
+	mu    sync.Mutex
+	usage *pb.ResourceUsage
+}
+
+func (su *stageUsage) add(cmd *exec.Cmd) {
+	usage := commandUsage(cmd)
+	if usage == nil {
+		return
+	}
+	su.mu.Lock()
+	defer su.mu.Unlock()
+	if su.usage == nil {
+		su.usage = usage
+		return
+	}
+	su.usage.UserCpuMs += usage.UserCpuMs
+	su.usage.SystemCpuMs += usage.SystemCpuMs
+	if usage.MaxRssKb > su.usage.MaxRssKb {
+		su.usage.MaxRssKb = usage.MaxRssKb
+	}
+	su.usage.Partial = su.usage.Partial || usage.Partial
+}
+
+func (su *stageUsage) get() *pb.ResourceUsage {
+	su.mu.Lock()
+	defer su.mu.Unlock()
+	return su.usage
+}
+
+// commandUsage returns CPU time and the peak resident set size of the finished cmd.
+// On Linux the usage of the process includes its descendants which it waited for, so the usage of the process group
+// is complete if cmd is the leader of its own process group and no processes of the group outlive it.
+// Otherwise, the usage is marked as partial. Returns nil if cmd isn't finished.
+func commandUsage(cmd *exec.Cmd) *pb.ResourceUsage {
+	state := cmd.ProcessState
+	if state == nil {
+		return nil
+	}
+	usage := &pb.ResourceUsage{
+		UserCpuMs:   state.UserTime().Milliseconds(),
+		SystemCpuMs: state.SystemTime().Milliseconds(),
+		Partial:     true,
+	}
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		usage.MaxRssKb = rusage.Maxrss
+		isGroupLeader := cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid
+		usage.Partial = !isGroupLeader || syscall.Kill(-state.Pid(), 0) == nil
+	}
+	return usage
+}
+
+// recordResourceUsage records the resource usage of the step to metrics and adds it to cache.RunMetadata of the code processing
+func recordResourceUsage(ctx context.Context, cacheService cache.Cache, pipelineId uuid.UUID, sdk, stage string, usage *pb.ResourceUsage) {
+	if usage == nil {
+		return
+	}
+	usage.Stage = stage
+	cpu := time.Duration(usage.UserCpuMs+usage.SystemCpuMs) * time.Millisecond
+	metrics.ObserveStageResources(sdk, stage, cpu, usage.MaxRssKb*1024)
+
+	metadata := &pb.RunMetadata{}
+	if cached := GetRunMetadata(ctx, cacheService, pipelineId); cached != nil {
+		// metadata of the sdk is shared by all runs, so the cached value is never changed in place
+		metadata = proto.Clone(cached).(*pb.RunMetadata)
+	}
+	metadata.ResourceUsage = append(metadata.ResourceUsage, usage)
+	_ = utils.SetToCache(ctx, cacheService, pipelineId, cache.RunMetadata, metadata)
+}
@@ -0,0 +1,119 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/metrics"
+	"beam.apache.org/playground/backend/internal/run_history"
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// historyWriteTimeout is the max time of writing the record of the code processing to the run history
+const historyWriteTimeout = 10 * time.Second
+
+type stageDurationsKey struct{}
+
+// stageDurations keeps durations of steps of the code processing in the order the steps are finished
+type stageDurations struct {
+	mu        sync.Mutex
+	durations []*pb.StageDuration
+}
+
+func (sd *stageDurations) add(stage string, duration time.Duration) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.durations = append(sd.durations, &pb.StageDuration{Stage: stage, DurationMs: duration.Milliseconds()})
+}
+
+func (sd *stageDurations) list() []*pb.StageDuration {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	return append([]*pb.StageDuration{}, sd.durations...)
+}
+
+// withStageDurations returns a copy of ctx which keeps durations of steps of the code processing
+func withStageDurations(ctx context.Context, durations *stageDurations) context.Context {
+	return context.WithValue(ctx, stageDurationsKey{}, durations)
+}
+
+// observeStage records the duration of the step which is started at start to metrics and to durations of steps kept in ctx.
+// Use it with defer at the beginning of the step.
+func observeStage(ctx context.Context, sdk, stage string, start time.Time) {
+	metrics.ObserveStage(sdk, stage, start)
+	if durations, ok := ctx.Value(stageDurationsKey{}).(*stageDurations); ok {
+		durations.add(stage, time.Since(start))
+	}
+}
+
+// recordRunHistory adds the record of the finished code processing of the example of the catalog to the run history.
+// The record is written in the background, so the run history never delays or fails the code processing.
+// The code processing of the code which isn't an example isn't recorded.
+func recordRunHistory(ctx context.Context, cacheService cache.Cache, historyStore run_history.Store, pipelineId uuid.UUID, sdkEnv *environment.BeamEnvs, durations *stageDurations) {
+	if historyStore == nil {
+		return
+	}
+	value, err := cacheService.GetValue(ctx, pipelineId, cache.ExampleId)
+	exampleId, _ := value.(string)
+	if err != nil || exampleId == "" {
+		return
+	}
+	status := terminalStatus(ctx, cacheService, pipelineId)
+	record := &pb.RunRecord{
+		PipelineUuid:   pipelineId.String(),
+		ExampleId:      exampleId,
+		Sdk:            sdkEnv.ApacheBeamSdk,
+		BeamVersion:    sdkEnv.RunMetadata().GetBeamVersion(),
+		Status:         status,
+		StageDurations: durations.list(),
+		FinishedAtMs:   time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if subKey, ok := ErrorOutputSubKey(status); ok {
+		errorOutput, _ := cacheService.GetValue(ctx, pipelineId, subKey)
+		errorOutputString, _ := errorOutput.(string)
+		record.ErrorSummary = run_history.ErrorSummary(errorOutputString)
+	}
+
+	log := logger.FromContext(ctx)
+	go func() {
+		historyCtx, cancel := context.WithTimeout(logger.NewContext(context.Background(), log), historyWriteTimeout)
+		defer cancel()
+		if err := historyStore.Add(historyCtx, record); err != nil {
+			log.Warnf("error during add the record to the run history: %s\n", err.Error())
+		}
+	}()
+}
+
+// ErrorOutputSubKey returns the subKey of the error output of the step which failed with the status
+func ErrorOutputSubKey(status pb.Status) (cache.SubKey, bool) {
+	switch status {
+	case pb.Status_STATUS_VALIDATION_ERROR:
+		return cache.ValidationOutput, true
+	case pb.Status_STATUS_PREPARATION_ERROR:
+		return cache.PreparationOutput, true
+	case pb.Status_STATUS_COMPILE_ERROR:
+		return cache.CompileOutput, true
+	case pb.Status_STATUS_RUN_ERROR:
+		return cache.RunError, true
+	}
+	return "", false
+}
@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/utils"
+	"bytes"
+	"context"
+	"github.com/google/uuid"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	initialSetupRetryBackoff = time.Second
+	maxSetupRetryBackoff     = 30 * time.Second
+)
+
+var (
+	// transientSetupFailureRegexp matches errors of the infrastructure which are expected to disappear
+	// in a while: network timeouts, refused connections and 5xx responses of the module proxy or the package index.
+	transientSetupFailureRegexp = regexp.MustCompile(`(?i)(i/o timeout|connection refused|connection reset by peer|tls handshake timeout|temporary failure in name resolution|bad gateway|service unavailable|gateway timeout)`)
+
+	// userCodeErrorRegexp matches errors which point to a position in the source file of the user,
+	// including positions of kotlinc in both "Main.kt:5:9" and "Main.kt: (5, 9)" formats
+	userCodeErrorRegexp = regexp.MustCompile(`\.(go|java|scala|py|kt)(:\d+|: \(\d+)`)
+)
+
+// commandRunner runs a new attempt of the command writing its output to stdout and stderr.
+// Each attempt should create a new *exec.Cmd since a command can't be run twice.
+type commandRunner func(ctx context.Context, stdout, stderr io.Writer) error
+
+// runSetupWithOutput runs the setup command in the background with retries in case of transient failures
+// and keeps the output of the last attempt in stdOutput and stdError.
+// The number of retries is saved into the cache as cache.SetupRetries.
+func runSetupWithOutput(ctx context.Context, run commandRunner, maxRetries int, stdOutput, stdError *bytes.Buffer, pipelineId uuid.UUID, cacheService cache.Cache, successChannel chan bool, errorChannel chan error) {
+	go func() {
+		retries, err := runSetupWithRetries(ctx, run, maxRetries, initialSetupRetryBackoff, stdOutput, stdError)
+		_ = utils.SetToCache(ctx, cacheService, pipelineId, cache.SetupRetries, retries)
+		if err != nil {
+			errorChannel <- err
+			successChannel <- false
+		} else {
+			successChannel <- true
+		}
+	}()
+}
+
+// runSetupWithRetries runs the setup command and retries it up to maxRetries times with exponential backoff
+// while it fails because of transient infrastructure errors. Errors of the user code are never retried.
+// Returns the number of retries and the error of the last attempt.
+func runSetupWithRetries(ctx context.Context, run commandRunner, maxRetries int, backoff time.Duration, stdOutput, stdError *bytes.Buffer) (int, error) {
+	log := logger.FromContext(ctx)
+	retries := 0
+	for {
+		stdOutput.Reset()
+		stdError.Reset()
+		err := run(ctx, stdOutput, stdError)
+		if err == nil || retries >= maxRetries || !isTransientSetupFailure(stdOutput.String()+"\n"+stdError.String()) {
+			return retries, err
+		}
+		retries++
+		log.Warnf("setup command failed with transient error: %s. Retry %d of %d in %s\n", err.Error(), retries, maxRetries, backoff)
+		select {
+		case <-ctx.Done():
+			return retries, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxSetupRetryBackoff {
+			backoff = maxSetupRetryBackoff
+		}
+	}
+}
+
+// isTransientSetupFailure returns true if the output of the failed setup command contains a transient
+// infrastructure error and doesn't contain errors of the user code.
+// Lines which point to the source file are errors of the user code unless they report a transient error,
+// e.g. the go tool reports a failed download of a module at the position of its import.
+func isTransientSetupFailure(output string) bool {
+	transient := false
+	for _, line := range strings.Split(output, "\n") {
+		isTransient := transientSetupFailureRegexp.MatchString(line)
+		if !isTransient && userCodeErrorRegexp.MatchString(line) {
+			return false
+		}
+		transient = transient || isTransient
+	}
+	return transient
+}
@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package code_processing
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// persistTimeout is how long Shutdown waits for canceled code processing to save its state into cache
+const persistTimeout = 10 * time.Second
+
+// inFlight keeps the code processing which is in progress on this server
+var inFlight = newRunRegistry()
+
+// runRegistry keeps the code processing in progress, so it can be drained when the server shuts down
+type runRegistry struct {
+	mu       sync.Mutex
+	draining bool
+	runs     map[uuid.UUID]*inFlightRun
+}
+
+// inFlightRun is the code processing in progress
+type inFlightRun struct {
+	cancel   context.CancelFunc
+	done     chan struct{}
+	canceled bool
+}
+
+func newRunRegistry() *runRegistry {
+	return &runRegistry{runs: map[uuid.UUID]*inFlightRun{}}
+}
+
+// start registers the code processing which is stopped by cancel.
+// If the server shuts down, doesn't register it and returns false.
+func (r *runRegistry) start(pipelineId uuid.UUID, cancel context.CancelFunc) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.draining {
+		return false
+	}
+	r.runs[pipelineId] = &inFlightRun{cancel: cancel, done: make(chan struct{})}
+	return true
+}
+
+// finish removes the code processing from the registry.
+// Should be called after the code processing saved its state into cache.
+func (r *runRegistry) finish(pipelineId uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if run, ok := r.runs[pipelineId]; ok {
+		close(run.done)
+		delete(r.runs, pipelineId)
+	}
+}
+
+// isCanceled returns true if the code processing was canceled by the shutdown
+func (r *runRegistry) isCanceled(pipelineId uuid.UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.runs[pipelineId]
+	return ok && run.canceled
+}
+
+// isDraining returns true if the shutdown has been started
+func (r *runRegistry) isDraining() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.draining
+}
+
+// drain stops registering new code processing, waits up to drainPeriod for registered code processing to finish
+// and cancels the rest. Returns the number of canceled code processing.
+func (r *runRegistry) drain(drainPeriod time.Duration) int {
+	r.mu.Lock()
+	r.draining = true
+	runs := make([]*inFlightRun, 0, len(r.runs))
+	for _, run := range r.runs {
+		runs = append(runs, run)
+	}
+	r.mu.Unlock()
+
+	drainTimer := time.NewTimer(drainPeriod)
+	defer drainTimer.Stop()
+	if waitAll(runs, drainTimer.C) {
+		return 0
+	}
+
+	r.mu.Lock()
+	canceled := make([]*inFlightRun, 0, len(r.runs))
+	for _, run := range r.runs {
+		run.canceled = true
+		run.cancel()
+		canceled = append(canceled, run)
+	}
+	r.mu.Unlock()
+
+	persistTimer := time.NewTimer(persistTimeout)
+	defer persistTimer.Stop()
+	waitAll(canceled, persistTimer.C)
+	return len(canceled)
+}
+
+// waitAll waits until all runs are done or until the deadline. Returns false if the deadline is reached first.
+func waitAll(runs []*inFlightRun, deadline <-chan time.Time) bool {
+	for _, run := range runs {
+		select {
+		case <-run.done:
+		case <-deadline:
+			return false
+		}
+	}
+	return true
+}
+
+// IsShuttingDown returns true if the server shuts down and new code processing isn't accepted
+func IsShuttingDown() bool {
+	return inFlight.isDraining()
+}
+
+// Shutdown stops accepting new code processing and waits up to drainPeriod for the code processing in progress to finish.
+// The code processing which isn't finished by then is canceled with killing its processes and
+// saving playground.Status_STATUS_CANCELED_BY_SHUTDOWN as cache.Status together with its partial output into cache.
+// Returns after canceled code processing saved its state, so the server can exit. Returns the number of canceled code processing.
+func Shutdown(drainPeriod time.Duration) int {
+	return inFlight.drain(drainPeriod)
+}
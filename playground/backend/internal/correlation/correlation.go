@@ -0,0 +1,72 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"regexp"
+)
+
+const (
+	// Header is a metadata key which is used by clients to send the correlation id of the request
+	Header = "x-correlation-id"
+	// maxIdLength is the max length of the correlation id received from the client
+	maxIdLength = 128
+)
+
+// idRegexp matches correlation ids which can be received from clients. Other ids are replaced with generated ones.
+var idRegexp = regexp.MustCompile(`^[\w.:-]+$`)
+
+type idContextKey struct{}
+
+// NewContext returns a copy of ctx which carries the correlation id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, idContextKey{}, id)
+}
+
+// FromContext returns the correlation id stored in ctx.
+// If there is no correlation id in ctx returns an empty string.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(idContextKey{}).(string)
+	return id
+}
+
+// UnaryInterceptor returns grpc.UnaryServerInterceptor which adds the correlation id to the context of each call.
+// The correlation id is taken from the metadata of the call or generated if the client doesn't send a valid one.
+// The contextual logger of the call contains the correlation id as the correlationId field.
+func UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := idFromMetadata(ctx)
+		ctx = NewContext(ctx, id)
+		ctx = logger.NewContext(ctx, logger.FromContext(ctx).WithCorrelationId(id))
+		return handler(ctx, req)
+	}
+}
+
+// idFromMetadata returns the first valid correlation id from the metadata of the call or generates a new one
+func idFromMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, id := range md.Get(Header) {
+		if len(id) <= maxIdLength && idRegexp.MatchString(id) {
+			return id
+		}
+	}
+	return uuid.New().String()
+}
@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"strings"
+	"testing"
+)
+
+func TestUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []string
+		want     string
+		wantUuid bool
+	}{
+		{
+			// Test that the correlation id is taken from the metadata of the call
+			name: "id from metadata",
+			ids:  []string{"request-1"},
+			want: "request-1",
+		},
+		{
+			// Test that invalid ids are skipped
+			name: "first valid id from metadata",
+			ids:  []string{"bad id", strings.Repeat("a", maxIdLength+1), "request:2"},
+			want: "request:2",
+		},
+		{
+			// Test that the correlation id is generated if the client doesn't send it
+			name:     "generated id",
+			wantUuid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pairs []string
+			for _, id := range tt.ids {
+				pairs = append(pairs, Header, id)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+			var gotId string
+			var gotLogger *logger.Entry
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				gotId, gotLogger = FromContext(ctx), logger.FromContext(ctx)
+				return nil, nil
+			}
+			if _, err := UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+				t.Fatalf("UnaryInterceptor() unexpected error = %v", err)
+			}
+			if tt.wantUuid {
+				if _, err := uuid.Parse(gotId); err != nil {
+					t.Errorf("UnaryInterceptor() id = %v, want a generated uuid", gotId)
+				}
+			} else if gotId != tt.want {
+				t.Errorf("UnaryInterceptor() id = %v, want %v", gotId, tt.want)
+			}
+			if fieldId := gotLogger.Fields()["correlationId"]; fieldId != gotId {
+				t.Errorf("UnaryInterceptor() logger correlationId = %v, want %v", fieldId, gotId)
+			}
+		})
+	}
+}
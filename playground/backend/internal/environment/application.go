@@ -17,6 +17,7 @@ package environment
 
 import (
 	"fmt"
+	"net"
 	"time"
 )
 
@@ -78,6 +79,148 @@ func NewCacheEnvs(cacheType, cacheAddress string, cacheExpirationTime time.Durat
 	}
 }
 
+//MetricsEnvs contains all environment variables that needed to expose metrics
+type MetricsEnvs struct {
+	// enabled is true if metrics should be collected and exposed
+	enabled bool
+
+	// port is a port of the HTTP server which exposes metrics
+	port int
+}
+
+// Enabled returns true if metrics should be collected and exposed
+func (me *MetricsEnvs) Enabled() bool {
+	return me.enabled
+}
+
+// Address returns address of the HTTP server which exposes metrics
+func (me *MetricsEnvs) Address() string {
+	return fmt.Sprintf(":%d", me.port)
+}
+
+// NewMetricsEnvs constructor for MetricsEnvs
+func NewMetricsEnvs(enabled bool, port int) *MetricsEnvs {
+	return &MetricsEnvs{
+		enabled: enabled,
+		port:    port,
+	}
+}
+
+//RateLimitEnvs contains all environment variables that needed to limit code runs of clients
+type RateLimitEnvs struct {
+	// rate is a number of code runs per second which are allowed for each client
+	rate float64
+
+	// burst is a maximum number of code runs which client can do at once
+	burst int
+
+	// clientKeys are keys which clients can send to be identified instead of IP address
+	clientKeys []string
+
+	// exemptions are client keys or IP addresses which aren't limited
+	exemptions []string
+
+	// trustedProxies are networks of proxies whose x-forwarded-for header is used to find the IP address of the client
+	trustedProxies []*net.IPNet
+}
+
+// Enabled returns true if code runs should be limited
+func (re *RateLimitEnvs) Enabled() bool {
+	return re.rate > 0
+}
+
+// Rate returns number of code runs per second which are allowed for each client
+func (re *RateLimitEnvs) Rate() float64 {
+	return re.rate
+}
+
+// Burst returns maximum number of code runs which client can do at once
+func (re *RateLimitEnvs) Burst() int {
+	return re.burst
+}
+
+// ClientKeys returns keys which clients can send to be identified instead of IP address
+func (re *RateLimitEnvs) ClientKeys() []string {
+	return re.clientKeys
+}
+
+// Exemptions returns client keys or IP addresses which aren't limited
+func (re *RateLimitEnvs) Exemptions() []string {
+	return re.exemptions
+}
+
+// TrustedProxies returns networks of proxies whose x-forwarded-for header is used to find the IP address of the client
+func (re *RateLimitEnvs) TrustedProxies() []*net.IPNet {
+	return re.trustedProxies
+}
+
+// NewRateLimitEnvs constructor for RateLimitEnvs
+func NewRateLimitEnvs(rate float64, burst int, clientKeys, exemptions []string, trustedProxies []*net.IPNet) *RateLimitEnvs {
+	return &RateLimitEnvs{
+		rate:           rate,
+		burst:          burst,
+		clientKeys:     clientKeys,
+		exemptions:     exemptions,
+		trustedProxies: trustedProxies,
+	}
+}
+
+//OutputLimitEnvs contains all environment variables that needed to limit the output of code runs
+type OutputLimitEnvs struct {
+	// limit is a number of bytes of each output stream of the run which are kept, the rest of the stream is discarded
+	limit int64
+
+	// hardLimit is a number of bytes of each output stream of the run after which the run is stopped
+	hardLimit int64
+}
+
+// Limit returns number of bytes of each output stream of the run which are kept.
+// Non-positive value means that the output isn't limited.
+func (oe *OutputLimitEnvs) Limit() int64 {
+	return oe.limit
+}
+
+// HardLimit returns number of bytes of each output stream of the run after which the run is stopped.
+// Non-positive value means that the run isn't stopped because of its output.
+func (oe *OutputLimitEnvs) HardLimit() int64 {
+	return oe.hardLimit
+}
+
+// NewOutputLimitEnvs constructor for OutputLimitEnvs
+func NewOutputLimitEnvs(limit, hardLimit int64) *OutputLimitEnvs {
+	return &OutputLimitEnvs{
+		limit:     limit,
+		hardLimit: hardLimit,
+	}
+}
+
+//RunHistoryEnvs contains all environment variables that needed to keep the run history of examples
+type RunHistoryEnvs struct {
+	// enabled is the flag to keep results of runs of examples
+	enabled bool
+
+	// retention is a number of the most recent runs which are kept for each example
+	retention int
+}
+
+// Enabled returns true if results of runs of examples should be kept
+func (rhe *RunHistoryEnvs) Enabled() bool {
+	return rhe.enabled
+}
+
+// Retention returns number of the most recent runs which are kept for each example
+func (rhe *RunHistoryEnvs) Retention() int {
+	return rhe.retention
+}
+
+// NewRunHistoryEnvs constructor for RunHistoryEnvs
+func NewRunHistoryEnvs(enabled bool, retention int) *RunHistoryEnvs {
+	return &RunHistoryEnvs{
+		enabled:   enabled,
+		retention: retention,
+	}
+}
+
 //ApplicationEnvs contains all environment variables that needed to run backend processes
 type ApplicationEnvs struct {
 	// workingDir is a root working directory of application.
@@ -88,9 +231,30 @@ type ApplicationEnvs struct {
 	// cacheEnvs contains environment variables for cache
 	cacheEnvs *CacheEnvs
 
+	// metricsEnvs contains environment variables for metrics
+	metricsEnvs *MetricsEnvs
+
+	// rateLimitEnvs contains environment variables for rate limiting
+	rateLimitEnvs *RateLimitEnvs
+
+	// outputLimitEnvs contains environment variables for limiting of the run output
+	outputLimitEnvs *OutputLimitEnvs
+
+	// runHistoryEnvs contains environment variables for the run history of examples
+	runHistoryEnvs *RunHistoryEnvs
+
 	// pipelineExecuteTimeout is timeout for code processing
 	pipelineExecuteTimeout time.Duration
 
+	// shutdownDrainPeriod is the max time which the server waits for code processing in progress when it shuts down
+	shutdownDrainPeriod time.Duration
+
+	// workspacePoolSize is a number of pipeline workspaces which are created in advance
+	workspacePoolSize int
+
+	// refreshPrecompiledObjects is the flag to rerun examples of the catalog and store their outputs at startup
+	refreshPrecompiledObjects bool
+
 	// launchSite is a launch site of application
 	launchSite string
 
@@ -99,20 +263,42 @@ type ApplicationEnvs struct {
 
 	// pipelinesFolder is name of folder in which the pipelines resources are stored
 	pipelinesFolder string
+
+	// logFormat is a format of the log lines written locally (text/json)
+	logFormat string
 }
 
 // NewApplicationEnvs constructor for ApplicationEnvs
-func NewApplicationEnvs(workingDir, launchSite, projectId, pipelinesFolder string, cacheEnvs *CacheEnvs, pipelineExecuteTimeout time.Duration) *ApplicationEnvs {
+func NewApplicationEnvs(workingDir, launchSite, projectId, pipelinesFolder, logFormat string, cacheEnvs *CacheEnvs, metricsEnvs *MetricsEnvs, rateLimitEnvs *RateLimitEnvs, outputLimitEnvs *OutputLimitEnvs, runHistoryEnvs *RunHistoryEnvs, pipelineExecuteTimeout, shutdownDrainPeriod time.Duration, workspacePoolSize int, refreshPrecompiledObjects bool) *ApplicationEnvs {
 	return &ApplicationEnvs{
-		workingDir:             workingDir,
-		cacheEnvs:              cacheEnvs,
-		pipelineExecuteTimeout: pipelineExecuteTimeout,
-		launchSite:             launchSite,
-		projectId:              projectId,
-		pipelinesFolder:        pipelinesFolder,
+		workingDir:                workingDir,
+		cacheEnvs:                 cacheEnvs,
+		metricsEnvs:               metricsEnvs,
+		rateLimitEnvs:             rateLimitEnvs,
+		outputLimitEnvs:           outputLimitEnvs,
+		runHistoryEnvs:            runHistoryEnvs,
+		pipelineExecuteTimeout:    pipelineExecuteTimeout,
+		shutdownDrainPeriod:       shutdownDrainPeriod,
+		workspacePoolSize:         workspacePoolSize,
+		refreshPrecompiledObjects: refreshPrecompiledObjects,
+		launchSite:                launchSite,
+		projectId:                 projectId,
+		pipelinesFolder:           pipelinesFolder,
+		logFormat:                 logFormat,
 	}
 }
 
+// WorkspacePoolSize returns number of pipeline workspaces which are created in advance.
+// Zero value means that workspaces are created for each run.
+func (ae *ApplicationEnvs) WorkspacePoolSize() int {
+	return ae.workspacePoolSize
+}
+
+// RefreshPrecompiledObjects returns true if examples of the catalog should be rerun and their outputs stored at startup
+func (ae *ApplicationEnvs) RefreshPrecompiledObjects() bool {
+	return ae.refreshPrecompiledObjects
+}
+
 // WorkingDir returns root working directory of application
 func (ae *ApplicationEnvs) WorkingDir() string {
 	return ae.workingDir
@@ -123,6 +309,32 @@ func (ae *ApplicationEnvs) CacheEnvs() *CacheEnvs {
 	return ae.cacheEnvs
 }
 
+// MetricsEnvs returns metrics environments
+func (ae *ApplicationEnvs) MetricsEnvs() *MetricsEnvs {
+	return ae.metricsEnvs
+}
+
+// RateLimitEnvs returns rate limiting environments
+func (ae *ApplicationEnvs) RateLimitEnvs() *RateLimitEnvs {
+	return ae.rateLimitEnvs
+}
+
+// OutputLimitEnvs returns environments for limiting of the run output
+func (ae *ApplicationEnvs) OutputLimitEnvs() *OutputLimitEnvs {
+	return ae.outputLimitEnvs
+}
+
+// RunHistoryEnvs returns environments for the run history of examples
+func (ae *ApplicationEnvs) RunHistoryEnvs() *RunHistoryEnvs {
+	return ae.runHistoryEnvs
+}
+
+// ShutdownDrainPeriod returns the max time which the server waits for code processing in progress when it shuts down.
+// Code processing which isn't finished after this time is canceled.
+func (ae *ApplicationEnvs) ShutdownDrainPeriod() time.Duration {
+	return ae.shutdownDrainPeriod
+}
+
 // PipelineExecuteTimeout returns timeout for code processing
 func (ae *ApplicationEnvs) PipelineExecuteTimeout() time.Duration {
 	return ae.pipelineExecuteTimeout
@@ -142,3 +354,8 @@ func (ae *ApplicationEnvs) GoogleProjectId() string {
 func (ae *ApplicationEnvs) PipelinesFolder() string {
 	return ae.pipelinesFolder
 }
+
+// LogFormat returns format of the log lines written locally
+func (ae *ApplicationEnvs) LogFormat() string {
+	return ae.logFormat
+}
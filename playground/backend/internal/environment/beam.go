@@ -40,17 +40,68 @@ func NewExecutorConfig(compileCmd, runCmd, testCmd string, compileArgs, runArgs,
 	return &ExecutorConfig{CompileCmd: compileCmd, RunCmd: runCmd, TestCmd: testCmd, CompileArgs: compileArgs, RunArgs: runArgs, TestArgs: testArgs}
 }
 
+// IoSubstitutions contains local stand-ins of inputs which can't be read without network or credentials, e.g. inputs of
+// examples from the catalog. Stand-ins are configured in the config file of the SDK:
+// - Paths: local paths by gs:// URIs. URIs which end with "/" are replaced as prefixes of other URIs
+// - BigQueryTables: code which reads the inline data or a local file instead of the read of the public BigQuery table
+// - OutputPaths: local paths which outputs of examples are written to, they are redirected to the directory of the run
+type IoSubstitutions struct {
+	Paths          map[string]string `json:"paths"`
+	BigQueryTables map[string]string `json:"bigquery_tables"`
+	OutputPaths    []string          `json:"output_paths"`
+}
+
+// Modes of the sandbox of the executed code
+const (
+	// SandboxModeOff runs the code with the environment of the backend in the folder of the pipeline
+	SandboxModeOff = "off"
+	// SandboxModeScrubbed runs the code with the minimal environment in the scratch folder of the pipeline
+	SandboxModeScrubbed = "scrubbed"
+	// SandboxModeStrict additionally allows the code to write only to the folder of the pipeline and WritablePaths
+	SandboxModeStrict = "strict"
+)
+
+// SandboxConfig contains the restrictions of the environment of the executed code. The mode is set by the environment
+// variable, other fields are configured in the "sandbox" field of the config file of the SDK:
+// - Envs: names of environment variables of the backend which are required by the SDK, e.g. proxy settings
+// - WritablePaths: paths which the code can write to in the strict mode besides the folder of the pipeline
+type SandboxConfig struct {
+	Mode          string   `json:"-"`
+	Envs          []string `json:"envs"`
+	WritablePaths []string `json:"writable_paths"`
+}
+
+// BeamEnvsOptions contains settings of the code processing of the SDK which are configured by environment variables
+// and the config file of the SDK:
+// - InjectRandomSeed: random generators in the code are created with the fixed seed
+// - SetupRetries: max number of retries of the setup command in case of transient infrastructure errors
+// - IoSubstitutions: local stand-ins of inputs of the code
+// - RejectInteractiveInput: the code which reads the standard input is rejected instead of the warning
+// - RejectJavaModules: the Java code which declares a module is rejected instead of stripping the module
+// - RunMetadata: versions of the sdk and the runner which are attached to results of runs
+// - Sandbox: restrictions of the environment of the executed code
+type BeamEnvsOptions struct {
+	InjectRandomSeed       bool
+	SetupRetries           int
+	IoSubstitutions        IoSubstitutions
+	RejectInteractiveInput bool
+	RejectJavaModules      bool
+	RunMetadata            *pb.RunMetadata
+	Sandbox                SandboxConfig
+}
+
 // BeamEnvs contains all environments related of ApacheBeam. These will use to run pipelines
 type BeamEnvs struct {
 	ApacheBeamSdk     pb.Sdk
 	ExecutorConfig    *ExecutorConfig
 	preparedModDir    string
 	numOfParallelJobs int
+	options           BeamEnvsOptions
 }
 
 // NewBeamEnvs is a BeamEnvs constructor
-func NewBeamEnvs(apacheBeamSdk pb.Sdk, executorConfig *ExecutorConfig, preparedModDir string, numOfParallelJobs int) *BeamEnvs {
-	return &BeamEnvs{ApacheBeamSdk: apacheBeamSdk, ExecutorConfig: executorConfig, preparedModDir: preparedModDir, numOfParallelJobs: numOfParallelJobs}
+func NewBeamEnvs(apacheBeamSdk pb.Sdk, executorConfig *ExecutorConfig, preparedModDir string, numOfParallelJobs int, options BeamEnvsOptions) *BeamEnvs {
+	return &BeamEnvs{ApacheBeamSdk: apacheBeamSdk, ExecutorConfig: executorConfig, preparedModDir: preparedModDir, numOfParallelJobs: numOfParallelJobs, options: options}
 }
 
 // PreparedModDir returns the path to the directory where prepared go.mod and go.sum are located
@@ -62,3 +113,43 @@ func (b *BeamEnvs) PreparedModDir() string {
 func (b *BeamEnvs) NumOfParallelJobs() int {
 	return b.numOfParallelJobs
 }
+
+// InjectRandomSeed returns true if all random generators in the code should be created with the fixed seed
+// to make the output reproducible.
+func (b *BeamEnvs) InjectRandomSeed() bool {
+	return b.options.InjectRandomSeed
+}
+
+// SetupRetries returns the max number of retries of the setup command (e.g. compilation with downloading of dependencies)
+// in case it fails because of a transient infrastructure error.
+func (b *BeamEnvs) SetupRetries() int {
+	return b.options.SetupRetries
+}
+
+// IoSubstitutions returns local stand-ins of inputs which are used instead of inputs from the config file of the SDK
+func (b *BeamEnvs) IoSubstitutions() IoSubstitutions {
+	return b.options.IoSubstitutions
+}
+
+// RejectInteractiveInput returns true if the code which reads the standard input should be rejected before the run.
+// Otherwise, the code is run with the empty input and the warning is added to the output of the preparation.
+func (b *BeamEnvs) RejectInteractiveInput() bool {
+	return b.options.RejectInteractiveInput
+}
+
+// RejectJavaModules returns true if the Java code which declares a module (module-info.java) should be rejected before
+// the run. Otherwise, the module declaration is removed from the code and the warning is added to the output of the preparation.
+func (b *BeamEnvs) RejectJavaModules() bool {
+	return b.options.RejectJavaModules
+}
+
+// RunMetadata returns versions of the sdk and the runner which are captured at the startup of the application.
+// The same metadata is attached to results of all runs and precompiled objects.
+func (b *BeamEnvs) RunMetadata() *pb.RunMetadata {
+	return b.options.RunMetadata
+}
+
+// Sandbox returns the restrictions of the environment of the executed code
+func (b *BeamEnvs) Sandbox() SandboxConfig {
+	return b.options.Sandbox
+}
@@ -18,12 +18,15 @@ package environment
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/logger"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -37,6 +40,13 @@ const (
 	workingDirKey                 = "APP_WORK_DIR"
 	preparedModDirKey             = "PREPARED_MOD_DIR"
 	numOfParallelJobsKey          = "NUM_PARALLEL_JOBS"
+	injectRandomSeedKey           = "INJECT_RANDOM_SEED"
+	setupRetriesKey               = "SETUP_RETRIES"
+	rejectInteractiveInputKey     = "REJECT_INTERACTIVE_INPUT"
+	rejectJavaModulesKey          = "REJECT_JAVA_MODULES"
+	beamVersionKey                = "BEAM_VERSION"
+	containerImageKey             = "CONTAINER_IMAGE"
+	sandboxModeKey                = "SANDBOX_MODE"
 	cacheTypeKey                  = "CACHE_TYPE"
 	cacheAddressKey               = "CACHE_ADDRESS"
 	beamPathKey                   = "BEAM_PATH"
@@ -46,20 +56,52 @@ const (
 	launchSiteKey                 = "LAUNCH_SITE"
 	projectIdKey                  = "GOOGLE_CLOUD_PROJECT"
 	pipelinesFolderKey            = "PIPELINES_FOLDER_NAME"
+	logFormatKey                  = "LOG_FORMAT"
+	metricsEnabledKey             = "METRICS_ENABLED"
+	metricsPortKey                = "METRICS_PORT"
+	rateLimitRateKey              = "RATE_LIMIT_RATE"
+	rateLimitBurstKey             = "RATE_LIMIT_BURST"
+	rateLimitClientKeysKey        = "RATE_LIMIT_CLIENT_KEYS"
+	rateLimitExemptionsKey        = "RATE_LIMIT_EXEMPTIONS"
+	rateLimitTrustedProxiesKey    = "RATE_LIMIT_TRUSTED_PROXIES"
+	runOutputLimitKey             = "RUN_OUTPUT_LIMIT"
+	runOutputHardLimitKey         = "RUN_OUTPUT_HARD_LIMIT"
+	workspacePoolSizeKey          = "WORKSPACE_POOL_SIZE"
+	refreshPrecompiledObjectsKey  = "REFRESH_PRECOMPILED_OBJECTS"
+	shutdownDrainPeriodKey        = "SHUTDOWN_DRAIN_PERIOD"
+	runHistoryEnabledKey          = "RUN_HISTORY_ENABLED"
+	runHistoryRetentionKey        = "RUN_HISTORY_RETENTION"
 	defaultPipelinesFolder        = "executable_files"
 	defaultLaunchSite             = "local"
+	defaultLogFormat              = "text"
 	defaultProtocol               = "HTTP"
 	defaultIp                     = "localhost"
 	defaultPort                   = 8080
+	defaultMetricsEnabled         = false
+	defaultMetricsPort            = 9090
+	defaultRateLimitRate          = 0
+	defaultRateLimitBurst         = 10
+	defaultRunOutputLimit         = 1 << 20
+	defaultRunOutputHardLimit     = 0
+	defaultWorkspacePoolSize      = 0
+	defaultRefreshPrecompiled     = false
+	defaultRunHistoryEnabled      = false
+	defaultRunHistoryRetention    = 50
+	listSeparator                 = ","
 	defaultSdk                    = pb.Sdk_SDK_JAVA
 	defaultBeamJarsPath           = "/opt/apache/beam/jars/*"
 	defaultCacheType              = "local"
 	defaultCacheAddress           = "localhost:6379"
 	defaultCacheKeyExpirationTime = time.Minute * 15
 	defaultPipelineExecuteTimeout = time.Minute * 10
+	defaultShutdownDrainPeriod    = time.Second * 20
 	jsonExt                       = ".json"
 	configFolderName              = "configs"
 	defaultNumOfParallelJobs      = 20
+	defaultSetupRetries           = 3
+	defaultSandboxMode            = SandboxModeOff
+	sandboxWrapper                = "bwrap"
+	versionProbeTimeout           = time.Second * 10
 )
 
 // Environment operates with environment structures: NetworkEnvs, BeamEnvs, ApplicationEnvs
@@ -92,15 +134,34 @@ func NewEnvironment(networkEnvs NetworkEnvs, beamEnvs BeamEnvs, appEnvs Applicat
 //	- cache expiration time: 15 minutes
 //	- type of cache: local
 //	- cache address: localhost:6379
+//	- metrics: disabled, port 9090
+//	- rate limiting: disabled, burst 10
+//	- run output limit: 1 MiB for each output stream, hard limit: disabled
+//	- run history: disabled, 50 runs for each example
 // If os environment variables don't contain a value for app working dir - returns error.
 func GetApplicationEnvsFromOsEnvs() (*ApplicationEnvs, error) {
 	pipelineExecuteTimeout := defaultPipelineExecuteTimeout
+	shutdownDrainPeriod := defaultShutdownDrainPeriod
 	cacheExpirationTime := defaultCacheKeyExpirationTime
 	cacheType := getEnv(cacheTypeKey, defaultCacheType)
 	cacheAddress := getEnv(cacheAddressKey, defaultCacheAddress)
 	launchSite := getEnv(launchSiteKey, defaultLaunchSite)
 	projectId := os.Getenv(projectIdKey)
 	pipelinesFolder := getEnv(pipelinesFolderKey, defaultPipelinesFolder)
+	logFormat := getEnv(logFormatKey, defaultLogFormat)
+	metricsEnabled := defaultMetricsEnabled
+	metricsPort := defaultMetricsPort
+	rateLimitRate := float64(defaultRateLimitRate)
+	rateLimitBurst := defaultRateLimitBurst
+	rateLimitClientKeys := getListEnv(rateLimitClientKeysKey)
+	rateLimitExemptions := getListEnv(rateLimitExemptionsKey)
+	rateLimitTrustedProxies := getNetworksEnv(rateLimitTrustedProxiesKey)
+	runOutputLimit := int64(defaultRunOutputLimit)
+	runOutputHardLimit := int64(defaultRunOutputHardLimit)
+	workspacePoolSize := defaultWorkspacePoolSize
+	refreshPrecompiledObjects := defaultRefreshPrecompiled
+	runHistoryEnabled := defaultRunHistoryEnabled
+	runHistoryRetention := defaultRunHistoryRetention
 
 	if value, present := os.LookupEnv(cacheKeyExpirationTimeKey); present {
 		if converted, err := time.ParseDuration(value); err == nil {
@@ -116,9 +177,86 @@ func GetApplicationEnvsFromOsEnvs() (*ApplicationEnvs, error) {
 			log.Printf("couldn't convert provided pipeline execute timeout. Using default %s\n", defaultPipelineExecuteTimeout)
 		}
 	}
+	if value, present := os.LookupEnv(shutdownDrainPeriodKey); present {
+		if converted, err := time.ParseDuration(value); err == nil && converted >= 0 {
+			shutdownDrainPeriod = converted
+		} else {
+			log.Printf("couldn't convert provided shutdown drain period. Using default %s\n", defaultShutdownDrainPeriod)
+		}
+	}
+	if value, present := os.LookupEnv(metricsEnabledKey); present {
+		if converted, err := strconv.ParseBool(value); err == nil {
+			metricsEnabled = converted
+		} else {
+			log.Printf("couldn't convert provided metrics enabled flag. Using default %t\n", defaultMetricsEnabled)
+		}
+	}
+	if value, present := os.LookupEnv(metricsPortKey); present {
+		if converted, err := strconv.Atoi(value); err == nil {
+			metricsPort = converted
+		} else {
+			log.Printf("couldn't convert provided metrics port. Using default %d\n", defaultMetricsPort)
+		}
+	}
+	if value, present := os.LookupEnv(rateLimitRateKey); present {
+		if converted, err := strconv.ParseFloat(value, 64); err == nil {
+			rateLimitRate = converted
+		} else {
+			log.Printf("couldn't convert provided rate limit. Rate limiting is disabled\n")
+		}
+	}
+	if value, present := os.LookupEnv(rateLimitBurstKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted > 0 {
+			rateLimitBurst = converted
+		} else {
+			log.Printf("couldn't convert provided rate limit burst. Using default %d\n", defaultRateLimitBurst)
+		}
+	}
+	if value, present := os.LookupEnv(runOutputLimitKey); present {
+		if converted, err := strconv.ParseInt(value, 10, 64); err == nil {
+			runOutputLimit = converted
+		} else {
+			log.Printf("couldn't convert provided run output limit. Using default %d\n", defaultRunOutputLimit)
+		}
+	}
+	if value, present := os.LookupEnv(runOutputHardLimitKey); present {
+		if converted, err := strconv.ParseInt(value, 10, 64); err == nil {
+			runOutputHardLimit = converted
+		} else {
+			log.Printf("couldn't convert provided run output hard limit. Runs aren't stopped because of their output\n")
+		}
+	}
+	if value, present := os.LookupEnv(workspacePoolSizeKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted >= 0 {
+			workspacePoolSize = converted
+		} else {
+			log.Printf("couldn't convert provided workspace pool size. Workspaces are created for each run\n")
+		}
+	}
+	if value, present := os.LookupEnv(refreshPrecompiledObjectsKey); present {
+		if converted, err := strconv.ParseBool(value); err == nil {
+			refreshPrecompiledObjects = converted
+		} else {
+			log.Printf("couldn't convert provided precompiled objects refresh flag. Using default %t\n", defaultRefreshPrecompiled)
+		}
+	}
+	if value, present := os.LookupEnv(runHistoryEnabledKey); present {
+		if converted, err := strconv.ParseBool(value); err == nil {
+			runHistoryEnabled = converted
+		} else {
+			log.Printf("couldn't convert provided run history enabled flag. Using default %t\n", defaultRunHistoryEnabled)
+		}
+	}
+	if value, present := os.LookupEnv(runHistoryRetentionKey); present {
+		if converted, err := strconv.Atoi(value); err == nil && converted > 0 {
+			runHistoryRetention = converted
+		} else {
+			log.Printf("couldn't convert provided run history retention. Using default %d\n", defaultRunHistoryRetention)
+		}
+	}
 
 	if value, present := os.LookupEnv(workingDirKey); present {
-		return NewApplicationEnvs(value, launchSite, projectId, pipelinesFolder, NewCacheEnvs(cacheType, cacheAddress, cacheExpirationTime), pipelineExecuteTimeout), nil
+		return NewApplicationEnvs(value, launchSite, projectId, pipelinesFolder, logFormat, NewCacheEnvs(cacheType, cacheAddress, cacheExpirationTime), NewMetricsEnvs(metricsEnabled, metricsPort), NewRateLimitEnvs(rateLimitRate, rateLimitBurst, rateLimitClientKeys, rateLimitExemptions, rateLimitTrustedProxies), NewOutputLimitEnvs(runOutputLimit, runOutputHardLimit), NewRunHistoryEnvs(runHistoryEnabled, runHistoryRetention), pipelineExecuteTimeout, shutdownDrainPeriod, workspacePoolSize, refreshPrecompiledObjects), nil
 	}
 	return nil, errors.New("APP_WORK_DIR env should be provided with os.env")
 }
@@ -164,6 +302,50 @@ func ConfigureBeamEnvs(workDir string) (*BeamEnvs, error) {
 		}
 	}
 
+	injectRandomSeed := false
+	if value, present := os.LookupEnv(injectRandomSeedKey); present {
+		convertedValue, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Errorf("Incorrect value for %s. Should be boolean. Will be used default value: false", injectRandomSeedKey)
+		} else {
+			injectRandomSeed = convertedValue
+		}
+	}
+
+	rejectInteractiveInput := false
+	if value, present := os.LookupEnv(rejectInteractiveInputKey); present {
+		convertedValue, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Errorf("Incorrect value for %s. Should be boolean. Will be used default value: false", rejectInteractiveInputKey)
+		} else {
+			rejectInteractiveInput = convertedValue
+		}
+	}
+
+	rejectJavaModules := false
+	if value, present := os.LookupEnv(rejectJavaModulesKey); present {
+		convertedValue, err := strconv.ParseBool(value)
+		if err != nil {
+			logger.Errorf("Incorrect value for %s. Should be boolean. Will be used default value: false", rejectJavaModulesKey)
+		} else {
+			rejectJavaModules = convertedValue
+		}
+	}
+
+	setupRetries := defaultSetupRetries
+	if value, present := os.LookupEnv(setupRetriesKey); present {
+		convertedValue, err := strconv.Atoi(value)
+		if err != nil {
+			logger.Errorf("Incorrect value for %s. Should be integer. Will be used default value: %d", setupRetriesKey, defaultSetupRetries)
+		} else {
+			if convertedValue < 0 {
+				logger.Errorf("Incorrect value for %s. Should be a non-negative integer value but it is %d. Will be used default value: %d", setupRetriesKey, convertedValue, defaultSetupRetries)
+			} else {
+				setupRetries = convertedValue
+			}
+		}
+	}
+
 	if value, present := os.LookupEnv(beamSdkKey); present {
 
 		switch value {
@@ -178,6 +360,10 @@ func ConfigureBeamEnvs(workDir string) (*BeamEnvs, error) {
 			sdk = pb.Sdk_SDK_PYTHON
 		case pb.Sdk_SDK_SCIO.String():
 			sdk = pb.Sdk_SDK_SCIO
+		case pb.Sdk_SDK_YAML.String():
+			sdk = pb.Sdk_SDK_YAML
+		case pb.Sdk_SDK_KOTLIN.String():
+			sdk = pb.Sdk_SDK_KOTLIN
 		}
 	}
 	if sdk == pb.Sdk_SDK_UNSPECIFIED {
@@ -188,7 +374,29 @@ func ConfigureBeamEnvs(workDir string) (*BeamEnvs, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewBeamEnvs(sdk, executorConfig, preparedModDir, numOfParallelJobs), nil
+	ioSubstitutions, err := getIoSubstitutionsFromJson(configPath)
+	if err != nil {
+		return nil, err
+	}
+	runMetadata, err := getRunMetadata(configPath)
+	if err != nil {
+		return nil, err
+	}
+	sandbox, err := getSandboxFromJson(configPath)
+	if err != nil {
+		return nil, err
+	}
+	sandbox.Mode = getSandboxMode()
+	options := BeamEnvsOptions{
+		InjectRandomSeed:       injectRandomSeed,
+		SetupRetries:           setupRetries,
+		IoSubstitutions:        *ioSubstitutions,
+		RejectInteractiveInput: rejectInteractiveInput,
+		RejectJavaModules:      rejectJavaModules,
+		RunMetadata:            runMetadata,
+		Sandbox:                *sandbox,
+	}
+	return NewBeamEnvs(sdk, executorConfig, preparedModDir, numOfParallelJobs, options), nil
 }
 
 // createExecutorConfig creates ExecutorConfig that corresponds to specific Apache Beam SDK.
@@ -199,7 +407,7 @@ func createExecutorConfig(apacheBeamSdk pb.Sdk, configPath string) (*ExecutorCon
 		return nil, err
 	}
 	switch apacheBeamSdk {
-	case pb.Sdk_SDK_JAVA:
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_KOTLIN: // Kotlin code is compiled and run against the same jars as Java code
 		args, err := ConcatBeamJarsToString()
 		if err != nil {
 			return nil, fmt.Errorf("error during proccessing jars: %s", err.Error())
@@ -211,6 +419,8 @@ func createExecutorConfig(apacheBeamSdk pb.Sdk, configPath string) (*ExecutorCon
 		// Go sdk doesn't need any additional arguments from the config file
 	case pb.Sdk_SDK_PYTHON:
 		// Python sdk doesn't need any additional arguments from the config file
+	case pb.Sdk_SDK_YAML:
+		// YAML sdk doesn't need any additional arguments from the config file
 	case pb.Sdk_SDK_SCIO:
 		return nil, errors.New("not yet supported")
 	}
@@ -240,6 +450,132 @@ func getConfigFromJson(configPath string) (*ExecutorConfig, error) {
 	return &executorConfig, err
 }
 
+// getIoSubstitutionsFromJson reads local stand-ins of inputs from the "io_substitutions" field of a json file
+func getIoSubstitutionsFromJson(configPath string) (*IoSubstitutions, error) {
+	file, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := struct {
+		IoSubstitutions IoSubstitutions `json:"io_substitutions"`
+	}{}
+	if err = json.Unmarshal(file, &config); err != nil {
+		return nil, err
+	}
+	return &config.IoSubstitutions, nil
+}
+
+// getSandboxFromJson reads restrictions of the executed code from the "sandbox" field of a json file
+func getSandboxFromJson(configPath string) (*SandboxConfig, error) {
+	file, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := struct {
+		Sandbox SandboxConfig `json:"sandbox"`
+	}{}
+	if err = json.Unmarshal(file, &config); err != nil {
+		return nil, err
+	}
+	return &config.Sandbox, nil
+}
+
+// getSandboxMode returns the mode of the sandbox from the environment variable.
+// The strict mode without the wrapper on the platform is applied as the scrubbed one, this is logged at the startup.
+func getSandboxMode() string {
+	mode := getEnv(sandboxModeKey, defaultSandboxMode)
+	switch mode {
+	case SandboxModeOff, SandboxModeScrubbed:
+	case SandboxModeStrict:
+		if _, err := exec.LookPath(sandboxWrapper); err != nil {
+			logger.Warnf("%s isn't found, so writable paths of the executed code aren't restricted in the %s mode of the sandbox", sandboxWrapper, SandboxModeStrict)
+		}
+	default:
+		logger.Errorf("Incorrect value for %s. Should be one of %s, %s, %s. Will be used default value: %s", sandboxModeKey, SandboxModeOff, SandboxModeScrubbed, SandboxModeStrict, defaultSandboxMode)
+		mode = defaultSandboxMode
+	}
+	return mode
+}
+
+// getRunMetadata returns versions of the sdk and the runner. The version of the sdk is the output of the command from
+// the "sdk_version_cmd" field of a json file. The version of Beam is read from the environment variable or, if it isn't
+// set, is the output of the command from the "beam_version_cmd" field. Commands are run once at the startup.
+func getRunMetadata(configPath string) (*pb.RunMetadata, error) {
+	file, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := struct {
+		SdkVersionCmd  []string `json:"sdk_version_cmd"`
+		BeamVersionCmd []string `json:"beam_version_cmd"`
+	}{}
+	if err = json.Unmarshal(file, &config); err != nil {
+		return nil, err
+	}
+	beamVersion := os.Getenv(beamVersionKey)
+	if beamVersion == "" {
+		beamVersion = probeVersion(config.BeamVersionCmd)
+	}
+	return &pb.RunMetadata{
+		SdkVersion:     probeVersion(config.SdkVersionCmd),
+		BeamVersion:    beamVersion,
+		ContainerImage: os.Getenv(containerImageKey),
+	}, nil
+}
+
+// probeVersion runs the command and returns the first non-empty line of its output, e.g. "java -version" prints
+// the version to stderr. Returns an empty string if there is no command or it fails.
+func probeVersion(command []string) string {
+	if len(command) == 0 {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), versionProbeTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, command[0], command[1:]...).CombinedOutput()
+	if err != nil {
+		logger.Errorf("Error during probe of the version by %s: %s. Version will be empty", strings.Join(command, " "), err.Error())
+		return ""
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// getListEnv returns values of a comma-separated environment variable or nil
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), listSeparator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getNetworksEnv returns networks of a comma-separated environment variable of CIDRs or IP addresses or nil.
+// IP address is a network of the single address. Values which are neither CIDRs nor IP addresses are skipped.
+func getNetworksEnv(key string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, value := range getListEnv(key) {
+		if ip := net.ParseIP(value); ip != nil {
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(8*len(ip), 8*len(ip))})
+			continue
+		}
+		if _, network, err := net.ParseCIDR(value); err == nil {
+			networks = append(networks, network)
+		} else {
+			log.Printf("couldn't convert provided network %s of %s. The network is skipped\n", value, key)
+		}
+	}
+	return networks
+}
+
 // getEnv returns an environment variable or default value
 func getEnv(key, defaultValue string) string {
 	if value, ok := os.LookupEnv(key); ok {
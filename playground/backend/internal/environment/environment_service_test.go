@@ -19,10 +19,12 @@ import (
 	playground "beam.apache.org/playground/backend/internal/api/v1"
 	"fmt"
 	"io/fs"
+	"net"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 )
 
 const (
@@ -92,16 +94,16 @@ func TestNewEnvironment(t *testing.T) {
 	}{
 		{name: "create env service with default envs", want: &Environment{
 			NetworkEnvs:     *NewNetworkEnvs(defaultIp, defaultPort, defaultProtocol),
-			BeamSdkEnvs:     *NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, 0),
-			ApplicationEnvs: *NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout),
+			BeamSdkEnvs:     *NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, 0, BeamEnvsOptions{}),
+			ApplicationEnvs: *NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, defaultWorkspacePoolSize, defaultRefreshPrecompiled),
 		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if got := NewEnvironment(
 				*NewNetworkEnvs(defaultIp, defaultPort, defaultProtocol),
-				*NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, 0),
-				*NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout)); !reflect.DeepEqual(got, tt.want) {
+				*NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, 0, BeamEnvsOptions{}),
+				*NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, defaultWorkspacePoolSize, defaultRefreshPrecompiled)); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewEnvironment() = %v, want %v", got, tt.want)
 			}
 		})
@@ -125,16 +127,59 @@ func Test_getSdkEnvsFromOsEnvs(t *testing.T) {
 		},
 		{
 			name:      "default beam envs",
-			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs),
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{SetupRetries: defaultSetupRetries, RunMetadata: &playground.RunMetadata{}, Sandbox: SandboxConfig{Mode: SandboxModeOff}}),
 			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA"},
 			wantErr:   false,
 		},
 		{
 			name:      "specific sdk key in os envs",
-			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs),
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{SetupRetries: defaultSetupRetries, RunMetadata: &playground.RunMetadata{}, Sandbox: SandboxConfig{Mode: SandboxModeOff}}),
 			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA"},
 			wantErr:   false,
 		},
+		{
+			name:      "random seed injection in os envs",
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{InjectRandomSeed: true, SetupRetries: defaultSetupRetries, RunMetadata: &playground.RunMetadata{}, Sandbox: SandboxConfig{Mode: SandboxModeOff}}),
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA", injectRandomSeedKey: "true"},
+			wantErr:   false,
+		},
+		{
+			name:      "setup retries in os envs",
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{SetupRetries: 5, RunMetadata: &playground.RunMetadata{}, Sandbox: SandboxConfig{Mode: SandboxModeOff}}),
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA", injectRandomSeedKey: "false", setupRetriesKey: "5"},
+			wantErr:   false,
+		},
+		{
+			name:      "rejection of interactive input in os envs",
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{SetupRetries: 5, RejectInteractiveInput: true, RunMetadata: &playground.RunMetadata{}, Sandbox: SandboxConfig{Mode: SandboxModeOff}}),
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA", rejectInteractiveInputKey: "true"},
+			wantErr:   false,
+		},
+		{
+			name:      "run metadata in os envs",
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{SetupRetries: 5, RejectInteractiveInput: true, RunMetadata: &playground.RunMetadata{BeamVersion: "2.33.0", ContainerImage: "gcr.io/project/backend-java@sha256:1a2b"}, Sandbox: SandboxConfig{Mode: SandboxModeOff}}),
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA", beamVersionKey: "2.33.0", containerImageKey: "gcr.io/project/backend-java@sha256:1a2b"},
+			wantErr:   false,
+		},
+		{
+			name:      "sandbox mode in os envs",
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{SetupRetries: 5, RejectInteractiveInput: true, RunMetadata: &playground.RunMetadata{BeamVersion: "2.33.0", ContainerImage: "gcr.io/project/backend-java@sha256:1a2b"}, Sandbox: SandboxConfig{Mode: SandboxModeScrubbed}}),
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA", sandboxModeKey: SandboxModeScrubbed},
+			wantErr:   false,
+		},
+		{
+			// Test that the unknown mode of the sandbox is replaced with the default one
+			name:      "incorrect sandbox mode in os envs",
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{SetupRetries: 5, RejectInteractiveInput: true, RunMetadata: &playground.RunMetadata{BeamVersion: "2.33.0", ContainerImage: "gcr.io/project/backend-java@sha256:1a2b"}, Sandbox: SandboxConfig{Mode: SandboxModeOff}}),
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA", sandboxModeKey: "always"},
+			wantErr:   false,
+		},
+		{
+			name:      "rejection of java modules in os envs",
+			want:      NewBeamEnvs(defaultSdk, executorConfig, preparedModDir, defaultNumOfParallelJobs, BeamEnvsOptions{SetupRetries: 5, RejectInteractiveInput: true, RejectJavaModules: true, RunMetadata: &playground.RunMetadata{BeamVersion: "2.33.0", ContainerImage: "gcr.io/project/backend-java@sha256:1a2b"}, Sandbox: SandboxConfig{Mode: SandboxModeOff}}),
+			envsToSet: map[string]string{beamSdkKey: "SDK_JAVA", rejectJavaModulesKey: "true"},
+			wantErr:   false,
+		},
 		{
 			name:      "wrong sdk key in os envs",
 			want:      nil,
@@ -210,10 +255,52 @@ func Test_getApplicationEnvsFromOsEnvs(t *testing.T) {
 	}{
 		{
 			name:      "working dir is provided",
-			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, defaultPipelineExecuteTimeout),
+			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, defaultWorkspacePoolSize, defaultRefreshPrecompiled),
 			wantErr:   false,
 			envsToSet: map[string]string{workingDirKey: "/app", launchSiteKey: defaultLaunchSite, projectIdKey: defaultProjectId},
 		},
+		{
+			name:      "metrics are enabled",
+			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{true, 9100}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, defaultWorkspacePoolSize, defaultRefreshPrecompiled),
+			wantErr:   false,
+			envsToSet: map[string]string{workingDirKey: "/app", metricsEnabledKey: "true", metricsPortKey: "9100"},
+		},
+		{
+			name:      "rate limiting is enabled",
+			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{0.5, 5, []string{"frontend"}, []string{"frontend", "10.0.0.1"}, []*net.IPNet{{IP: net.IPv4(10, 128, 0, 0).To4(), Mask: net.CIDRMask(9, 32)}, {IP: net.IPv4(35, 191, 0, 1).To4(), Mask: net.CIDRMask(32, 32)}}}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, defaultWorkspacePoolSize, defaultRefreshPrecompiled),
+			wantErr:   false,
+			envsToSet: map[string]string{workingDirKey: "/app", rateLimitRateKey: "0.5", rateLimitBurstKey: "5", rateLimitClientKeysKey: "frontend", rateLimitExemptionsKey: "frontend, 10.0.0.1", rateLimitTrustedProxiesKey: "10.128.0.0/9, 35.191.0.1, not_a_network"},
+		},
+		{
+			name:      "run output is limited",
+			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{1024, 4096}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, defaultWorkspacePoolSize, defaultRefreshPrecompiled),
+			wantErr:   false,
+			envsToSet: map[string]string{workingDirKey: "/app", runOutputLimitKey: "1024", runOutputHardLimitKey: "4096"},
+		},
+		{
+			name:      "workspace pool is enabled",
+			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, 4, defaultRefreshPrecompiled),
+			wantErr:   false,
+			envsToSet: map[string]string{workingDirKey: "/app", workspacePoolSizeKey: "4"},
+		},
+		{
+			name:      "precompiled objects refresh is enabled",
+			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, defaultWorkspacePoolSize, true),
+			wantErr:   false,
+			envsToSet: map[string]string{workingDirKey: "/app", refreshPrecompiledObjectsKey: "true"},
+		},
+		{
+			name:      "shutdown drain period is provided",
+			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{defaultRunHistoryEnabled, defaultRunHistoryRetention}, defaultPipelineExecuteTimeout, time.Minute, defaultWorkspacePoolSize, defaultRefreshPrecompiled),
+			wantErr:   false,
+			envsToSet: map[string]string{workingDirKey: "/app", shutdownDrainPeriodKey: "1m"},
+		},
+		{
+			name:      "run history is enabled",
+			want:      NewApplicationEnvs("/app", defaultLaunchSite, defaultProjectId, defaultPipelinesFolder, defaultLogFormat, &CacheEnvs{defaultCacheType, defaultCacheAddress, defaultCacheKeyExpirationTime}, &MetricsEnvs{defaultMetricsEnabled, defaultMetricsPort}, &RateLimitEnvs{defaultRateLimitRate, defaultRateLimitBurst, nil, nil, nil}, &OutputLimitEnvs{defaultRunOutputLimit, defaultRunOutputHardLimit}, &RunHistoryEnvs{true, 10}, defaultPipelineExecuteTimeout, defaultShutdownDrainPeriod, defaultWorkspacePoolSize, defaultRefreshPrecompiled),
+			wantErr:   false,
+			envsToSet: map[string]string{workingDirKey: "/app", runHistoryEnabledKey: "true", runHistoryRetentionKey: "10"},
+		},
 		{
 			name:    "working dir isn't provided",
 			want:    nil,
@@ -308,3 +395,170 @@ func Test_getConfigFromJson(t *testing.T) {
 		})
 	}
 }
+
+func Test_getIoSubstitutionsFromJson(t *testing.T) {
+	configWithSubstitutions := filepath.Join(t.TempDir(), defaultSdk.String()+jsonExt)
+	config := `{"run_cmd": "java", "io_substitutions": {"paths": {"gs://apache-beam-samples/shakespeare/": "/opt/samples/shakespeare/"}, "bigquery_tables": {"bigquery-public-data:samples.shakespeare": "Create.of(new TableRow())"}, "output_paths": ["/tmp/output"]}}`
+	if err := os.WriteFile(configWithSubstitutions, []byte(config), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	tests := []struct {
+		name       string
+		configPath string
+		want       *IoSubstitutions
+		wantErr    bool
+	}{
+		{
+			name:       "config with substitutions",
+			configPath: configWithSubstitutions,
+			want: &IoSubstitutions{
+				Paths:          map[string]string{"gs://apache-beam-samples/shakespeare/": "/opt/samples/shakespeare/"},
+				BigQueryTables: map[string]string{"bigquery-public-data:samples.shakespeare": "Create.of(new TableRow())"},
+				OutputPaths:    []string{"/tmp/output"},
+			},
+			wantErr: false,
+		},
+		{
+			// Test that substitutions are optional
+			name:       "config without substitutions",
+			configPath: filepath.Join(configFolderName, defaultSdk.String()+jsonExt),
+			want:       &IoSubstitutions{},
+			wantErr:    false,
+		},
+		{
+			name:       "error if wrong json path",
+			configPath: filepath.Join("wrong_folder", defaultSdk.String()+jsonExt),
+			want:       nil,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getIoSubstitutionsFromJson(tt.configPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getIoSubstitutionsFromJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getIoSubstitutionsFromJson() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getRunMetadata(t *testing.T) {
+	configWithProbes := filepath.Join(t.TempDir(), defaultSdk.String()+jsonExt)
+	config := `{"run_cmd": "java", "sdk_version_cmd": ["/bin/sh", "-c", "echo '\nopenjdk version 11.0.12' >&2"], "beam_version_cmd": ["/bin/sh", "-c", "echo 2.32.0"]}`
+	if err := os.WriteFile(configWithProbes, []byte(config), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	configWithFailedProbes := filepath.Join(t.TempDir(), defaultSdk.String()+jsonExt)
+	config = `{"run_cmd": "java", "sdk_version_cmd": ["/bin/sh", "-c", "exit 1"], "beam_version_cmd": ["no_such_command"]}`
+	if err := os.WriteFile(configWithFailedProbes, []byte(config), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	tests := []struct {
+		name       string
+		configPath string
+		envsToSet  map[string]string
+		want       *playground.RunMetadata
+		wantErr    bool
+	}{
+		{
+			// Test that the first non-empty line of the output is used, stderr included
+			name:       "versions from the probe commands",
+			configPath: configWithProbes,
+			envsToSet:  map[string]string{},
+			want:       &playground.RunMetadata{SdkVersion: "openjdk version 11.0.12", BeamVersion: "2.32.0"},
+			wantErr:    false,
+		},
+		{
+			// Test that os envs take precedence over the probe of the beam version
+			name:       "versions from os envs",
+			configPath: configWithProbes,
+			envsToSet:  map[string]string{beamVersionKey: "2.33.0", containerImageKey: "gcr.io/project/backend-java:2.33.0"},
+			want:       &playground.RunMetadata{SdkVersion: "openjdk version 11.0.12", BeamVersion: "2.33.0", ContainerImage: "gcr.io/project/backend-java:2.33.0"},
+			wantErr:    false,
+		},
+		{
+			// Test that failed probes don't break the startup
+			name:       "failed probe commands",
+			configPath: configWithFailedProbes,
+			envsToSet:  map[string]string{},
+			want:       &playground.RunMetadata{},
+			wantErr:    false,
+		},
+		{
+			name:       "error if wrong json path",
+			configPath: filepath.Join("wrong_folder", defaultSdk.String()+jsonExt),
+			envsToSet:  map[string]string{},
+			want:       nil,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Unsetenv(beamVersionKey)
+			os.Unsetenv(containerImageKey)
+			if err := setOsEnvs(tt.envsToSet); err != nil {
+				t.Fatalf("couldn't setup os env")
+			}
+			defer os.Unsetenv(beamVersionKey)
+			defer os.Unsetenv(containerImageKey)
+			got, err := getRunMetadata(tt.configPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getRunMetadata() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.GetSdkVersion() != tt.want.GetSdkVersion() || got.GetBeamVersion() != tt.want.GetBeamVersion() || got.GetContainerImage() != tt.want.GetContainerImage() {
+				t.Errorf("getRunMetadata() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSandboxFromJson(t *testing.T) {
+	configWithSandbox := filepath.Join(t.TempDir(), defaultSdk.String()+jsonExt)
+	config := `{"run_cmd": "java", "sandbox": {"envs": ["HTTP_PROXY", "JAVA_HOME"], "writable_paths": ["/opt/playground/cache"]}}`
+	if err := os.WriteFile(configWithSandbox, []byte(config), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	tests := []struct {
+		name       string
+		configPath string
+		want       *SandboxConfig
+		wantErr    bool
+	}{
+		{
+			name:       "config with sandbox",
+			configPath: configWithSandbox,
+			want:       &SandboxConfig{Envs: []string{"HTTP_PROXY", "JAVA_HOME"}, WritablePaths: []string{"/opt/playground/cache"}},
+			wantErr:    false,
+		},
+		{
+			// Test that the sandbox is optional
+			name:       "config without sandbox",
+			configPath: filepath.Join(configFolderName, defaultSdk.String()+jsonExt),
+			want:       &SandboxConfig{},
+			wantErr:    false,
+		},
+		{
+			name:       "error if wrong json path",
+			configPath: filepath.Join("wrong_folder", defaultSdk.String()+jsonExt),
+			want:       nil,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getSandboxFromJson(tt.configPath)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getSandboxFromJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSandboxFromJson() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
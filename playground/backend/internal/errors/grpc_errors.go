@@ -38,3 +38,10 @@ func InternalError(title string, formatMessage string, args ...interface{}) erro
 	message := fmt.Sprintf(formatMessage, args...)
 	return status.Errorf(codes.Internal, "%s: %s", title, message)
 }
+
+// UnavailableError returns error with Unavailable code error and message like "title: message".
+// Clients can retry the request later.
+func UnavailableError(title string, formatMessage string, args ...interface{}) error {
+	message := fmt.Sprintf(formatMessage, args...)
+	return status.Errorf(codes.Unavailable, "%s: %s", title, message)
+}
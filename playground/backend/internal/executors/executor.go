@@ -20,6 +20,7 @@ import (
 	"beam.apache.org/playground/backend/internal/validators"
 	"context"
 	"os/exec"
+	"strings"
 	"sync"
 )
 
@@ -37,6 +38,7 @@ type CmdConfiguration struct {
 	commandName     string
 	commandArgs     []string
 	pipelineOptions []string
+	sandbox         Sandbox
 }
 
 // Executor struct for all sdks (Java/Python/Go/SCIO)
@@ -97,7 +99,7 @@ func (ex *Executor) Compile(ctx context.Context) *exec.Cmd {
 	args := append(ex.compileArgs.commandArgs, ex.compileArgs.fileName)
 	cmd := exec.CommandContext(ctx, ex.compileArgs.commandName, args...)
 	cmd.Dir = ex.compileArgs.workingDir
-	return cmd
+	return withoutInput(cmd)
 }
 
 // Run prepares the Cmd for execution of the code
@@ -112,7 +114,7 @@ func (ex *Executor) Run(ctx context.Context) *exec.Cmd {
 	}
 	cmd := exec.CommandContext(ctx, ex.runArgs.commandName, args...)
 	cmd.Dir = ex.runArgs.workingDir
-	return cmd
+	return withoutInput(ex.runArgs.sandbox.apply(ctx, cmd))
 }
 
 // RunTest prepares the Cmd for execution of the unit test
@@ -121,5 +123,12 @@ func (ex *Executor) RunTest(ctx context.Context) *exec.Cmd {
 	args := append(ex.testArgs.commandArgs, ex.testArgs.fileName)
 	cmd := exec.CommandContext(ctx, ex.testArgs.commandName, args...)
 	cmd.Dir = ex.testArgs.workingDir
+	return withoutInput(ex.testArgs.sandbox.apply(ctx, cmd))
+}
+
+// withoutInput connects the standard input of cmd to an empty reader.
+// The playground doesn't provide interactive input, so the code which reads it gets EOF instead of waiting until the timeout.
+func withoutInput(cmd *exec.Cmd) *exec.Cmd {
+	cmd.Stdin = strings.NewReader("")
 	return cmd
 }
@@ -146,6 +146,14 @@ func (b *RunBuilder) WithArgs(runArgs []string) *RunBuilder {
 	return b
 }
 
+//WithSandbox sets the sandbox which restricts the environment of the executed code
+func (b *RunBuilder) WithSandbox(sandbox Sandbox) *RunBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.runArgs.sandbox = sandbox
+	})
+	return b
+}
+
 //WithGraphOutput adds the need of graph output to executor
 func (b *RunBuilder) WithGraphOutput() *RunBuilder {
 	b.actions = append(b.actions, func(e *Executor) {
@@ -178,6 +186,14 @@ func (b *UnitTestExecutorBuilder) WithWorkingDir(dir string) *UnitTestExecutorBu
 	return b
 }
 
+//WithSandbox sets the sandbox which restricts the environment of the executed unit test
+func (b *UnitTestExecutorBuilder) WithSandbox(sandbox Sandbox) *UnitTestExecutorBuilder {
+	b.actions = append(b.actions, func(e *Executor) {
+		e.testArgs.sandbox = sandbox
+	})
+	return b
+}
+
 //WithGraphOutput adds the need of graph output to executor
 func (b *UnitTestExecutorBuilder) WithGraphOutput() *UnitTestExecutorBuilder {
 	b.actions = append(b.actions, func(e *Executor) {
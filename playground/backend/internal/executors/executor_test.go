@@ -19,9 +19,14 @@ import (
 	"beam.apache.org/playground/backend/internal/preparers"
 	"beam.apache.org/playground/backend/internal/validators"
 	"context"
+	"errors"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestExecutor_Compile(t *testing.T) {
@@ -191,3 +196,180 @@ func TestExecutor_RunTest(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_RunWithoutInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		runArgs CmdConfiguration
+		wantErr bool
+	}{
+		{
+			// Test case with the code which reads the whole input.
+			// As a result, want to receive the finished run instead of the timeout.
+			name: "read the whole input",
+			runArgs: CmdConfiguration{
+				workingDir:      "./",
+				commandName:     "cat",
+				pipelineOptions: []string{""},
+			},
+			wantErr: false,
+		},
+		{
+			// Test case with the code which waits for a line of the input.
+			// As a result, want to receive the failed run instead of the timeout.
+			name: "read a line of the input",
+			runArgs: CmdConfiguration{
+				workingDir:      "./",
+				commandName:     "sh",
+				commandArgs:     []string{"-c", "read line"},
+				pipelineOptions: []string{""},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			ex := &Executor{runArgs: tt.runArgs}
+			cmd := ex.Run(ctx)
+			if cmd.Stdin == nil {
+				t.Fatalf("Run() stdin of the cmd isn't set")
+			}
+			err := cmd.Run()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				t.Fatalf("Run() the cmd is waiting for the input until the timeout")
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecutor_RunInSandbox(t *testing.T) {
+	sensitiveEnvs := map[string]string{
+		"CACHE_ADDRESS":                  "redis.internal:6379",
+		"GOOGLE_APPLICATION_CREDENTIALS": "/var/secrets/google/key.json",
+		"HTTP_PROXY":                     "http://127.0.0.1:8081",
+	}
+	for key, value := range sensitiveEnvs {
+		if err := os.Setenv(key, value); err != nil {
+			t.Fatalf("error during test setup: %s", err.Error())
+		}
+		defer os.Unsetenv(key)
+	}
+	pipelineDir := t.TempDir()
+	scratchDir := filepath.Join(pipelineDir, "scratch")
+	if err := os.Mkdir(scratchDir, 0700); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	// the code dumps its environment and its working directory
+	dumpEnv := CmdConfiguration{
+		workingDir:      pipelineDir,
+		commandName:     "sh",
+		commandArgs:     []string{"-c", "env; echo CWD=$(pwd)"},
+		pipelineOptions: []string{""},
+	}
+	tests := []struct {
+		name     string
+		sandbox  Sandbox
+		wantEnvs []string
+		// leakedEnvs are envs of the backend which shouldn't be seen by the code
+		leakedEnvs []string
+	}{
+		{
+			// Test case with the code which is run without the sandbox.
+			// As a result, want to receive the whole environment of the backend as before.
+			name:       "without sandbox",
+			sandbox:    Sandbox{},
+			wantEnvs:   []string{"CACHE_ADDRESS=redis.internal:6379", "HTTP_PROXY=http://127.0.0.1:8081", "CWD=" + pipelineDir},
+			leakedEnvs: []string{},
+		},
+		{
+			// Test case with the code which is run in the sandbox.
+			// As a result, want to receive only the minimal environment and envs of the sdk in the scratch folder.
+			name:       "with sandbox",
+			sandbox:    Sandbox{HomeDir: pipelineDir, WorkingDir: scratchDir, Envs: []string{"HTTP_PROXY", "NOT_SET_ENV"}},
+			wantEnvs:   []string{"HOME=" + pipelineDir, "TMPDIR=" + scratchDir, "HTTP_PROXY=http://127.0.0.1:8081", "PATH=" + os.Getenv("PATH"), "CWD=" + scratchDir},
+			leakedEnvs: []string{"CACHE_ADDRESS", "GOOGLE_APPLICATION_CREDENTIALS", "NOT_SET_ENV"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runArgs := dumpEnv
+			runArgs.sandbox = tt.sandbox
+			testArgs := dumpEnv
+			testArgs.sandbox = tt.sandbox
+			ex := &Executor{runArgs: runArgs, testArgs: testArgs}
+			for _, cmd := range []*exec.Cmd{ex.Run(context.Background()), ex.RunTest(context.Background())} {
+				output, err := cmd.Output()
+				if err != nil {
+					t.Fatalf("%s error = %v", cmd.String(), err)
+				}
+				lines := strings.Split(string(output), "\n")
+				for _, env := range tt.wantEnvs {
+					if !containsLine(lines, env) {
+						t.Errorf("%s output = %s, want %s", cmd.String(), output, env)
+					}
+				}
+				for _, key := range tt.leakedEnvs {
+					if strings.Contains(string(output), key+"=") {
+						t.Errorf("%s output = %s, want no %s", cmd.String(), output, key)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExecutor_RunInStrictSandbox(t *testing.T) {
+	if _, err := exec.LookPath(sandboxWrapper); err != nil {
+		t.Skipf("%s isn't available on the platform", sandboxWrapper)
+	}
+	pipelineDir := t.TempDir()
+	outsideDir := t.TempDir()
+	sandbox := Sandbox{HomeDir: pipelineDir, WorkingDir: pipelineDir, Strict: true}
+	if err := sandbox.apply(context.Background(), exec.Command("true")).Run(); err != nil {
+		t.Skipf("%s can't create the sandbox on the platform: %s", sandboxWrapper, err.Error())
+	}
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{
+			name:    "write to the folder of the pipeline",
+			path:    filepath.Join(pipelineDir, "file.txt"),
+			wantErr: false,
+		},
+		{
+			name:    "write outside of the folder of the pipeline",
+			path:    filepath.Join(outsideDir, "file.txt"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ex := &Executor{runArgs: CmdConfiguration{
+				commandName:     "sh",
+				commandArgs:     []string{"-c", "echo output > " + tt.path},
+				pipelineOptions: []string{""},
+				sandbox:         sandbox,
+			}}
+			if err := ex.Run(context.Background()).Run(); (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// containsLine checks that lines contain the line
+func containsLine(lines []string, line string) bool {
+	for _, l := range lines {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
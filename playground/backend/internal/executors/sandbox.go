@@ -0,0 +1,95 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executors
+
+import (
+	"context"
+	"os"
+	"os/exec"
+)
+
+const (
+	pathEnvKey     = "PATH"
+	homeEnvKey     = "HOME"
+	tmpDirEnvKey   = "TMPDIR"
+	sandboxWrapper = "bwrap"
+)
+
+// Sandbox restricts the environment of the executed code:
+// - HomeDir: the folder which is used as HOME of the code, the folder of the pipeline
+// - WorkingDir: the scratch folder which is used as the working and the temporary directory of the code
+// - Envs: names of environment variables of the backend which are passed to the code, e.g. proxy settings of the SDK
+// - WritablePaths: paths which the code can write to besides HomeDir, applied only if Strict is true
+// - Strict: if true the code can't write anywhere except HomeDir and WritablePaths. It is applied with the
+// bubblewrap wrapper, so the restriction is skipped on platforms without it
+// Sandbox without HomeDir doesn't restrict the code.
+type Sandbox struct {
+	HomeDir       string
+	WorkingDir    string
+	Envs          []string
+	WritablePaths []string
+	Strict        bool
+}
+
+// apply returns cmd which is run with the minimal environment in the scratch folder.
+// The backend environment isn't inherited, so the code doesn't get cache endpoints and credentials of the backend.
+func (sandbox Sandbox) apply(ctx context.Context, cmd *exec.Cmd) *exec.Cmd {
+	if sandbox.HomeDir == "" {
+		return cmd
+	}
+	if sandbox.WorkingDir != "" {
+		cmd.Dir = sandbox.WorkingDir
+	}
+	if sandbox.Strict {
+		if wrapper, err := exec.LookPath(sandboxWrapper); err == nil {
+			dir := cmd.Dir
+			cmd = exec.CommandContext(ctx, wrapper, append(sandbox.wrapperArgs(dir), append([]string{cmd.Path}, cmd.Args[1:]...)...)...)
+			cmd.Dir = dir
+		}
+	}
+	cmd.Env = sandbox.env(cmd.Dir)
+	return cmd
+}
+
+// env returns environment variables of the code: PATH of the backend, HOME, TMPDIR and variables with names from Envs
+func (sandbox Sandbox) env(tmpDir string) []string {
+	env := []string{
+		pathEnvKey + "=" + os.Getenv(pathEnvKey),
+		homeEnvKey + "=" + sandbox.HomeDir,
+	}
+	if tmpDir != "" {
+		env = append(env, tmpDirEnvKey+"="+tmpDir)
+	}
+	for _, key := range sandbox.Envs {
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+	return env
+}
+
+// wrapperArgs returns arguments of bubblewrap which mount the file system read-only except HomeDir and WritablePaths.
+// /tmp is replaced with an empty folder of the run, so code of different pipelines doesn't see files of each other.
+func (sandbox Sandbox) wrapperArgs(dir string) []string {
+	args := []string{"--ro-bind", "/", "/", "--dev", "/dev", "--proc", "/proc", "--tmpfs", "/tmp", "--die-with-parent"}
+	for _, path := range append([]string{sandbox.HomeDir}, sandbox.WritablePaths...) {
+		args = append(args, "--bind-try", path, path)
+	}
+	if dir != "" {
+		args = append(args, "--chdir", dir)
+	}
+	return append(args, "--")
+}
@@ -58,6 +58,10 @@ func NewLifeCycle(sdk pb.Sdk, pipelineId uuid.UUID, pipelinesFolder string) (*Li
 		return newGoLifeCycle(pipelineId, pipelinesFolder), nil
 	case pb.Sdk_SDK_PYTHON:
 		return newPythonLifeCycle(pipelineId, pipelinesFolder), nil
+	case pb.Sdk_SDK_YAML:
+		return newYamlLifeCycle(pipelineId, pipelinesFolder), nil
+	case pb.Sdk_SDK_KOTLIN:
+		return newKotlinLifeCycle(pipelineId, pipelinesFolder), nil
 	default:
 		return nil, fmt.Errorf("%s isn't supported now", sdk)
 	}
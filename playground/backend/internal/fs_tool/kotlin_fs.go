@@ -0,0 +1,55 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	KotlinSourceFileExtension = ".kt"
+)
+
+// newKotlinLifeCycle creates LifeCycle with Kotlin SDK environment.
+// Kotlin code is compiled to the same class files as Java code.
+func newKotlinLifeCycle(pipelineId uuid.UUID, pipelinesFolder string) *LifeCycle {
+	kotlinLifeCycle := newCompilingLifeCycle(pipelineId, pipelinesFolder, KotlinSourceFileExtension, javaCompiledFileExtension)
+	kotlinLifeCycle.Paths.ExecutableName = kotlinExecutableName
+	return kotlinLifeCycle
+}
+
+// kotlinExecutableName returns name that should be executed (HelloWorldTest for HelloWorldTest.class).
+// Other entries of the folder, e.g. META-INF folder with the Kotlin module, are ignored.
+func kotlinExecutableName(executableFileFolderPath string) (string, error) {
+	dirEntries, err := os.ReadDir(executableFileFolderPath)
+	if err != nil {
+		return "", err
+	}
+	name := ""
+	for _, entry := range dirEntries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == javaCompiledFileExtension {
+			name = strings.TrimSuffix(entry.Name(), javaCompiledFileExtension)
+		}
+	}
+	if name == "" {
+		return "", errors.New("number of executable files should be at least one")
+	}
+	return name, nil
+}
@@ -0,0 +1,122 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fs_tool
+
+import (
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_newKotlinLifeCycle(t *testing.T) {
+	pipelineId := uuid.New()
+	workingDir, _ := filepath.Abs("workingDir")
+	baseFileFolder := filepath.Join(workingDir, pipelinesFolder, pipelineId.String())
+	srcFileFolder := filepath.Join(baseFileFolder, "src")
+	binFileFolder := filepath.Join(baseFileFolder, "bin")
+
+	type args struct {
+		pipelineId      uuid.UUID
+		pipelinesFolder string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *LifeCycle
+	}{
+		{
+			// Test case with calling newKotlinLifeCycle method with correct pipelineId and workingDir.
+			// As a result, want to receive an expected kotlin life cycle.
+			name: "newKotlinLifeCycle",
+			args: args{
+				pipelineId:      pipelineId,
+				pipelinesFolder: filepath.Join(workingDir, pipelinesFolder),
+			},
+			want: &LifeCycle{
+				folderGlobs: []string{baseFileFolder, srcFileFolder, binFileFolder},
+				Paths: LifeCyclePaths{
+					SourceFileName:                   pipelineId.String() + KotlinSourceFileExtension,
+					AbsoluteSourceFileFolderPath:     srcFileFolder,
+					AbsoluteSourceFilePath:           filepath.Join(srcFileFolder, pipelineId.String()+KotlinSourceFileExtension),
+					ExecutableFileName:               pipelineId.String() + javaCompiledFileExtension,
+					AbsoluteExecutableFileFolderPath: binFileFolder,
+					AbsoluteExecutableFilePath:       filepath.Join(binFileFolder, pipelineId.String()+javaCompiledFileExtension),
+					AbsoluteBaseFolderPath:           baseFileFolder,
+					AbsoluteLogFilePath:              filepath.Join(baseFileFolder, logFileName),
+					ExecutableName:                   kotlinExecutableName,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newKotlinLifeCycle(tt.args.pipelineId, tt.args.pipelinesFolder)
+			if !reflect.DeepEqual(got.folderGlobs, tt.want.folderGlobs) {
+				t.Errorf("newKotlinLifeCycle() folderGlobs = %v, want %v", got.folderGlobs, tt.want.folderGlobs)
+			}
+			if !checkPathsEqual(got.Paths, tt.want.Paths) {
+				t.Errorf("newKotlinLifeCycle() Paths = %v, want %v", got.Paths, tt.want.Paths)
+			}
+		})
+	}
+}
+
+func Test_kotlinExecutableName(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   []string
+		want    string
+		wantErr bool
+	}{
+		{
+			// Test that the folder with the Kotlin module is ignored
+			name:    "class file and module folder",
+			files:   []string{"TaskTest.class", filepath.Join("META-INF", "main.kotlin_module")},
+			want:    "TaskTest",
+			wantErr: false,
+		},
+		{
+			name:    "without class files",
+			files:   []string{filepath.Join("META-INF", "main.kotlin_module")},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folder := t.TempDir()
+			for _, file := range tt.files {
+				filePath := filepath.Join(folder, file)
+				if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+					t.Fatalf("error during test setup: %s", err.Error())
+				}
+				if err := os.WriteFile(filePath, []byte("TEMP_DATA"), 0600); err != nil {
+					t.Fatalf("error during test setup: %s", err.Error())
+				}
+			}
+			got, err := kotlinExecutableName(folder)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("kotlinExecutableName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("kotlinExecutableName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
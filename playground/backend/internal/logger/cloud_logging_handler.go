@@ -74,6 +74,36 @@ func (c CloudLoggingHandler) Fatalf(format string, args ...interface{}) {
 	c.logMessage(logging.Critical, fmt.Sprintf(format, args...))
 }
 
+// LogWithFields buffers the Entry for output to the logging service with fields as its labels.
+func (c CloudLoggingHandler) LogWithFields(severity Severity, fields Fields, message string) {
+	labels := make(map[string]string, len(fields))
+	for key, value := range fields {
+		labels[key] = fmt.Sprint(value)
+	}
+	c.logger.Log(logging.Entry{
+		Timestamp: time.Now(),
+		Severity:  cloudSeverity(severity),
+		Payload:   message,
+		Labels:    labels,
+	})
+}
+
+// cloudSeverity converts Severity to the severity of the logging service
+func cloudSeverity(severity Severity) logging.Severity {
+	switch severity {
+	case INFO:
+		return logging.Info
+	case WARN:
+		return logging.Warning
+	case ERROR:
+		return logging.Error
+	case FATAL:
+		return logging.Critical
+	default:
+		return logging.Debug
+	}
+}
+
 // logMessage buffers the Entry for output to the logging service.
 func (c CloudLoggingHandler) logMessage(severity logging.Severity, args ...interface{}) {
 	c.logger.Log(logging.Entry{
@@ -0,0 +1,216 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	pipelineIdField    = "pipelineId"
+	correlationIdField = "correlationId"
+)
+
+type entryContextKey struct{}
+
+// Fields is a set of key-value pairs which are attached to each log message written via Entry.
+type Fields map[string]interface{}
+
+// FieldsHandler is a Handler that is able to write log entries together with their fields.
+// Handlers that don't implement this interface receive fields rendered as a part of the message.
+type FieldsHandler interface {
+	Handler
+
+	// LogWithFields logs a message at level severity with the attached fields.
+	// Messages at level FATAL are handled the same way as by the Fatal method of the handler.
+	LogWithFields(severity Severity, fields Fields, message string)
+}
+
+// Entry is a contextual logger which attaches its fields to each logged message.
+// A nil *Entry is valid and logs messages without fields.
+type Entry struct {
+	fields Fields
+	// handlers receive messages of the contextual logger instead of handlers of the root logger if they are set
+	handlers []Handler
+}
+
+// WithFields returns a contextual logger with received fields
+func WithFields(fields Fields) *Entry {
+	return (*Entry)(nil).WithFields(fields)
+}
+
+// WithPipelineId returns a contextual logger with the pipelineId field
+func WithPipelineId(pipelineId string) *Entry {
+	return WithFields(Fields{pipelineIdField: pipelineId})
+}
+
+// WithHandlers returns a contextual logger which writes messages to received handlers instead of handlers of the root logger
+func WithHandlers(handlers ...Handler) *Entry {
+	return (*Entry)(nil).WithHandlers(handlers...)
+}
+
+// WithFields returns a new contextual logger which contains fields of the current one and received fields.
+// In case of the same keys received fields overwrite existing ones.
+func (e *Entry) WithFields(fields Fields) *Entry {
+	merged := e.Fields()
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return &Entry{fields: merged, handlers: e.entryHandlers()}
+}
+
+// WithHandlers returns a new contextual logger with fields of the current one which writes messages to received handlers.
+// Contextual loggers which are created from it write messages to the same handlers.
+func (e *Entry) WithHandlers(handlers ...Handler) *Entry {
+	return &Entry{fields: e.Fields(), handlers: handlers}
+}
+
+// WithPipelineId returns a new contextual logger with the pipelineId field
+func (e *Entry) WithPipelineId(pipelineId string) *Entry {
+	return e.WithFields(Fields{pipelineIdField: pipelineId})
+}
+
+// WithCorrelationId returns a new contextual logger with the correlationId field
+func (e *Entry) WithCorrelationId(correlationId string) *Entry {
+	return e.WithFields(Fields{correlationIdField: correlationId})
+}
+
+// Fields returns a copy of fields of the contextual logger
+func (e *Entry) Fields() Fields {
+	fields := Fields{}
+	if e == nil {
+		return fields
+	}
+	for key, value := range e.fields {
+		fields[key] = value
+	}
+	return fields
+}
+
+func (e *Entry) Info(args ...interface{}) {
+	e.log(INFO, fmt.Sprint(args...))
+}
+
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.log(INFO, fmt.Sprintf(format, args...))
+}
+
+func (e *Entry) Warn(args ...interface{}) {
+	e.log(WARN, fmt.Sprint(args...))
+}
+
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	e.log(WARN, fmt.Sprintf(format, args...))
+}
+
+func (e *Entry) Error(args ...interface{}) {
+	e.log(ERROR, fmt.Sprint(args...))
+}
+
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.log(ERROR, fmt.Sprintf(format, args...))
+}
+
+func (e *Entry) Debug(args ...interface{}) {
+	e.log(DEBUG, fmt.Sprint(args...))
+}
+
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	e.log(DEBUG, fmt.Sprintf(format, args...))
+}
+
+func (e *Entry) Fatal(args ...interface{}) {
+	e.log(FATAL, fmt.Sprint(args...))
+}
+
+func (e *Entry) Fatalf(format string, args ...interface{}) {
+	e.log(FATAL, fmt.Sprintf(format, args...))
+}
+
+// log forwards the message to all handlers.
+// FieldsHandler receives fields as they are, other handlers receive them as a prefix of the message.
+func (e *Entry) log(severity Severity, message string) {
+	for _, handler := range e.handlersOrRoot() {
+		if fieldsHandler, ok := handler.(FieldsHandler); ok {
+			fieldsHandler.LogWithFields(severity, e.Fields(), message)
+			continue
+		}
+		text := e.formatFields() + message
+		switch severity {
+		case INFO:
+			handler.Info(text)
+		case WARN:
+			handler.Warn(text)
+		case ERROR:
+			handler.Error(text)
+		case DEBUG:
+			handler.Debug(text)
+		case FATAL:
+			handler.Fatal(text)
+		}
+	}
+}
+
+// entryHandlers returns handlers of the contextual logger, nil if messages are written to handlers of the root logger
+func (e *Entry) entryHandlers() []Handler {
+	if e == nil {
+		return nil
+	}
+	return e.handlers
+}
+
+// handlersOrRoot returns handlers of the contextual logger if they are set, otherwise handlers of the root logger
+func (e *Entry) handlersOrRoot() []Handler {
+	if entryHandlers := e.entryHandlers(); entryHandlers != nil {
+		return entryHandlers
+	}
+	return handlers
+}
+
+// formatFields renders fields as "key=value " pairs sorted by key
+func (e *Entry) formatFields() string {
+	if e == nil || len(e.fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(e.fields))
+	for key := range e.fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var builder strings.Builder
+	for _, key := range keys {
+		builder.WriteString(fmt.Sprintf("%s=%v ", key, e.fields[key]))
+	}
+	return builder.String()
+}
+
+// NewContext returns a copy of ctx which carries the contextual logger
+func NewContext(ctx context.Context, entry *Entry) context.Context {
+	return context.WithValue(ctx, entryContextKey{}, entry)
+}
+
+// FromContext returns the contextual logger stored in ctx.
+// If there is no logger in ctx returns a logger without fields.
+func FromContext(ctx context.Context) *Entry {
+	if ctx == nil {
+		return nil
+	}
+	entry, _ := ctx.Value(entryContextKey{}).(*Entry)
+	return entry
+}
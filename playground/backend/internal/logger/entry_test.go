@@ -0,0 +1,186 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type fieldsTestHandler struct {
+	testHandler
+	fields  []Fields
+	message []string
+}
+
+func (t *fieldsTestHandler) LogWithFields(severity Severity, fields Fields, message string) {
+	t.fields = append(t.fields, fields)
+	t.message = append(t.message, fmt.Sprint(severity, message))
+}
+
+func TestEntry_WithFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		entry      *Entry
+		wantFields Fields
+	}{
+		{
+			name:       "nil entry",
+			entry:      nil,
+			wantFields: Fields{},
+		},
+		{
+			name:       "with pipelineId",
+			entry:      WithPipelineId("MOCK_ID"),
+			wantFields: Fields{pipelineIdField: "MOCK_ID"},
+		},
+		{
+			name:       "with merged fields",
+			entry:      WithPipelineId("MOCK_ID").WithFields(Fields{"sdk": "SDK_JAVA"}).WithFields(Fields{"stage": "Prepare"}),
+			wantFields: Fields{pipelineIdField: "MOCK_ID", "sdk": "SDK_JAVA", "stage": "Prepare"},
+		},
+		{
+			name:       "with overwritten field",
+			entry:      WithFields(Fields{"stage": "Prepare"}).WithFields(Fields{"stage": "Compile"}),
+			wantFields: Fields{"stage": "Compile"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.Fields(); !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("Fields() = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestEntry_WithFieldsDoesNotChangeParent(t *testing.T) {
+	parent := WithPipelineId("MOCK_ID")
+	_ = parent.WithFields(Fields{"stage": "Run"})
+	if got := parent.Fields(); !reflect.DeepEqual(got, Fields{pipelineIdField: "MOCK_ID"}) {
+		t.Errorf("parent fields were changed: %v", got)
+	}
+}
+
+func TestEntry_Log(t *testing.T) {
+	defer SetHandlers([]Handler{&preparedHandler})
+	fieldsHandler := &fieldsTestHandler{}
+	plainHandler := &testHandler{}
+	SetHandlers([]Handler{fieldsHandler, plainHandler})
+
+	WithPipelineId("MOCK_ID").WithFields(Fields{"stage": "Run"}).Errorf("TEST FORMAT %s", "TEST_VALUE")
+
+	wantFields := Fields{pipelineIdField: "MOCK_ID", "stage": "Run"}
+	if len(fieldsHandler.fields) != 1 || !reflect.DeepEqual(fieldsHandler.fields[0], wantFields) {
+		t.Errorf("FieldsHandler received fields %v, want %v", fieldsHandler.fields, wantFields)
+	}
+	if want := fmt.Sprint(ERROR, "TEST FORMAT TEST_VALUE"); fieldsHandler.message[0] != want {
+		t.Errorf("FieldsHandler received message %s, want %s", fieldsHandler.message[0], want)
+	}
+	if want := fmt.Sprint(ERROR, "pipelineId=MOCK_ID stage=Run TEST FORMAT TEST_VALUE"); len(plainHandler.logs) != 1 || plainHandler.logs[0] != want {
+		t.Errorf("Handler received logs %v, want %s", plainHandler.logs, want)
+	}
+}
+
+func TestEntry_WithHandlers(t *testing.T) {
+	defer SetHandlers([]Handler{&preparedHandler})
+	rootHandler := &testHandler{}
+	SetHandlers([]Handler{rootHandler})
+	entryHandler := &fieldsTestHandler{}
+
+	// Test that loggers which are created from the logger with handlers write messages only to its handlers
+	WithPipelineId("MOCK_ID").WithHandlers(entryHandler).WithFields(Fields{"stage": "Run"}).Infof("TEST FORMAT %s", "TEST_VALUE")
+
+	wantFields := Fields{pipelineIdField: "MOCK_ID", "stage": "Run"}
+	if len(entryHandler.fields) != 1 || !reflect.DeepEqual(entryHandler.fields[0], wantFields) {
+		t.Errorf("handler of the entry received fields %v, want %v", entryHandler.fields, wantFields)
+	}
+	if len(rootHandler.logs) != 0 {
+		t.Errorf("handler of the root logger received logs %v, want none", rootHandler.logs)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	entry := WithPipelineId("MOCK_ID")
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want *Entry
+	}{
+		{
+			name: "context without logger",
+			ctx:  context.Background(),
+			want: nil,
+		},
+		{
+			name: "context with logger",
+			ctx:  NewContext(context.Background(), entry),
+			want: entry,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FromContext(tt.ctx); got != tt.want {
+				t.Errorf("FromContext() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJsonHandler_LogWithFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      func(handler *JsonHandler)
+		wantLine map[string]interface{}
+	}{
+		{
+			name: "message without fields",
+			log: func(handler *JsonHandler) {
+				handler.Warnf("TEST FORMAT %s\n", "TEST_VALUE")
+			},
+			wantLine: map[string]interface{}{severityKey: "WARN", messageKey: "TEST FORMAT TEST_VALUE"},
+		},
+		{
+			name: "message with fields",
+			log: func(handler *JsonHandler) {
+				handler.LogWithFields(INFO, Fields{pipelineIdField: "MOCK_ID", "stage": "Compile"}, "TEST_VALUE")
+			},
+			wantLine: map[string]interface{}{severityKey: "INFO", messageKey: "TEST_VALUE", pipelineIdField: "MOCK_ID", "stage": "Compile"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			tt.log(NewJsonHandler(out))
+
+			line := map[string]interface{}{}
+			if err := json.Unmarshal(out.Bytes(), &line); err != nil {
+				t.Fatalf("output is not a JSON line: %s", out.String())
+			}
+			if _, ok := line[timeKey]; !ok {
+				t.Errorf("time is missing in %v", line)
+			}
+			delete(line, timeKey)
+			if !reflect.DeepEqual(line, tt.wantLine) {
+				t.Errorf("LogWithFields() wrote %v, want %v", line, tt.wantLine)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	timeKey     = "time"
+	severityKey = "severity"
+	messageKey  = "message"
+)
+
+// JsonHandler writes each log entry as a single JSON line with all fields of the entry as top-level keys
+type JsonHandler struct {
+	mu  *sync.Mutex
+	out io.Writer
+}
+
+// NewJsonHandler creates JsonHandler which writes to out
+func NewJsonHandler(out io.Writer) *JsonHandler {
+	return &JsonHandler{mu: &sync.Mutex{}, out: out}
+}
+
+func (h JsonHandler) Info(args ...interface{}) {
+	h.LogWithFields(INFO, nil, fmt.Sprint(args...))
+}
+
+func (h JsonHandler) Infof(format string, args ...interface{}) {
+	h.LogWithFields(INFO, nil, fmt.Sprintf(format, args...))
+}
+
+func (h JsonHandler) Warn(args ...interface{}) {
+	h.LogWithFields(WARN, nil, fmt.Sprint(args...))
+}
+
+func (h JsonHandler) Warnf(format string, args ...interface{}) {
+	h.LogWithFields(WARN, nil, fmt.Sprintf(format, args...))
+}
+
+func (h JsonHandler) Error(args ...interface{}) {
+	h.LogWithFields(ERROR, nil, fmt.Sprint(args...))
+}
+
+func (h JsonHandler) Errorf(format string, args ...interface{}) {
+	h.LogWithFields(ERROR, nil, fmt.Sprintf(format, args...))
+}
+
+func (h JsonHandler) Debug(args ...interface{}) {
+	h.LogWithFields(DEBUG, nil, fmt.Sprint(args...))
+}
+
+func (h JsonHandler) Debugf(format string, args ...interface{}) {
+	h.LogWithFields(DEBUG, nil, fmt.Sprintf(format, args...))
+}
+
+func (h JsonHandler) Fatal(args ...interface{}) {
+	h.LogWithFields(FATAL, nil, fmt.Sprint(args...))
+}
+
+func (h JsonHandler) Fatalf(format string, args ...interface{}) {
+	h.LogWithFields(FATAL, nil, fmt.Sprintf(format, args...))
+}
+
+// LogWithFields writes a JSON line with the time, severity, message and fields.
+// Fields with the same keys as the time, severity or message are overwritten.
+func (h JsonHandler) LogWithFields(severity Severity, fields Fields, message string) {
+	line := make(map[string]interface{}, len(fields)+3)
+	for key, value := range fields {
+		line[key] = value
+	}
+	line[timeKey] = time.Now().UTC().Format(time.RFC3339Nano)
+	line[severityKey] = severity.level()
+	line[messageKey] = strings.TrimSuffix(message, "\n")
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		data, _ = json.Marshal(map[string]interface{}{
+			timeKey:     line[timeKey],
+			severityKey: line[severityKey],
+			messageKey:  fmt.Sprintf("%s (fields couldn't be marshaled: %s)", line[messageKey], err.Error()),
+		})
+	}
+
+	h.mu.Lock()
+	_, _ = h.out.Write(append(data, '\n'))
+	h.mu.Unlock()
+
+	if severity == FATAL {
+		os.Exit(1)
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"cloud.google.com/go/logging"
 	"context"
 	"log"
+	"os"
+	"strings"
 )
 
 type Severity string
@@ -30,15 +32,27 @@ const (
 	FATAL     Severity = "[FATAL]:"
 	DEBUG     Severity = "[DEBUG]:"
 	appEngine          = "app_engine"
+
+	// TextFormat is used to write logs as plain text lines
+	TextFormat = "text"
+
+	// JsonFormat is used to write logs as JSON lines
+	JsonFormat = "json"
 )
 
 var handlers []Handler
 
+// level returns the name of the severity without decorations, e.g. "INFO"
+func (s Severity) level() string {
+	return strings.Trim(string(s), "[]:")
+}
+
 // SetupLogger constructs logger by application environment
 // Add handlers in root logger:
 //   CloudLoggingHandler - if server running on App Engine
+//   JsonHandler - if server running locally and logFormat is JsonFormat
 //   StdHandler - if server running locally
-func SetupLogger(ctx context.Context, launchSite, googleProjectId string) {
+func SetupLogger(ctx context.Context, launchSite, googleProjectId, logFormat string) {
 	switch launchSite {
 	case appEngine:
 		client, err := logging.NewClient(ctx, googleProjectId)
@@ -48,6 +62,10 @@ func SetupLogger(ctx context.Context, launchSite, googleProjectId string) {
 		cloudLogger := NewCloudLoggingHandler(client)
 		AddHandler(cloudLogger)
 	default:
+		if logFormat == JsonFormat {
+			AddHandler(NewJsonHandler(os.Stderr))
+			return
+		}
 		stdLogger := NewStdHandler()
 		AddHandler(stdLogger)
 	}
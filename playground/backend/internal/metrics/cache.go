@@ -0,0 +1,55 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"github.com/google/uuid"
+)
+
+// skipCacheMetricsKey is the key of the context value which disables recording of reads of Cache
+type skipCacheMetricsKey struct{}
+
+// WithoutCacheMetrics returns a copy of ctx whose reads of Cache aren't recorded as hits or misses,
+// e.g. re-reads of the terminal status which the code processing has saved itself
+func WithoutCacheMetrics(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheMetricsKey{}, true)
+}
+
+// Cache is a cache.Cache which records hits and misses of the wrapped cache
+type Cache struct {
+	cache.Cache
+}
+
+// NewCache wraps cacheService to record hits and misses of its reads
+func NewCache(cacheService cache.Cache) *Cache {
+	return &Cache{Cache: cacheService}
+}
+
+// GetValue returns value from the wrapped cache and records whether it is found unless ctx is WithoutCacheMetrics
+func (c *Cache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (interface{}, error) {
+	value, err := c.Cache.GetValue(ctx, pipelineId, subKey)
+	if skip, _ := ctx.Value(skipCacheMetricsKey{}).(bool); skip {
+		return value, err
+	}
+	if err != nil {
+		CacheMiss(string(subKey))
+	} else {
+		CacheHit(string(subKey))
+	}
+	return value, err
+}
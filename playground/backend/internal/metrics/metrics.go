@@ -0,0 +1,184 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	namespace   = "playground"
+	sdkLabel    = "sdk"
+	statusLabel = "status"
+	stageLabel  = "stage"
+	subKeyLabel = "sub_key"
+)
+
+// collectors contains all metrics of the application
+type collectors struct {
+	registry       *prometheus.Registry
+	runs           *prometheus.CounterVec
+	runsInFlight   *prometheus.GaugeVec
+	stageDurations *prometheus.HistogramVec
+	stageCpu       *prometheus.HistogramVec
+	stageMaxRss    *prometheus.HistogramVec
+	cacheHits      *prometheus.CounterVec
+	cacheMisses    *prometheus.CounterVec
+	cacheEvictions prometheus.Counter
+}
+
+var (
+	mu      sync.RWMutex
+	metrics *collectors
+)
+
+// Setup creates and registers all metrics of the application.
+// Until Setup is called all functions of the package don't record anything.
+func Setup() {
+	registry := prometheus.NewRegistry()
+	newMetrics := &collectors{
+		registry: registry,
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_total",
+			Help:      "Number of finished code runs by sdk and terminal status.",
+		}, []string{sdkLabel, statusLabel}),
+		runsInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "runs_in_flight",
+			Help:      "Number of code runs which are being processed right now.",
+		}, []string{sdkLabel}),
+		stageDurations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "stage_duration_seconds",
+			Help:      "Duration of the code processing stages.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+		}, []string{sdkLabel, stageLabel}),
+		stageCpu: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "stage_cpu_seconds",
+			Help:      "User and system CPU time of processes of the code processing stages.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+		}, []string{sdkLabel, stageLabel}),
+		stageMaxRss: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "stage_max_rss_bytes",
+			Help:      "Peak resident set size of processes of the code processing stages.",
+			Buckets:   prometheus.ExponentialBuckets(16<<20, 2, 10),
+		}, []string{sdkLabel, stageLabel}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_hits_total",
+			Help:      "Number of cache reads which found a value.",
+		}, []string{subKeyLabel}),
+		cacheMisses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_misses_total",
+			Help:      "Number of cache reads which didn't find a value.",
+		}, []string{subKeyLabel}),
+		cacheEvictions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_evictions_total",
+			Help:      "Number of pipelines removed from the cache after their expiration.",
+		}),
+	}
+	registry.MustRegister(
+		newMetrics.runs,
+		newMetrics.runsInFlight,
+		newMetrics.stageDurations,
+		newMetrics.stageCpu,
+		newMetrics.stageMaxRss,
+		newMetrics.cacheHits,
+		newMetrics.cacheMisses,
+		newMetrics.cacheEvictions,
+	)
+
+	mu.Lock()
+	metrics = newMetrics
+	mu.Unlock()
+}
+
+// Handler returns http.Handler which exposes registered metrics.
+// If metrics are not set up returns http.NotFoundHandler.
+func Handler() http.Handler {
+	m := get()
+	if m == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RunStarted records that processing of the code for sdk is started
+func RunStarted(sdk string) {
+	if m := get(); m != nil {
+		m.runsInFlight.WithLabelValues(sdk).Inc()
+	}
+}
+
+// RunFinished records that processing of the code for sdk is finished with the status
+func RunFinished(sdk, status string) {
+	if m := get(); m != nil {
+		m.runsInFlight.WithLabelValues(sdk).Dec()
+		m.runs.WithLabelValues(sdk, status).Inc()
+	}
+}
+
+// ObserveStage records duration of the stage which is started at startTime.
+// Use it with defer at the beginning of the stage: defer metrics.ObserveStage(sdk, stage, time.Now())
+func ObserveStage(sdk, stage string, startTime time.Time) {
+	if m := get(); m != nil {
+		m.stageDurations.WithLabelValues(sdk, stage).Observe(time.Since(startTime).Seconds())
+	}
+}
+
+// ObserveStageResources records CPU time and the peak resident set size in bytes of processes of the stage
+func ObserveStageResources(sdk, stage string, cpu time.Duration, maxRssBytes int64) {
+	if m := get(); m != nil {
+		m.stageCpu.WithLabelValues(sdk, stage).Observe(cpu.Seconds())
+		m.stageMaxRss.WithLabelValues(sdk, stage).Observe(float64(maxRssBytes))
+	}
+}
+
+// CacheHit records a cache read of the subKey which found a value
+func CacheHit(subKey string) {
+	if m := get(); m != nil {
+		m.cacheHits.WithLabelValues(subKey).Inc()
+	}
+}
+
+// CacheMiss records a cache read of the subKey which didn't find a value
+func CacheMiss(subKey string) {
+	if m := get(); m != nil {
+		m.cacheMisses.WithLabelValues(subKey).Inc()
+	}
+}
+
+// CacheEvicted records that count pipelines are removed from the cache
+func CacheEvicted(count int) {
+	if m := get(); m != nil {
+		m.cacheEvictions.Add(float64(count))
+	}
+}
+
+func get() *collectors {
+	mu.RLock()
+	defer mu.RUnlock()
+	return metrics
+}
@@ -0,0 +1,125 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"beam.apache.org/playground/backend/internal/cache"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mockCache struct {
+	cache.Cache
+	values map[cache.SubKey]interface{}
+}
+
+func (m *mockCache) GetValue(ctx context.Context, pipelineId uuid.UUID, subKey cache.SubKey) (interface{}, error) {
+	value, ok := m.values[subKey]
+	if !ok {
+		return nil, fmt.Errorf("value with subKey: %s not found", subKey)
+	}
+	return value, nil
+}
+
+func scrape(t *testing.T) string {
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+	response, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("error during scraping metrics: %s", err.Error())
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("error during reading metrics: %s", err.Error())
+	}
+	return string(body)
+}
+
+func TestHandler(t *testing.T) {
+	Setup()
+	simulateRun := func(sdk, status string) {
+		RunStarted(sdk)
+		ObserveStage(sdk, "Validate", time.Now().Add(-2*time.Second))
+		ObserveStage(sdk, "Compile", time.Now().Add(-20*time.Second))
+		ObserveStageResources(sdk, "Compile", 3*time.Second, 100<<20)
+		RunFinished(sdk, status)
+	}
+	simulateRun("SDK_JAVA", "STATUS_FINISHED")
+	simulateRun("SDK_JAVA", "STATUS_FINISHED")
+	simulateRun("SDK_GO", "STATUS_COMPILE_ERROR")
+	RunStarted("SDK_PYTHON")
+
+	cacheService := NewCache(&mockCache{values: map[cache.SubKey]interface{}{cache.Status: "MOCK_STATUS"}})
+	_, _ = cacheService.GetValue(context.Background(), uuid.New(), cache.Status)
+	// Test that reads without cache metrics aren't recorded as hits
+	_, _ = cacheService.GetValue(WithoutCacheMetrics(context.Background()), uuid.New(), cache.Status)
+	_, _ = cacheService.GetValue(context.Background(), uuid.New(), cache.RunOutput)
+	_, _ = cacheService.GetValue(context.Background(), uuid.New(), cache.RunOutput)
+	CacheEvicted(3)
+
+	got := scrape(t)
+	wantLines := []string{
+		`playground_runs_total{sdk="SDK_JAVA",status="STATUS_FINISHED"} 2`,
+		`playground_runs_total{sdk="SDK_GO",status="STATUS_COMPILE_ERROR"} 1`,
+		`playground_runs_in_flight{sdk="SDK_JAVA"} 0`,
+		`playground_runs_in_flight{sdk="SDK_PYTHON"} 1`,
+		`playground_stage_duration_seconds_count{sdk="SDK_JAVA",stage="Validate"} 2`,
+		`playground_stage_duration_seconds_bucket{sdk="SDK_JAVA",stage="Validate",le="1"} 0`,
+		`playground_stage_duration_seconds_bucket{sdk="SDK_JAVA",stage="Validate",le="2.5"} 2`,
+		`playground_stage_duration_seconds_bucket{sdk="SDK_GO",stage="Compile",le="10"} 0`,
+		`playground_stage_duration_seconds_bucket{sdk="SDK_GO",stage="Compile",le="30"} 1`,
+		`playground_stage_cpu_seconds_bucket{sdk="SDK_JAVA",stage="Compile",le="2.5"} 0`,
+		`playground_stage_cpu_seconds_bucket{sdk="SDK_JAVA",stage="Compile",le="5"} 2`,
+		`playground_stage_max_rss_bytes_bucket{sdk="SDK_GO",stage="Compile",le="6.7108864e+07"} 0`,
+		`playground_stage_max_rss_bytes_bucket{sdk="SDK_GO",stage="Compile",le="1.34217728e+08"} 1`,
+		`playground_cache_hits_total{sub_key="STATUS"} 1`,
+		`playground_cache_misses_total{sub_key="RUN_OUTPUT"} 2`,
+		`playground_cache_evictions_total 3`,
+	}
+	for _, line := range wantLines {
+		if !strings.Contains(got, line+"\n") {
+			t.Errorf("metrics don't contain %s", line)
+		}
+	}
+}
+
+func TestHandlerWithoutSetup(t *testing.T) {
+	mu.Lock()
+	metrics = nil
+	mu.Unlock()
+
+	RunStarted("SDK_JAVA")
+	RunFinished("SDK_JAVA", "STATUS_FINISHED")
+	ObserveStage("SDK_JAVA", "Run", time.Now())
+	ObserveStageResources("SDK_JAVA", "Run", time.Second, 1<<20)
+	CacheHit("STATUS")
+	CacheMiss("STATUS")
+	CacheEvicted(1)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Handler() returns status %d, want %d", recorder.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,273 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package precompiled_refresh
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cache"
+	"beam.apache.org/playground/backend/internal/cache/local"
+	"beam.apache.org/playground/backend/internal/cloud_bucket"
+	"beam.apache.org/playground/backend/internal/code_processing"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/preparers"
+	"beam.apache.org/playground/backend/internal/run_history"
+	"beam.apache.org/playground/backend/internal/setup_tools/life_cycle"
+	"beam.apache.org/playground/backend/internal/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+)
+
+const (
+	sdkField     = "sdk"
+	stageField   = "stage"
+	refreshStage = "PrecompiledObjectsRefresh"
+	newLine      = "\n"
+)
+
+// Storage is the catalog of examples which also keeps their precompiled objects
+type Storage interface {
+	// GetPrecompiledObjects returns examples of the catalog for the target sdk and category
+	GetPrecompiledObjects(ctx context.Context, targetSdk pb.Sdk, targetCategory string) (*cloud_bucket.SdkToCategories, error)
+
+	// GetPrecompiledObject returns the source code of the example
+	GetPrecompiledObject(ctx context.Context, precompiledObjectPath string) (string, error)
+
+	// GetPrecompiledObjectError returns the error of the last refresh of the example
+	GetPrecompiledObjectError(ctx context.Context, precompiledObjectPath string) (string, error)
+
+	// PutPrecompiledObjectFile writes data as the file of the example with the extension
+	PutPrecompiledObjectFile(ctx context.Context, precompiledObjectPath string, extension string, data []byte) error
+}
+
+// Failure is an example which isn't run successfully by the refresh
+type Failure struct {
+	CloudPath string
+	Status    pb.Status
+	// Output is the output of the failed step of the code processing
+	Output string
+	// New is true if the previous refresh of the example passed. It's false if its result can't be read
+	New bool
+}
+
+// Report is the summary of the refresh
+type Report struct {
+	// Refreshed are paths of examples which are run successfully and whose outputs are stored
+	Refreshed []string
+	Failed    []Failure
+}
+
+// NewlyFailed returns failures of examples which passed the previous refresh
+func (r *Report) NewlyFailed() []Failure {
+	var failures []Failure
+	for _, failure := range r.Failed {
+		if failure.New {
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+// String returns the summary of the refresh with the list of newly failed examples
+func (r *Report) String() string {
+	newlyFailed := r.NewlyFailed()
+	summary := fmt.Sprintf("Precompiled objects are refreshed: %d passed, %d failed, %d newly failed", len(r.Refreshed), len(r.Failed), len(newlyFailed))
+	if len(newlyFailed) == 0 {
+		return summary
+	}
+	examples := make([]string, 0, len(newlyFailed))
+	for _, failure := range newlyFailed {
+		examples = append(examples, fmt.Sprintf("%s (%s)", failure.CloudPath, failure.Status))
+	}
+	return summary + ": " + strings.Join(examples, ", ")
+}
+
+// exampleResult is the result of the code processing of the example
+type exampleResult struct {
+	status      pb.Status
+	output      string
+	logs        string
+	errorOutput string
+	metadata    *pb.RunMetadata
+}
+
+// Run runs each example of the catalog for the sdk of sdkEnv through the code processing
+// with the same steps as code of users and stores the run output and logs of the example into storage.
+// For failed examples the error of the failed step is stored instead of the run output,
+// so the catalog keeps the output of the last successful run.
+// Examples are run one at a time with their own cache, so the refresh doesn't share cached values with runs of users.
+// A failure of an example doesn't stop the refresh, returns an error only if the catalog couldn't be read.
+// If historyStore isn't nil, runs of examples are added to their run history with the cloud path as the id of the example.
+func Run(ctx context.Context, storage Storage, historyStore run_history.Store, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs) (*Report, error) {
+	log := logger.WithFields(logger.Fields{sdkField: sdkEnv.ApacheBeamSdk.String(), stageField: refreshStage})
+	sdkToCategories, err := storage.GetPrecompiledObjects(ctx, sdkEnv.ApacheBeamSdk, "")
+	if err != nil {
+		log.Errorf("error during get precompiled objects: %s\n", err.Error())
+		return nil, err
+	}
+
+	cacheCtx, cancelCache := context.WithCancel(ctx)
+	defer cancelCache()
+	cacheService := local.New(cacheCtx)
+
+	report := &Report{}
+	for _, object := range catalogObjects(sdkToCategories) {
+		result, err := runExample(ctx, storage, cacheService, historyStore, object, appEnv, sdkEnv)
+		if err != nil {
+			log.Errorf("%s: error during run the example: %s\n", object.CloudPath, err.Error())
+			result = &exampleResult{status: pb.Status_STATUS_ERROR, errorOutput: err.Error()}
+		}
+		if result.status == pb.Status_STATUS_FINISHED {
+			if err = storeSuccess(ctx, storage, object.CloudPath, result); err != nil {
+				log.Errorf("%s: error during store the precompiled object: %s\n", object.CloudPath, err.Error())
+				report.Failed = append(report.Failed, Failure{CloudPath: object.CloudPath, Status: pb.Status_STATUS_ERROR, Output: err.Error()})
+				continue
+			}
+			report.Refreshed = append(report.Refreshed, object.CloudPath)
+			continue
+		}
+
+		// examples of the catalog are expected to pass, so an example without the stored error passed before.
+		// If the stored error can't be read, the example isn't reported as newly failed.
+		failure := Failure{CloudPath: object.CloudPath, Status: result.status, Output: result.errorOutput}
+		previousError, err := storage.GetPrecompiledObjectError(ctx, object.CloudPath)
+		switch {
+		case err == nil:
+			failure.New = previousError == ""
+		case errors.Is(err, cloud_bucket.ErrObjectNotExist):
+			failure.New = true
+		default:
+			log.Errorf("%s: error during get the previous error of the precompiled object: %s\n", object.CloudPath, err.Error())
+		}
+		if err = storeFailure(ctx, storage, object.CloudPath, result); err != nil {
+			log.Errorf("%s: error during store the error of the precompiled object: %s\n", object.CloudPath, err.Error())
+		}
+		report.Failed = append(report.Failed, failure)
+	}
+	log.Info(report.String())
+	return report, nil
+}
+
+// catalogObjects returns examples of the catalog sorted by their paths.
+// Examples which belong to several categories are returned once.
+func catalogObjects(sdkToCategories *cloud_bucket.SdkToCategories) []cloud_bucket.ObjectInfo {
+	objects := map[string]cloud_bucket.ObjectInfo{}
+	for _, categories := range *sdkToCategories {
+		for _, precompiledObjects := range categories {
+			for _, object := range precompiledObjects {
+				objects[object.CloudPath] = object
+			}
+		}
+	}
+	paths := make([]string, 0, len(objects))
+	for path := range objects {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	result := make([]cloud_bucket.ObjectInfo, 0, len(paths))
+	for _, path := range paths {
+		result = append(result, objects[path])
+	}
+	return result
+}
+
+// runExample runs the code of the example through validation, preparation, compilation and run
+// and returns the terminal status of the code processing together with its outputs
+func runExample(ctx context.Context, storage Storage, cacheService cache.Cache, historyStore run_history.Store, object cloud_bucket.ObjectInfo, appEnv *environment.ApplicationEnvs, sdkEnv *environment.BeamEnvs) (*exampleResult, error) {
+	code, err := storage.GetPrecompiledObject(ctx, object.CloudPath)
+	if err != nil {
+		return nil, err
+	}
+	pipelineId := uuid.New()
+	lc, err := life_cycle.Setup(sdkEnv.ApacheBeamSdk, code, pipelineId, appEnv.WorkingDir(), appEnv.PipelinesFolder(), sdkEnv.PreparedModDir(), nil)
+	if err != nil {
+		return nil, err
+	}
+	initialValues := []struct {
+		subKey cache.SubKey
+		value  interface{}
+	}{
+		{cache.Status, pb.Status_STATUS_VALIDATING},
+		{cache.RunOutputIndex, 0},
+		{cache.LogsIndex, 0},
+		{cache.Canceled, false},
+		{cache.RunOutputTruncated, false},
+		{cache.RunErrorTruncated, false},
+		{cache.ExampleId, object.CloudPath},
+	}
+	for _, initialValue := range initialValues {
+		if err = utils.SetToCache(ctx, cacheService, pipelineId, initialValue.subKey, initialValue.value); err != nil {
+			code_processing.DeleteFolders(pipelineId, lc)
+			return nil, err
+		}
+	}
+	if err = cacheService.SetExpTime(ctx, pipelineId, appEnv.CacheEnvs().KeyExpirationTime()); err != nil {
+		code_processing.DeleteFolders(pipelineId, lc)
+		return nil, err
+	}
+
+	code_processing.Process(ctx, cacheService, historyStore, lc, pipelineId, appEnv, sdkEnv, object.PipelineOptions, preparers.Overrides{})
+
+	status, err := code_processing.GetProcessingStatus(ctx, cacheService, pipelineId, "")
+	if err != nil {
+		return nil, err
+	}
+	result := &exampleResult{status: status}
+	result.output, _ = code_processing.GetProcessingOutput(ctx, cacheService, pipelineId, cache.RunOutput, "")
+	result.logs, _ = code_processing.GetProcessingOutput(ctx, cacheService, pipelineId, cache.Logs, "")
+	result.metadata = code_processing.GetRunMetadata(ctx, cacheService, pipelineId)
+	if subKey, ok := code_processing.ErrorOutputSubKey(status); ok {
+		result.errorOutput, _ = code_processing.GetProcessingOutput(ctx, cacheService, pipelineId, subKey, "")
+	}
+	return result, nil
+}
+
+// storeSuccess stores the run output, logs and run metadata of the example and clears its error
+func storeSuccess(ctx context.Context, storage Storage, path string, result *exampleResult) error {
+	if err := storage.PutPrecompiledObjectFile(ctx, path, cloud_bucket.OutputExtension, []byte(result.output)); err != nil {
+		return err
+	}
+	if err := storage.PutPrecompiledObjectFile(ctx, path, cloud_bucket.LogsExtension, []byte(result.logs)); err != nil {
+		return err
+	}
+	if result.metadata != nil {
+		metadata, err := json.Marshal(result.metadata)
+		if err != nil {
+			return err
+		}
+		if err = storage.PutPrecompiledObjectFile(ctx, path, cloud_bucket.MetadataExtension, metadata); err != nil {
+			return err
+		}
+	}
+	return storage.PutPrecompiledObjectFile(ctx, path, cloud_bucket.ErrorExtension, []byte{})
+}
+
+// storeFailure stores the logs and the error of the example keeping its last successful run output
+func storeFailure(ctx context.Context, storage Storage, path string, result *exampleResult) error {
+	if err := storage.PutPrecompiledObjectFile(ctx, path, cloud_bucket.LogsExtension, []byte(result.logs)); err != nil {
+		return err
+	}
+	errorText := result.status.String()
+	if result.errorOutput != "" {
+		errorText += newLine + result.errorOutput
+	}
+	return storage.PutPrecompiledObjectFile(ctx, path, cloud_bucket.ErrorExtension, []byte(errorText))
+}
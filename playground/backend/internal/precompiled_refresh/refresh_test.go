@@ -0,0 +1,199 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package precompiled_refresh
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/cloud_bucket"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/run_history"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	passingExample      = "SDK_PYTHON/Passing"
+	failingExample      = "SDK_PYTHON/Failing"
+	stillFailingExample = "SDK_PYTHON/StillFailing"
+	unreadableExample   = "SDK_PYTHON/Unreadable"
+)
+
+// fakeStorage keeps the catalog and files of precompiled objects in memory by the path of the example and the extension
+type fakeStorage struct {
+	objects cloud_bucket.SdkToCategories
+	files   map[string]string
+	// unreadable are files which exist but can't be read
+	unreadable map[string]bool
+}
+
+func (s *fakeStorage) GetPrecompiledObjects(_ context.Context, _ pb.Sdk, _ string) (*cloud_bucket.SdkToCategories, error) {
+	return &s.objects, nil
+}
+
+func (s *fakeStorage) GetPrecompiledObject(_ context.Context, precompiledObjectPath string) (string, error) {
+	return s.getFile(precompiledObjectPath, "py")
+}
+
+func (s *fakeStorage) GetPrecompiledObjectError(_ context.Context, precompiledObjectPath string) (string, error) {
+	return s.getFile(precompiledObjectPath, cloud_bucket.ErrorExtension)
+}
+
+func (s *fakeStorage) PutPrecompiledObjectFile(_ context.Context, precompiledObjectPath string, extension string, data []byte) error {
+	s.files[precompiledObjectPath+"."+extension] = string(data)
+	return nil
+}
+
+func (s *fakeStorage) getFile(precompiledObjectPath string, extension string) (string, error) {
+	if s.unreadable[precompiledObjectPath+"."+extension] {
+		return "", errors.New("storage is unavailable")
+	}
+	data, ok := s.files[precompiledObjectPath+"."+extension]
+	if !ok {
+		return "", fmt.Errorf("file not found: %w", cloud_bucket.ErrObjectNotExist)
+	}
+	return data, nil
+}
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 isn't installed")
+	}
+	storage := &fakeStorage{
+		objects: cloud_bucket.SdkToCategories{pb.Sdk_SDK_PYTHON.String(): cloud_bucket.CategoryToPrecompiledObjects{
+			"Common": {{CloudPath: passingExample}, {CloudPath: failingExample}},
+			// Test that examples of several categories are run once
+			"IO": {{CloudPath: passingExample}, {CloudPath: stillFailingExample}, {CloudPath: unreadableExample}},
+		}},
+		files: map[string]string{
+			passingExample + ".py":         "print(\"Hello, Beam\")\n",
+			passingExample + ".output":     "stale output\n",
+			failingExample + ".py":         "raise ValueError(\"broken example\")\n",
+			failingExample + ".output":     "last good output\n",
+			stillFailingExample + ".py":    "raise ValueError(\"broken example\")\n",
+			stillFailingExample + ".error": "STATUS_RUN_ERROR",
+			unreadableExample + ".py":      "raise ValueError(\"broken example\")\n",
+		},
+		unreadable: map[string]bool{unreadableExample + ".error": true},
+	}
+	appEnv := environment.NewApplicationEnvs(t.TempDir(), "local", "", "executable_files", "text", environment.NewCacheEnvs("local", "", time.Minute), environment.NewMetricsEnvs(false, 0), environment.NewRateLimitEnvs(0, 1, nil, nil, nil), environment.NewOutputLimitEnvs(1<<20, 0), environment.NewRunHistoryEnvs(false, 0), time.Minute, time.Second, 0, true)
+	sdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, environment.NewExecutorConfig("", "python3", "pytest", []string{}, []string{}, []string{}), "", 1, environment.BeamEnvsOptions{RunMetadata: &pb.RunMetadata{SdkVersion: "Python 3.8.10", BeamVersion: "2.33.0"}})
+
+	historyStore := run_history.NewLocalStore(10)
+
+	report, err := Run(context.Background(), storage, historyStore, appEnv, sdkEnv)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+
+	if !reflect.DeepEqual(report.Refreshed, []string{passingExample}) {
+		t.Errorf("Run() refreshed = %v, want %v", report.Refreshed, []string{passingExample})
+	}
+	if len(report.Failed) != 3 || report.Failed[0].CloudPath != failingExample || report.Failed[1].CloudPath != stillFailingExample || report.Failed[2].CloudPath != unreadableExample {
+		t.Fatalf("Run() failed = %v, want %s, %s and %s", report.Failed, failingExample, stillFailingExample, unreadableExample)
+	}
+	for _, failure := range report.Failed {
+		if failure.Status != pb.Status_STATUS_RUN_ERROR || !strings.Contains(failure.Output, "broken example") {
+			t.Errorf("Run() failure = %v, want %s with the error of the run", failure, pb.Status_STATUS_RUN_ERROR)
+		}
+	}
+	// Test that only the example which passed the previous refresh is reported as newly failed,
+	// the example whose previous error can't be read isn't reported as newly failed
+	if newlyFailed := report.NewlyFailed(); len(newlyFailed) != 1 || newlyFailed[0].CloudPath != failingExample {
+		t.Errorf("NewlyFailed() = %v, want %s", newlyFailed, failingExample)
+	}
+	if want := "1 passed, 3 failed, 1 newly failed: " + failingExample + " (STATUS_RUN_ERROR)"; !strings.HasSuffix(report.String(), want) {
+		t.Errorf("String() = %s, want suffix %s", report.String(), want)
+	}
+
+	if got := storage.files[passingExample+".output"]; got != "Hello, Beam\n" {
+		t.Errorf("stored output = %q, want %q", got, "Hello, Beam\n")
+	}
+	// Test that the metadata of the run is stored with the output, so the catalog shows versions which produced it
+	metadata := &pb.RunMetadata{}
+	if err = json.Unmarshal([]byte(storage.files[passingExample+".metadata"]), metadata); err != nil || metadata.SdkVersion != "Python 3.8.10" || metadata.BeamVersion != "2.33.0" {
+		t.Errorf("stored metadata = %q, want versions of the run", storage.files[passingExample+".metadata"])
+	}
+	if _, ok := storage.files[failingExample+".metadata"]; ok {
+		t.Errorf("metadata of the failed example is stored without its output")
+	}
+	if got, ok := storage.files[passingExample+".error"]; !ok || got != "" {
+		t.Errorf("stored error = %q, want the empty error", got)
+	}
+	// Test that the catalog keeps the last successful output of the failed example
+	if got := storage.files[failingExample+".output"]; got != "last good output\n" {
+		t.Errorf("stored output = %q, want %q", got, "last good output\n")
+	}
+	if got := storage.files[failingExample+".error"]; !strings.HasPrefix(got, "STATUS_RUN_ERROR\n") || !strings.Contains(got, "broken example") {
+		t.Errorf("stored error = %q, want the status and the error of the run", got)
+	}
+	for _, path := range []string{passingExample, failingExample} {
+		if _, ok := storage.files[path+".log"]; !ok {
+			t.Errorf("logs of %s aren't stored", path)
+		}
+	}
+
+	// Test that runs of examples are added to the run history with their cloud paths
+	tests := []struct {
+		name             string
+		exampleId        string
+		wantStatus       pb.Status
+		wantErrorSummary string
+	}{
+		{
+			name:       "run of the passing example is recorded",
+			exampleId:  passingExample,
+			wantStatus: pb.Status_STATUS_FINISHED,
+		},
+		{
+			name:             "run of the failing example is recorded with the error",
+			exampleId:        failingExample,
+			wantStatus:       pb.Status_STATUS_RUN_ERROR,
+			wantErrorSummary: "broken example",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := waitForRunRecord(t, historyStore, tt.exampleId)
+			if record.Status != tt.wantStatus || record.Sdk != pb.Sdk_SDK_PYTHON || record.BeamVersion != "2.33.0" {
+				t.Errorf("run record = %v, want %s of %s with Beam 2.33.0", record, tt.wantStatus, pb.Sdk_SDK_PYTHON)
+			}
+			if !strings.Contains(record.ErrorSummary, tt.wantErrorSummary) || (tt.wantErrorSummary == "") != (record.ErrorSummary == "") {
+				t.Errorf("run record error summary = %q, want %q", record.ErrorSummary, tt.wantErrorSummary)
+			}
+			if len(record.StageDurations) == 0 || record.StageDurations[len(record.StageDurations)-1].Stage != "Run" {
+				t.Errorf("run record stage durations = %v, want durations up to the Run stage", record.StageDurations)
+			}
+		})
+	}
+}
+
+// waitForRunRecord waits for the record of the example which is written to the run history in the background
+func waitForRunRecord(t *testing.T, historyStore run_history.Store, exampleId string) *pb.RunRecord {
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if records, _ := historyStore.List(context.Background(), exampleId, 1); len(records) == 1 {
+			return records[0]
+		}
+	}
+	t.Fatalf("run of %s isn't added to the run history", exampleId)
+	return nil
+}
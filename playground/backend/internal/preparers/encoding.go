@@ -0,0 +1,154 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+const (
+	utf16LittleEndian = "UTF-16LE"
+	utf16BigEndian    = "UTF-16BE"
+	unknownEncoding   = "unknown"
+)
+
+var (
+	utf8Bom    = []byte{0xEF, 0xBB, 0xBF}
+	utf16LeBom = []byte{0xFF, 0xFE}
+	utf16BeBom = []byte{0xFE, 0xFF}
+)
+
+// UnsupportedEncodingError is returned by preparers if the file with code can't be transcoded to UTF-8
+type UnsupportedEncodingError struct {
+	FilePath string
+	Encoding string
+}
+
+func (e *UnsupportedEncodingError) Error() string {
+	return fmt.Sprintf("File %s has unsupported encoding: %s, code should be saved in UTF-8", e.FilePath, e.Encoding)
+}
+
+// InvalidUtf8Error is returned by the UTF-8 validator if the file with code contains an invalid UTF-8 sequence
+type InvalidUtf8Error struct {
+	FilePath string
+	// Offset is the byte offset of the first invalid sequence from the start of the file
+	Offset int
+	Line   int
+}
+
+func (e *InvalidUtf8Error) Error() string {
+	return fmt.Sprintf("File %s isn't valid UTF-8: invalid byte sequence at offset %d (line %d), code should be saved in UTF-8", e.FilePath, e.Offset, e.Line)
+}
+
+//WithUtf8Validator adds preparer to check that the code is valid UTF-8.
+//The preparer is applied before other preparers of the code, so they never work with garbled code.
+func (builder *PreparersBuilder) WithUtf8Validator() *PreparersBuilder {
+	utf8Validator := Preparer{
+		Name:    Utf8ValidatorName,
+		Prepare: validateUtf8,
+		Args:    []interface{}{builder.filePath, builder.logger},
+	}
+	builder.AddPreparer(utf8Validator)
+	return builder
+}
+
+// validateUtf8 checks that the file by filePath is valid UTF-8 and returns InvalidUtf8Error otherwise.
+// The byte order mark of UTF-8 is allowed. UTF-16 code is valid as well, since preparers transcode it to UTF-8.
+func validateUtf8(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	if utf8.Valid(data) {
+		return nil
+	}
+	if code, encoding := toUtf8(data); code != nil && encoding != "" {
+		return nil
+	}
+	offset := firstInvalidUtf8(data)
+	return &InvalidUtf8Error{FilePath: filePath, Offset: offset, Line: bytes.Count(data[:offset], lf) + 1}
+}
+
+// firstInvalidUtf8 returns the byte offset of the first invalid UTF-8 sequence of data or -1 if data is valid
+func firstInvalidUtf8(data []byte) int {
+	for offset := 0; offset < len(data); {
+		r, size := utf8.DecodeRune(data[offset:])
+		if r == utf8.RuneError && size == 1 {
+			return offset
+		}
+		offset += size
+	}
+	return -1
+}
+
+// readSourceFile reads the file by filePath and returns its content as UTF-8 without a byte order mark.
+// UTF-16 content is transcoded to UTF-8, other encodings are rejected with UnsupportedEncodingError.
+func readSourceFile(filePath string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	code, encoding := toUtf8(data)
+	if code == nil {
+		return nil, &UnsupportedEncodingError{FilePath: filePath, Encoding: encoding}
+	}
+	return code, nil
+}
+
+// toUtf8 strips the UTF-8 byte order mark and transcodes UTF-16 data to UTF-8.
+// Returns nil and the detected encoding if data can't be transcoded.
+func toUtf8(data []byte) ([]byte, string) {
+	switch {
+	case bytes.HasPrefix(data, utf8Bom):
+		data = data[len(utf8Bom):]
+	case bytes.HasPrefix(data, utf16LeBom):
+		return decodeUtf16(data[len(utf16LeBom):], binary.LittleEndian), utf16LittleEndian
+	case bytes.HasPrefix(data, utf16BeBom):
+		return decodeUtf16(data[len(utf16BeBom):], binary.BigEndian), utf16BigEndian
+	case len(data) >= 2 && data[0] != 0 && data[1] == 0:
+		// ASCII character followed by zero byte is the start of UTF-16LE without byte order mark
+		return decodeUtf16(data, binary.LittleEndian), utf16LittleEndian
+	case len(data) >= 2 && data[0] == 0 && data[1] != 0:
+		return decodeUtf16(data, binary.BigEndian), utf16BigEndian
+	}
+	if !utf8.Valid(data) {
+		return nil, unknownEncoding
+	}
+	return data, ""
+}
+
+// decodeUtf16 transcodes UTF-16 data with received byte order to UTF-8.
+// Returns nil if data has an odd length.
+func decodeUtf16(data []byte, order binary.ByteOrder) []byte {
+	if len(data)%2 != 0 {
+		return nil
+	}
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i < len(data); i += 2 {
+		units = append(units, order.Uint16(data[i:]))
+	}
+	result := []byte(string(utf16.Decode(units)))
+	return bytes.TrimPrefix(result, utf8Bom)
+}
@@ -0,0 +1,260 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+const encodingTestCode = "package org.apache.beam.examples;\n\npublic class Class {\n    public static void main(String[] args) {\n        java.util.Random random = new java.util.Random();\n    }\n}"
+
+// encodeUtf16 encodes code to UTF-16 with received byte order and optional byte order mark
+func encodeUtf16(code string, order binary.ByteOrder, bom []byte) []byte {
+	units := utf16.Encode([]rune(code))
+	data := make([]byte, len(units)*2)
+	for i, unit := range units {
+		order.PutUint16(data[i*2:], unit)
+	}
+	return append(append([]byte{}, bom...), data...)
+}
+
+// encodingFixtures returns encodingTestCode saved with different encodings
+func encodingFixtures() map[string][]byte {
+	return map[string][]byte{
+		"UTF-8":                   []byte(encodingTestCode),
+		"UTF-8 with BOM":          append(append([]byte{}, utf8Bom...), encodingTestCode...),
+		"UTF-16LE with BOM":       encodeUtf16(encodingTestCode, binary.LittleEndian, utf16LeBom),
+		"UTF-16BE with BOM":       encodeUtf16(encodingTestCode, binary.BigEndian, utf16BeBom),
+		"UTF-16LE without BOM":    encodeUtf16(encodingTestCode, binary.LittleEndian, nil),
+		"UTF-16BE without BOM":    encodeUtf16(encodingTestCode, binary.BigEndian, nil),
+		"UTF-16LE with UTF-8 BOM": encodeUtf16("\uFEFF"+encodingTestCode, binary.LittleEndian, nil),
+	}
+}
+
+func Test_toUtf8(t *testing.T) {
+	for name, data := range encodingFixtures() {
+		t.Run(name, func(t *testing.T) {
+			if got, _ := toUtf8(data); string(got) != encodingTestCode {
+				t.Errorf("toUtf8() = %q, want %q", string(got), encodingTestCode)
+			}
+		})
+	}
+}
+
+func Test_readSourceFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "UTF-8 with BOM",
+			data:    append(append([]byte{}, utf8Bom...), "class Class {}"...),
+			want:    "class Class {}",
+			wantErr: false,
+		},
+		{
+			// Test that code in a single-byte encoding is rejected
+			name:    "Latin-1",
+			data:    []byte("class Caf\xe9 {}"),
+			wantErr: true,
+		},
+		{
+			name:    "UTF-16 with odd length",
+			data:    append(append([]byte{}, utf16LeBom...), 'c', 0, 'l'),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "Encoding.java")
+			if err := os.WriteFile(filePath, tt.data, 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			got, err := readSourceFile(filePath)
+			var encodingErr *UnsupportedEncodingError
+			if (err != nil) != tt.wantErr || (err != nil && !errors.As(err, &encodingErr)) {
+				t.Fatalf("readSourceFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != tt.want {
+				t.Errorf("readSourceFile() = %q, want %q", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_javaPreparersEncodings(t *testing.T) {
+	withoutPublicClass := "package org.apache.beam.examples;\n\nclass Class {\n    public static void main(String[] args) {\n        java.util.Random random = new java.util.Random();\n    }\n}"
+	withImportedPackage := "import org.apache.beam.examples.*;\n\npublic class Class {\n    public static void main(String[] args) {\n        java.util.Random random = new java.util.Random();\n    }\n}"
+	withoutPackage := "\n\n\npublic class Class {\n    public static void main(String[] args) {\n        java.util.Random random = new java.util.Random();\n    }\n}"
+	withSeededRandom := "package org.apache.beam.examples;\n\npublic class Class {\n    public static void main(String[] args) {\n        java.util.Random random = new java.util.Random(42L);\n    }\n}"
+
+	tests := []struct {
+		name        string
+		prepare     func(filePath string) error
+		wantFile    string
+		wantCode    string
+		wantErr     error
+		keepsFormat bool
+	}{
+		{
+			name: "public class remover",
+			prepare: func(filePath string) error {
+				return removePublicClassModifier(filePath, classWithPublicModifierPattern, classWithoutPublicModifierPattern)
+			},
+			wantCode: withoutPublicClass,
+		},
+		{
+			name:     "package changer",
+			prepare:  func(filePath string) error { return changePackage(filePath) },
+			wantCode: withImportedPackage,
+		},
+		{
+			name:     "package remover",
+			prepare:  func(filePath string) error { return replace(filePath, packagePattern, newLinePattern) },
+			wantCode: withoutPackage,
+		},
+		{
+			name:     "seed injector",
+			prepare:  func(filePath string) error { return injectRandomSeed(filePath) },
+			wantCode: withSeededRandom,
+		},
+		{
+			// Test that the class name is found without the byte order mark
+			name:        "file name changer",
+			prepare:     func(filePath string) error { return changeJavaTestFileName(filePath) },
+			wantFile:    "Class.java",
+			keepsFormat: true,
+		},
+		{
+			name:        "forbidden api guard",
+			prepare:     func(filePath string) error { return checkForbiddenApis(filePath, []string{"java.util.Random"}) },
+			wantErr:     &ForbiddenApiError{Usages: []ForbiddenApiUsage{{Api: "java.util.Random", Line: 5}}},
+			keepsFormat: true,
+		},
+	}
+	for _, tt := range tests {
+		for encoding, data := range encodingFixtures() {
+			t.Run(tt.name+" "+encoding, func(t *testing.T) {
+				dir := t.TempDir()
+				filePath := filepath.Join(dir, "Encoding.java")
+				if err := os.WriteFile(filePath, data, 0600); err != nil {
+					t.Fatalf("error during test setup: %s", err.Error())
+				}
+				err := tt.prepare(filePath)
+				if !reflect.DeepEqual(err, tt.wantErr) {
+					t.Fatalf("prepare() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if tt.wantFile != "" {
+					if _, err := os.Stat(filepath.Join(dir, tt.wantFile)); err != nil {
+						t.Errorf("prepare() file %s doesn't exist", tt.wantFile)
+					}
+				}
+				if tt.keepsFormat {
+					return
+				}
+				code, _ := os.ReadFile(filePath)
+				if string(code) != tt.wantCode {
+					t.Errorf("prepare() code = %q, wantCode %q", string(code), tt.wantCode)
+				}
+			})
+		}
+	}
+}
+
+func Test_validateUtf8(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name: "valid UTF-8",
+			data: []byte("public class Café {\n    // ☕ \U0001F600\n}\n"),
+		},
+		{
+			name: "UTF-8 with BOM",
+			data: append(append([]byte{}, utf8Bom...), "public class Café {}\n"...),
+		},
+		{
+			// Test that UTF-16 code is valid, since preparers transcode it to UTF-8
+			name: "UTF-16LE with BOM",
+			data: encodeUtf16(encodingTestCode, binary.LittleEndian, utf16LeBom),
+		},
+		{
+			name:    "invalid UTF-8 in the middle of the file",
+			data:    []byte("public class Main {\n    String name = \"Caf\xe9\";\n}\n"),
+			wantErr: &InvalidUtf8Error{Offset: 42, Line: 2},
+		},
+		{
+			// Test that the offset is counted from the start of the file including the byte order mark
+			name:    "invalid UTF-8 after BOM",
+			data:    append(append([]byte{}, utf8Bom...), "class \xc3\x28 {}"...),
+			wantErr: &InvalidUtf8Error{Offset: 9, Line: 1},
+		},
+		{
+			name:    "truncated sequence at the end of the file",
+			data:    []byte("class Main {}\n\xe2\x82"),
+			wantErr: &InvalidUtf8Error{Offset: 14, Line: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "Main.java")
+			if err := os.WriteFile(filePath, tt.data, 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			if wantErr, ok := tt.wantErr.(*InvalidUtf8Error); ok {
+				wantErr.FilePath = filePath
+			}
+			if err := validateUtf8(filePath); !reflect.DeepEqual(err, tt.wantErr) {
+				t.Errorf("validateUtf8() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreparersBuilder_WithUtf8Validator(t *testing.T) {
+	code := []byte("package org.apache.beam.examples;\n\nclass Caf\xe9 {}\n")
+	filePath := filepath.Join(t.TempDir(), "Main.java")
+	if err := os.WriteFile(filePath, code, 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	// Test that the validator is applied before preparers which are added earlier
+	builder := NewPreparersBuilder(filePath)
+	builder.JavaPreparers().WithPackageRemover()
+	builder.WithUtf8Validator()
+	var err error
+	for _, preparer := range *builder.Build().GetPreparers() {
+		if err = preparer.Prepare(preparer.Args...); err != nil {
+			break
+		}
+	}
+	var utf8Err *InvalidUtf8Error
+	if !errors.As(err, &utf8Err) {
+		t.Fatalf("preparers error = %v, want InvalidUtf8Error", err)
+	}
+	if got, _ := os.ReadFile(filePath); string(got) != string(code) {
+		t.Errorf("preparers changed the code = %q, want %q", got, code)
+	}
+}
@@ -27,7 +27,6 @@ import (
 const (
 	goName  = "go"
 	fmtArgs = "fmt"
-	sep     = "."
 )
 
 //GoPreparersBuilder facet of PreparersBuilder
@@ -43,8 +42,10 @@ func (builder *PreparersBuilder) GoPreparers() *GoPreparersBuilder {
 //WithCodeFormatter adds code formatter preparer
 func (builder *GoPreparersBuilder) WithCodeFormatter() *GoPreparersBuilder {
 	formatCodePreparer := Preparer{
-		Prepare: formatCode,
-		Args:    []interface{}{builder.filePath},
+		Name:        CodeFormatterName,
+		Prepare:     formatCode,
+		Args:        []interface{}{builder.filePath},
+		Description: "formatted the code with gofmt",
 	}
 	builder.AddPreparer(formatCodePreparer)
 	return builder
@@ -53,8 +54,10 @@ func (builder *GoPreparersBuilder) WithCodeFormatter() *GoPreparersBuilder {
 //WithFileNameChanger adds preparer to change file name
 func (builder *GoPreparersBuilder) WithFileNameChanger() *GoPreparersBuilder {
 	changeTestFileName := Preparer{
-		Prepare: changeGoTestFileName,
-		Args:    []interface{}{builder.filePath},
+		Name:        FileNameChangerName,
+		Prepare:     changeGoTestFileName,
+		Args:        []interface{}{builder.filePath},
+		Description: "renamed the file to the test file",
 	}
 	builder.AddPreparer(changeTestFileName)
 	return builder
@@ -67,6 +70,19 @@ func GetGoPreparers(builder *PreparersBuilder, isUnitTest bool) {
 		WithCodeFormatter()
 	if isUnitTest {
 		builder.GoPreparers().WithFileNameChanger()
+		builder.warnIfSkipped(FileNameChangerName, "the unit test may not be found by go test")
+	}
+}
+
+// GoRegistry returns preparers of Go code which can be skipped or forced by name
+func GoRegistry() Registry {
+	return Registry{
+		CodeFormatterName:        func(builder *PreparersBuilder) { builder.GoPreparers().WithCodeFormatter() },
+		FileNameChangerName:      func(builder *PreparersBuilder) { builder.GoPreparers().WithFileNameChanger() },
+		StdinCheckerName:         func(builder *PreparersBuilder) { builder.GoPreparers().WithStdinChecker(false) },
+		LineEndingNormalizerName: func(builder *PreparersBuilder) { builder.WithLineEndingNormalizer(LineEndingLf) },
+		Utf8ValidatorName:        func(builder *PreparersBuilder) { builder.WithUtf8Validator() },
+		IncludeResolverName:      func(builder *PreparersBuilder) { builder.WithIncludeResolver(builder.codeDir()) },
 	}
 }
 
@@ -84,7 +100,7 @@ func formatCode(args ...interface{}) error {
 
 func changeGoTestFileName(args ...interface{}) error {
 	filePath := args[0].(string)
-	testFileName := fmt.Sprintf("%s_test.%s", strings.Split(filePath, sep)[0], goName)
+	testFileName := fmt.Sprintf("%s_test%s", strings.TrimSuffix(filePath, filepath.Ext(filePath)), filepath.Ext(filePath))
 	err := os.Rename(filePath, testFileName)
 	if err != nil {
 		return err
@@ -101,14 +101,18 @@ func TestGetGoPreparers(t *testing.T) {
 			// getting the expected preparer
 			name: "get expected preparer",
 			args: args{filePath: ""},
-			want: &[]Preparer{{Prepare: formatCode, Args: nil}, {Prepare: changeGoTestFileName, Args: nil}},
+			want: &[]Preparer{
+				{Name: CodeFormatterName, Prepare: formatCode, Args: nil, Description: "formatted the code with gofmt"},
+				{Name: FileNameChangerName, Prepare: changeGoTestFileName, Args: nil, Description: "renamed the file to the test file"},
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			builder := NewPreparersBuilder(tt.args.filePath)
 			GetGoPreparers(builder, true)
-			if got := builder.Build().GetPreparers(); !reflect.DeepEqual(fmt.Sprint(got), fmt.Sprint(tt.want)) {
+			// preparers are compared before Build since it wraps them to fill the summary
+			if got := builder.preparers.GetPreparers(); !reflect.DeepEqual(fmt.Sprint(got), fmt.Sprint(tt.want)) {
 				t.Errorf("GetGoPreparers() = %v, want %v", got, tt.want)
 			}
 		})
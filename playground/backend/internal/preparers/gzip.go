@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+)
+
+// maxDecompressedSize is the max size of the decompressed code, so the archive can't fill the disk
+const maxDecompressedSize = 10 << 20
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// CompressedSizeError is returned by preparers if the decompressed code exceeds maxDecompressedSize
+type CompressedSizeError struct {
+	FilePath string
+}
+
+func (e *CompressedSizeError) Error() string {
+	return fmt.Sprintf("File %s is too large after decompression, max size is %d bytes", e.FilePath, maxDecompressedSize)
+}
+
+//WithGzipTransparency adds preparer to decompress gzip-compressed code before all other preparers.
+//Code without gzip magic bytes is kept as is.
+//The prepared code isn't compressed back, since the compiler and the runner read the code from the same file.
+func (builder *PreparersBuilder) WithGzipTransparency() *PreparersBuilder {
+	gzipDecompressor := Preparer{
+		Name:    GzipDecompressorName,
+		Prepare: decompressGzip,
+		Args:    []interface{}{builder.filePath, builder.logger},
+	}
+	builder.AddPreparer(gzipDecompressor)
+	return builder
+}
+
+// DecompressGzip replaces gzip-compressed code of the file by filePath with the decompressed code.
+// It is called before the validation of the code, so validators see the decompressed code as well.
+func DecompressGzip(filePath string, log *logger.Entry) error {
+	return decompressGzip(filePath, log)
+}
+
+// decompressGzip replaces gzip-compressed code of the file by filePath with the decompressed code
+func decompressGzip(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	if !bytes.HasPrefix(data, gzipMagic) {
+		return nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		log.Errorf("Preparation: Error during decompress file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	defer reader.Close()
+	code, err := io.ReadAll(io.LimitReader(reader, maxDecompressedSize+1))
+	if err != nil {
+		log.Errorf("Preparation: Error during decompress file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	if len(code) > maxDecompressedSize {
+		return &CompressedSizeError{FilePath: filePath}
+	}
+	if err = writeKeepingMode(filePath, code); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// writeKeepingMode writes data to the existing file by filePath keeping its permissions
+func writeKeepingMode(filePath string, data []byte) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, info.Mode())
+}
@@ -0,0 +1,119 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers_test
+
+import (
+	"beam.apache.org/playground/backend/internal/preparers"
+	"beam.apache.org/playground/backend/internal/preparers/preparertest"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const gzipFixture = "testdata/java/package_changer/multi_segment.java"
+
+// gzipCopy writes the gzip-compressed copy of the fixture into a temporary folder and returns its path
+func gzipCopy(t *testing.T, fixturePath string) string {
+	code, err := os.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err = writer.Write(code); err != nil {
+		t.Fatal(err)
+	}
+	if err = writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), filepath.Base(fixturePath))
+	if err = os.WriteFile(path, compressed.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPreparersBuilder_WithGzipTransparency(t *testing.T) {
+	packageChanger := func(builder *preparers.PreparersBuilder) { builder.JavaPreparers().WithPackageChanger() }
+	name := filepath.Base(gzipFixture)
+	want := preparertest.RunChain(t, packageChanger, gzipFixture).Files[name]
+
+	tests := []struct {
+		name    string
+		fixture string
+		chain   preparertest.Chain
+	}{
+		{
+			name:    "gzipped code is decompressed",
+			fixture: gzipCopy(t, gzipFixture),
+			chain: func(builder *preparers.PreparersBuilder) {
+				packageChanger(builder)
+				builder.WithGzipTransparency()
+			},
+		},
+		{
+			// Test that code without gzip magic bytes isn't changed
+			name:    "plain code",
+			fixture: gzipFixture,
+			chain: func(builder *preparers.PreparersBuilder) {
+				builder.WithGzipTransparency()
+				packageChanger(builder)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := preparertest.RunChain(t, tt.chain, tt.fixture)
+			if result.Err != nil {
+				t.Fatalf("preparers error = %v", result.Err)
+			}
+			if got := result.Files[name]; got != want {
+				t.Errorf("prepared code = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPreparersBuilder_WithGzipTransparencyCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Main.java")
+	if err := os.WriteFile(path, []byte{0x1f, 0x8b, 0x00, 0x01}, 0600); err != nil {
+		t.Fatal(err)
+	}
+	result := preparertest.RunChain(t, func(builder *preparers.PreparersBuilder) { builder.WithGzipTransparency() }, path)
+	if result.Err == nil {
+		t.Errorf("preparers should fail on corrupted gzip data")
+	}
+}
+
+func TestDecompressGzip(t *testing.T) {
+	want, err := os.ReadFile(gzipFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := gzipCopy(t, gzipFixture)
+	if err = preparers.DecompressGzip(path, nil); err != nil {
+		t.Fatalf("DecompressGzip() error = %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("DecompressGzip() code = %q, want %q", got, want)
+	}
+}
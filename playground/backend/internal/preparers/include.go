@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	includeDirectivePattern = `^[ \t]*(?://|#)[ \t]*@include[ \t]+(\S+)[ \t]*\r?$`
+	maxIncludeDepth         = 8
+)
+
+var (
+	includeDirectiveRegexp = regexp.MustCompile(includeDirectivePattern)
+	includeDirective       = []byte("@include")
+)
+
+// IncludeError is returned by the include resolver if the file referenced by the @include directive can't be inlined
+type IncludeError struct {
+	FilePath string
+	Line     int
+	Include  string
+	Reason   string
+}
+
+func (e *IncludeError) Error() string {
+	return fmt.Sprintf("File %s can't include %s at line %d: %s", e.FilePath, e.Include, e.Line, e.Reason)
+}
+
+//WithIncludeResolver adds preparer to replace "// @include <file>" and "# @include <file>" directives with contents
+//of files from baseDir. Included files can include other files, cyclic and too deep includes are rejected.
+func (builder *PreparersBuilder) WithIncludeResolver(baseDir string) *PreparersBuilder {
+	includeResolver := Preparer{
+		Name:        IncludeResolverName,
+		Prepare:     resolveIncludes,
+		Args:        []interface{}{builder.filePath, baseDir, builder.logger},
+		Description: "inlined files of @include directives",
+		Pattern:     includeDirectivePattern,
+	}
+	builder.AddPreparer(includeResolver)
+	return builder
+}
+
+// resolveIncludes replaces @include directives of the file by filePath with contents of referenced files from baseDir
+func resolveIncludes(args ...interface{}) error {
+	filePath := args[0].(string)
+	baseDir := args[1].(string)
+	log := loggerFromArgs(args, 2)
+
+	code, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	if !bytes.Contains(code, includeDirective) {
+		return nil
+	}
+	absFilePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+	resolved, err := inlineIncludes(code, filePath, baseDir, []string{absFilePath})
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(code, resolved) {
+		return nil
+	}
+	if err = writeKeepingMode(filePath, resolved); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// inlineIncludes returns the code with @include directives replaced by contents of referenced files.
+// chain contains files which are being inlined, the last of them is the file of the code.
+func inlineIncludes(code []byte, filePath, baseDir string, chain []string) ([]byte, error) {
+	lines := bytes.SplitAfter(code, lf)
+	var resolved bytes.Buffer
+	for i, line := range lines {
+		match := includeDirectiveRegexp.FindSubmatch(bytes.TrimSuffix(line, lf))
+		if match == nil {
+			resolved.Write(line)
+			continue
+		}
+		include := string(match[1])
+		includeError := &IncludeError{FilePath: filePath, Line: i + 1, Include: include}
+		includePath, err := includeFilePath(baseDir, include)
+		if err != nil {
+			includeError.Reason = err.Error()
+			return nil, includeError
+		}
+		for _, included := range chain {
+			if included == includePath {
+				includeError.Reason = fmt.Sprintf("cyclic include: %s -> %s", strings.Join(chain, " -> "), includePath)
+				return nil, includeError
+			}
+		}
+		if len(chain) > maxIncludeDepth {
+			includeError.Reason = fmt.Sprintf("includes are nested deeper than %d levels", maxIncludeDepth)
+			return nil, includeError
+		}
+		content, err := ioutil.ReadFile(includePath)
+		if os.IsNotExist(err) {
+			includeError.Reason = fmt.Sprintf("file %s doesn't exist", includePath)
+			return nil, includeError
+		}
+		if err != nil {
+			includeError.Reason = err.Error()
+			return nil, includeError
+		}
+		content, err = includedCode(content, includePath)
+		if err != nil {
+			return nil, err
+		}
+		content, err = inlineIncludes(content, includePath, baseDir, append(chain[:len(chain):len(chain)], includePath))
+		if err != nil {
+			return nil, err
+		}
+		resolved.Write(content)
+		if len(content) > 0 && !bytes.HasSuffix(content, lf) && bytes.HasSuffix(line, lf) {
+			resolved.Write(lf)
+		}
+	}
+	return resolved.Bytes(), nil
+}
+
+// includedCode returns content of the included file by includePath as UTF-8 without a byte order mark,
+// so included code is checked the same way as the code by the UTF-8 validator before it's inlined.
+func includedCode(content []byte, includePath string) ([]byte, error) {
+	code, encoding := toUtf8(content)
+	if code != nil {
+		return code, nil
+	}
+	if offset := firstInvalidUtf8(content); offset >= 0 {
+		return nil, &InvalidUtf8Error{FilePath: includePath, Offset: offset, Line: bytes.Count(content[:offset], lf) + 1}
+	}
+	return nil, &UnsupportedEncodingError{FilePath: includePath, Encoding: encoding}
+}
+
+// codeDir returns the folder of the code, files which are uploaded together with the code are included from it
+func (builder *PreparersBuilder) codeDir() string {
+	return filepath.Dir(builder.filePath)
+}
+
+// includeFilePath returns the path of the included file in baseDir.
+// Absolute paths and paths outside of baseDir are rejected, so the code can't inline arbitrary files of the server.
+func includeFilePath(baseDir, include string) (string, error) {
+	if filepath.IsAbs(include) {
+		return "", fmt.Errorf("absolute paths can't be included")
+	}
+	base, err := filepath.Abs(baseDir)
+	if err != nil {
+		return "", err
+	}
+	includePath := filepath.Join(base, include)
+	if relative, err := filepath.Rel(base, includePath); err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("paths outside of %s can't be included", baseDir)
+	}
+	return includePath, nil
+}
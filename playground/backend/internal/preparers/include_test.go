@@ -0,0 +1,201 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_resolveIncludes(t *testing.T) {
+	deepIncludes := map[string]string{}
+	for i := 0; i <= maxIncludeDepth; i++ {
+		deepIncludes[fmt.Sprintf("level%d.java", i)] = fmt.Sprintf("// @include level%d.java\n", i+1)
+	}
+	deepIncludes[fmt.Sprintf("level%d.java", maxIncludeDepth+1)] = "int deepest = 0;\n"
+	tests := []struct {
+		name string
+		code string
+		// includes are files of the base folder by their names
+		includes   map[string]string
+		want       string
+		wantReason string
+	}{
+		{
+			name:     "single include",
+			code:     "public class Main {\n    // @include setup.java\n    public static void main(String[] args) {}\n}\n",
+			includes: map[string]string{"setup.java": "    static int seed = 42;\n"},
+			want:     "public class Main {\n    static int seed = 42;\n    public static void main(String[] args) {}\n}\n",
+		},
+		{
+			// Test that included files are resolved relative to the base folder, not to the including file
+			name:     "nested include",
+			code:     "# @include common/setup.py\nprint(seed)\n",
+			includes: map[string]string{"common/setup.py": "# @include imports.py\nseed = 42", "imports.py": "import random\n"},
+			want:     "import random\nseed = 42\nprint(seed)\n",
+		},
+		{
+			name:     "code without includes",
+			code:     "public class Main {}\n",
+			includes: map[string]string{},
+			want:     "public class Main {}\n",
+		},
+		{
+			name:       "cyclic include",
+			code:       "// @include a.java\n",
+			includes:   map[string]string{"a.java": "// @include b.java\n", "b.java": "// @include a.java\n"},
+			wantReason: "cyclic include",
+		},
+		{
+			name:       "missing include",
+			code:       "class Main {}\n// @include missing.java\n",
+			includes:   map[string]string{},
+			wantReason: "doesn't exist",
+		},
+		{
+			name:       "include outside of the base folder",
+			code:       "// @include ../secret.java\n",
+			includes:   map[string]string{},
+			wantReason: "outside of",
+		},
+		{
+			name:       "too deep includes",
+			code:       "// @include level0.java\n",
+			includes:   deepIncludes,
+			wantReason: "nested deeper",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := t.TempDir()
+			for name, content := range tt.includes {
+				includePath := filepath.Join(baseDir, name)
+				if err := os.MkdirAll(filepath.Dir(includePath), 0700); err != nil {
+					t.Fatalf("error during test setup: %s", err.Error())
+				}
+				if err := os.WriteFile(includePath, []byte(content), 0600); err != nil {
+					t.Fatalf("error during test setup: %s", err.Error())
+				}
+			}
+			filePath := filepath.Join(t.TempDir(), "Main.java")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			err := resolveIncludes(filePath, baseDir)
+			if tt.wantReason != "" {
+				var includeErr *IncludeError
+				if !errors.As(err, &includeErr) || !strings.Contains(includeErr.Reason, tt.wantReason) {
+					t.Fatalf("resolveIncludes() error = %v, want IncludeError with reason %q", err, tt.wantReason)
+				}
+				if got, _ := os.ReadFile(filePath); string(got) != tt.code {
+					t.Errorf("resolveIncludes() changed the code = %q, want %q", got, tt.code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveIncludes() error = %v", err)
+			}
+			if got, _ := os.ReadFile(filePath); string(got) != tt.want {
+				t.Errorf("resolveIncludes() code = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreparersBuilder_WithIncludeResolver(t *testing.T) {
+	baseDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(baseDir, "helper.java"), []byte("public class Helper {}\n"), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	filePath := filepath.Join(t.TempDir(), "Main.java")
+	if err := os.WriteFile(filePath, []byte("public class Main {}\n// @include helper.java\n"), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	// Test that included code is prepared by preparers which are added earlier
+	builder := NewPreparersBuilder(filePath)
+	builder.JavaPreparers().WithPublicClassRemover()
+	builder.WithIncludeResolver(baseDir)
+	for _, preparer := range *builder.Build().GetPreparers() {
+		if err := preparer.Prepare(preparer.Args...); err != nil {
+			t.Fatalf("preparer %s error = %v", preparer.Name, err)
+		}
+	}
+	want := "class Main {}\nclass Helper {}"
+	if got, _ := os.ReadFile(filePath); string(got) != want {
+		t.Errorf("preparers code = %q, want %q", got, want)
+	}
+	wantTransformations := []string{"inlined files of @include directives", "removed the public modifier of classes"}
+	if got := builder.Summary().Transformations; !reflect.DeepEqual(got, wantTransformations) {
+		t.Errorf("summary transformations = %v, want %v", got, wantTransformations)
+	}
+}
+
+func Test_resolveIncludesEncoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		include []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			// Test that the byte order mark of the included file isn't inlined into the middle of the code
+			name:    "included code with byte order mark",
+			include: append([]byte{0xEF, 0xBB, 0xBF}, "class Helper {}\n"...),
+			want:    "class Main {}\nclass Helper {}\n",
+		},
+		{
+			name:    "included code isn't valid UTF-8",
+			include: []byte("class Helper {\n    String s = \"\xff\";\n}\n"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDir := t.TempDir()
+			includePath := filepath.Join(baseDir, "helper.java")
+			if err := os.WriteFile(includePath, tt.include, 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			code := "class Main {}\n// @include helper.java\n"
+			filePath := filepath.Join(t.TempDir(), "Main.java")
+			if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			err := resolveIncludes(filePath, baseDir)
+			if tt.wantErr {
+				var utf8Err *InvalidUtf8Error
+				if !errors.As(err, &utf8Err) || utf8Err.FilePath != includePath || utf8Err.Line != 2 {
+					t.Fatalf("resolveIncludes() error = %v, want InvalidUtf8Error of %s at line 2", err, includePath)
+				}
+				if got, _ := os.ReadFile(filePath); string(got) != code {
+					t.Errorf("resolveIncludes() changed the code = %q, want %q", got, code)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveIncludes() error = %v", err)
+			}
+			if got, _ := os.ReadFile(filePath); string(got) != tt.want {
+				t.Errorf("resolveIncludes() code = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
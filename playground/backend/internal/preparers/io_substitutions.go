@@ -0,0 +1,364 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	javaBigQueryReadPattern    = `\bBigQueryIO\s*\.\s*(?:<[^()]*>\s*)?read\w*\s*\(`
+	javaChainedCallPattern     = `^\s*\.\s*(?:<[^()]*>\s*)?([\w$]+)\s*\(`
+	pythonBigQueryReadPattern  = `\bReadFromBigQuery\s*\(`
+	pythonTableArgumentPattern = `[(,]\s*table\s*=\s*$`
+	javaBigQueryTableMethod    = "from"
+	pathSubstitutionFormat     = "%s -> %s"
+	tableSubstitutionFormat    = "BigQuery table %s -> local data"
+	concatenationOperator      = "+"
+)
+
+var (
+	javaBigQueryReadRegexp    = regexp.MustCompile(javaBigQueryReadPattern)
+	javaChainedCallRegexp     = regexp.MustCompile(javaChainedCallPattern)
+	pythonBigQueryReadRegexp  = regexp.MustCompile(pythonBigQueryReadPattern)
+	pythonTableArgumentRegexp = regexp.MustCompile(pythonTableArgumentPattern)
+)
+
+// literalSyntax describes comments and string literals of the language of the code
+type literalSyntax struct {
+	lineComment  string
+	blockComment bool
+	quotes       string
+	tripleQuotes bool
+}
+
+var (
+	javaLiteralSyntax   = literalSyntax{lineComment: "//", blockComment: true, quotes: `"'`, tripleQuotes: true}
+	pythonLiteralSyntax = literalSyntax{lineComment: "#", quotes: `"'`, tripleQuotes: true}
+)
+
+// stringLiteral is a string literal of the code from start to end including quotes
+type stringLiteral struct {
+	start int
+	end   int
+	value string
+}
+
+// bigQueryRead is the code from start to end which reads the BigQuery table by the name from the literal
+type bigQueryRead struct {
+	start int
+	end   int
+	table stringLiteral
+}
+
+// ioSubstitutor replaces inputs of the code with local stand-ins.
+// findBigQueryReads returns reads of BigQuery tables of the code without comments and string literals.
+type ioSubstitutor struct {
+	paths             map[string]string
+	tables            map[string]string
+	syntax            literalSyntax
+	findBigQueryReads func(stripped string, literals []stringLiteral) []bigQueryRead
+}
+
+//WithIoSubstitutor adds preparer to replace gs:// paths and reads of public BigQuery tables with local stand-ins
+func (builder *JavaPreparersBuilder) WithIoSubstitutor(paths, tables map[string]string) *JavaPreparersBuilder {
+	builder.AddPreparer(ioSubstitutorPreparer(&builder.PreparersBuilder, &ioSubstitutor{paths: paths, tables: tables, syntax: javaLiteralSyntax, findBigQueryReads: findJavaBigQueryReads}))
+	return builder
+}
+
+//WithIoSubstitutor adds preparer to replace gs:// paths and reads of public BigQuery tables with local stand-ins
+func (builder *PythonPreparersBuilder) WithIoSubstitutor(paths, tables map[string]string) *PythonPreparersBuilder {
+	builder.AddPreparer(ioSubstitutorPreparer(&builder.PreparersBuilder, &ioSubstitutor{paths: paths, tables: tables, syntax: pythonLiteralSyntax, findBigQueryReads: findPythonBigQueryReads}))
+	return builder
+}
+
+// ioSubstitutorPreparer returns preparer which applies the substitutor to the code of the builder
+// and adds applied substitutions to the summary of the builder
+func ioSubstitutorPreparer(builder *PreparersBuilder, substitutor *ioSubstitutor) Preparer {
+	return Preparer{
+		Name:    IoSubstitutorName,
+		Prepare: substituteIo,
+		Args:    []interface{}{builder.filePath, substitutor, builder.summary, builder.logger},
+	}
+}
+
+// substituteIo processes file by filePath and replaces inputs which are known to the substitutor with local stand-ins.
+// Unknown inputs are kept, so the code fails with the original error if they can't be read.
+func substituteIo(args ...interface{}) error {
+	filePath := args[0].(string)
+	substitutor := args[1].(*ioSubstitutor)
+	summary := args[2].(*RunSummary)
+	log := loggerFromArgs(args, 3)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	substituted, applied := substitutor.substitute(string(code))
+	if len(applied) == 0 {
+		return nil
+	}
+	if err = writeKeepingMode(filePath, []byte(substituted)); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	for _, substitution := range applied {
+		summary.addSubstitution(substitution)
+	}
+	return nil
+}
+
+// substitute returns code with replaced inputs and descriptions of applied substitutions.
+// Only string literals which contain the whole input are replaced, so inputs built from several parts are kept.
+func (substitutor *ioSubstitutor) substitute(code string) (string, []string) {
+	stripped, literals := scanLiterals(code, substitutor.syntax)
+	var edits []codeEdit
+	var applied []string
+	for _, read := range substitutor.findBigQueryReads(stripped, literals) {
+		replacement, ok := substitutor.tables[read.table.value]
+		if !ok || isConcatenated(stripped, read.table) {
+			continue
+		}
+		edits = append(edits, codeEdit{start: read.start, end: read.end, text: replacement})
+		applied = append(applied, fmt.Sprintf(tableSubstitutionFormat, read.table.value))
+	}
+	for _, literal := range literals {
+		if isEdited(edits, literal) || isConcatenated(stripped, literal) || !isPlainLiteral(code, literal) {
+			continue
+		}
+		path, ok := substitutePath(literal.value, substitutor.paths)
+		if !ok {
+			continue
+		}
+		quote := code[literal.start : literal.start+1]
+		edits = append(edits, codeEdit{start: literal.start, end: literal.end, text: quote + path + quote})
+		applied = append(applied, fmt.Sprintf(pathSubstitutionFormat, literal.value, path))
+	}
+	return applyEdits(code, edits), applied
+}
+
+// substitutePath returns the local path by uri. Paths by uris ending with "/" replace prefixes of other uris,
+// the longest prefix is used if several ones match.
+func substitutePath(uri string, paths map[string]string) (string, bool) {
+	if path, ok := paths[uri]; ok {
+		return path, true
+	}
+	longest := ""
+	for prefix := range paths {
+		if strings.HasSuffix(prefix, "/") && strings.HasPrefix(uri, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	if longest == "" {
+		return "", false
+	}
+	return paths[longest] + strings.TrimPrefix(uri, longest), true
+}
+
+// isPlainLiteral checks that the literal is a single-line string without escapes and prefixes, e.g. f-strings of Python
+func isPlainLiteral(code string, literal stringLiteral) bool {
+	if literal.start > 0 && isJavaIdentifierPart(rune(code[literal.start-1])) {
+		return false
+	}
+	return literal.end-literal.start == len(literal.value)+2 && !strings.ContainsAny(literal.value, "\\ \t\n")
+}
+
+// isConcatenated checks that the literal is a part of a string which is built by concatenation
+func isConcatenated(stripped string, literal stringLiteral) bool {
+	before := strings.TrimRight(stripped[:literal.start], " \t\r\n")
+	after := strings.TrimLeft(stripped[literal.end:], " \t\r\n")
+	return strings.HasSuffix(before, concatenationOperator) || strings.HasPrefix(after, concatenationOperator)
+}
+
+// isEdited checks that the literal is a part of the code which is replaced by one of edits
+func isEdited(edits []codeEdit, literal stringLiteral) bool {
+	for _, edit := range edits {
+		if literal.start >= edit.start && literal.end <= edit.end {
+			return true
+		}
+	}
+	return false
+}
+
+// scanLiterals returns code where comments and string literals are replaced with spaces and all string literals
+// of the code. Unterminated literals are kept in the code as they are.
+func scanLiterals(code string, syntax literalSyntax) (string, []stringLiteral) {
+	stripped := []byte(code)
+	blank := func(start, end int) {
+		for i := start; i < end; i++ {
+			if stripped[i] != '\n' {
+				stripped[i] = ' '
+			}
+		}
+	}
+	var literals []stringLiteral
+	for i := 0; i < len(code); {
+		switch {
+		case strings.HasPrefix(code[i:], syntax.lineComment):
+			end := strings.IndexByte(code[i:], '\n')
+			if end < 0 {
+				end = len(code) - i
+			}
+			blank(i, i+end)
+			i += end
+		case syntax.blockComment && strings.HasPrefix(code[i:], "/*"):
+			end := strings.Index(code[i+2:], "*/")
+			if end < 0 {
+				end = len(code) - i - 4
+			}
+			blank(i, i+end+4)
+			i += end + 4
+		case strings.IndexByte(syntax.quotes, code[i]) >= 0:
+			delimiter := code[i : i+1]
+			if syntax.tripleQuotes && strings.HasPrefix(code[i:], strings.Repeat(delimiter, 3)) {
+				delimiter = strings.Repeat(delimiter, 3)
+			}
+			end, terminated := closingQuote(code, i+len(delimiter), delimiter)
+			if terminated {
+				literals = append(literals, stringLiteral{start: i, end: end, value: code[i+len(delimiter) : end-len(delimiter)]})
+				blank(i, end)
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return string(stripped), literals
+}
+
+// closingQuote returns the index after the delimiter which closes the literal started at start
+// and false if the literal isn't terminated
+func closingQuote(code string, start int, delimiter string) (int, bool) {
+	for i := start; i < len(code); i++ {
+		switch {
+		case code[i] == '\\':
+			i++
+		case strings.HasPrefix(code[i:], delimiter):
+			return i + len(delimiter), true
+		case code[i] == '\n' && len(delimiter) == 1:
+			return i, false
+		}
+	}
+	return len(code), false
+}
+
+// closingParen returns the index after the parenthesis which closes the one at open or -1 if it isn't closed
+func closingParen(stripped string, open int) int {
+	depth := 0
+	for i := open; i < len(stripped); i++ {
+		switch stripped[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// literalAt returns the literal which is the only content of the code from start to end
+func literalAt(stripped string, literals []stringLiteral, start, end int) (stringLiteral, bool) {
+	for _, literal := range literals {
+		if literal.start >= start && literal.end <= end {
+			isOnly := strings.TrimSpace(stripped[start:literal.start]) == "" && strings.TrimSpace(stripped[literal.end:end]) == ""
+			return literal, isOnly
+		}
+	}
+	return stringLiteral{}, false
+}
+
+// findJavaBigQueryReads returns chains of calls started with BigQueryIO.read*() which read the table by the literal
+// passed to the from method. The whole chain is replaced, since its methods are methods of BigQueryIO.
+func findJavaBigQueryReads(stripped string, literals []stringLiteral) []bigQueryRead {
+	var reads []bigQueryRead
+	for _, match := range javaBigQueryReadRegexp.FindAllStringIndex(stripped, -1) {
+		end := closingParen(stripped, match[1]-1)
+		var table stringLiteral
+		hasTable := false
+		for end > 0 {
+			call := javaChainedCallRegexp.FindStringSubmatchIndex(stripped[end:])
+			if call == nil {
+				break
+			}
+			open := end + call[1] - 1
+			callEnd := closingParen(stripped, open)
+			if callEnd < 0 {
+				break
+			}
+			if stripped[end+call[2]:end+call[3]] == javaBigQueryTableMethod {
+				table, hasTable = literalAt(stripped, literals, open+1, callEnd-1)
+			}
+			end = callEnd
+		}
+		if hasTable {
+			reads = append(reads, bigQueryRead{start: match[0], end: end, table: table})
+		}
+	}
+	return reads
+}
+
+// findPythonBigQueryReads returns calls of ReadFromBigQuery which read the table by the literal
+// passed as the first positional argument or the table argument. The module of the call is replaced too.
+func findPythonBigQueryReads(stripped string, literals []stringLiteral) []bigQueryRead {
+	var reads []bigQueryRead
+	for _, match := range pythonBigQueryReadRegexp.FindAllStringIndex(stripped, -1) {
+		end := closingParen(stripped, match[1]-1)
+		if end < 0 {
+			continue
+		}
+		for _, literal := range literals {
+			if literal.start < match[1] || literal.end > end {
+				continue
+			}
+			before := strings.TrimSpace(stripped[match[1]:literal.start])
+			after := strings.TrimSpace(stripped[literal.end : end-1])
+			isArgument := (before == "" || pythonTableArgumentRegexp.MatchString(stripped[match[1]-1:literal.start])) &&
+				(after == "" || strings.HasPrefix(after, ","))
+			if isArgument {
+				reads = append(reads, bigQueryRead{start: pythonQualifiedNameStart(stripped, match[0]), end: end, table: literal})
+				break
+			}
+		}
+	}
+	return reads
+}
+
+// pythonQualifiedNameStart returns the start of the qualified name which ends at end, e.g. "beam.io." of ReadFromBigQuery
+func pythonQualifiedNameStart(stripped string, end int) int {
+	start := end
+	for start > 0 {
+		prefix := strings.TrimRight(stripped[:start], " \t")
+		if !strings.HasSuffix(prefix, ".") {
+			break
+		}
+		name := strings.TrimRight(prefix[:len(prefix)-1], " \t")
+		nameStart := len(name)
+		for nameStart > 0 && isJavaIdentifierPart(rune(name[nameStart-1])) {
+			nameStart--
+		}
+		if nameStart == len(name) {
+			break
+		}
+		start = nameStart
+	}
+	return start
+}
@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_substituteIo(t *testing.T) {
+	paths := map[string]string{
+		"gs://apache-beam-samples/shakespeare/kinglear.txt": "/opt/samples/kinglear.txt",
+		"gs://apache-beam-samples/nyc_trip/":                "/opt/samples/nyc_trip/",
+	}
+	tables := map[string]string{
+		"clouddataflow-readonly:samples.weather_stations": "Create.of(new TableRow().set(\"month\", 1))",
+		"bigquery-public-data:samples.shakespeare":        "beam.Create([{'word': 'king', 'word_count': 1}])",
+	}
+	tests := []struct {
+		name              string
+		fileName          string
+		code              string
+		want              string
+		wantSubstitutions []string
+	}{
+		{
+			name:              "mapped path",
+			fileName:          "Main.java",
+			code:              "p.apply(TextIO.read().from(\"gs://apache-beam-samples/shakespeare/kinglear.txt\"));\n",
+			want:              "p.apply(TextIO.read().from(\"/opt/samples/kinglear.txt\"));\n",
+			wantSubstitutions: []string{"gs://apache-beam-samples/shakespeare/kinglear.txt -> /opt/samples/kinglear.txt"},
+		},
+		{
+			// Test that paths by uris ending with "/" replace prefixes of other uris
+			name:              "mapped prefix of path",
+			fileName:          "main.py",
+			code:              "p | beam.io.ReadFromText('gs://apache-beam-samples/nyc_trip/csv/*')\n",
+			want:              "p | beam.io.ReadFromText('/opt/samples/nyc_trip/csv/*')\n",
+			wantSubstitutions: []string{"gs://apache-beam-samples/nyc_trip/csv/* -> /opt/samples/nyc_trip/csv/*"},
+		},
+		{
+			// Test that unknown inputs are kept, so the code fails with the original error
+			name:     "unmapped path",
+			fileName: "Main.java",
+			code:     "p.apply(TextIO.read().from(\"gs://apache-beam-samples/unknown.txt\"));\n",
+			want:     "p.apply(TextIO.read().from(\"gs://apache-beam-samples/unknown.txt\"));\n",
+		},
+		{
+			name:     "path built by concatenation",
+			fileName: "Main.java",
+			code:     "String input = \"gs://apache-beam-samples/nyc_trip/\" + name;\nString other = prefix + \"gs://apache-beam-samples/shakespeare/kinglear.txt\";\n",
+			want:     "String input = \"gs://apache-beam-samples/nyc_trip/\" + name;\nString other = prefix + \"gs://apache-beam-samples/shakespeare/kinglear.txt\";\n",
+		},
+		{
+			name:     "path in comments",
+			fileName: "Main.java",
+			code:     "// \"gs://apache-beam-samples/shakespeare/kinglear.txt\"\n/* \"gs://apache-beam-samples/shakespeare/kinglear.txt\" */\n",
+			want:     "// \"gs://apache-beam-samples/shakespeare/kinglear.txt\"\n/* \"gs://apache-beam-samples/shakespeare/kinglear.txt\" */\n",
+		},
+		{
+			// Test that the whole chain of the read is replaced, since its methods are methods of BigQueryIO
+			name:              "mapped Java BigQuery table",
+			fileName:          "Main.java",
+			code:              "p.apply(BigQueryIO.readTableRows()\n    .from(\"clouddataflow-readonly:samples.weather_stations\")\n    .withoutValidation())\n  .apply(ParDo.of(new ExtractFn()));\n",
+			want:              "p.apply(Create.of(new TableRow().set(\"month\", 1)))\n  .apply(ParDo.of(new ExtractFn()));\n",
+			wantSubstitutions: []string{"BigQuery table clouddataflow-readonly:samples.weather_stations -> local data"},
+		},
+		{
+			name:     "unmapped Java BigQuery table",
+			fileName: "Main.java",
+			code:     "p.apply(BigQueryIO.readTableRows().from(\"project:dataset.table\"));\n",
+			want:     "p.apply(BigQueryIO.readTableRows().from(\"project:dataset.table\"));\n",
+		},
+		{
+			name:              "mapped Python BigQuery table",
+			fileName:          "main.py",
+			code:              "p | beam.io.ReadFromBigQuery(\n    table='bigquery-public-data:samples.shakespeare', method='DIRECT_READ') | beam.Map(print)\n",
+			want:              "p | beam.Create([{'word': 'king', 'word_count': 1}]) | beam.Map(print)\n",
+			wantSubstitutions: []string{"BigQuery table bigquery-public-data:samples.shakespeare -> local data"},
+		},
+		{
+			// Test that the query isn't replaced even if it contains the mapped table
+			name:     "Python BigQuery query",
+			fileName: "main.py",
+			code:     "p | beam.io.ReadFromBigQuery(query='SELECT * FROM `bigquery-public-data.samples.shakespeare`')\n",
+			want:     "p | beam.io.ReadFromBigQuery(query='SELECT * FROM `bigquery-public-data.samples.shakespeare`')\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(filePath)
+			if filepath.Ext(tt.fileName) == javaSourceFileExtension {
+				builder.JavaPreparers().WithIoSubstitutor(paths, tables)
+			} else {
+				builder.PythonPreparers().WithIoSubstitutor(paths, tables)
+			}
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if err := preparer.Prepare(preparer.Args...); err != nil {
+					t.Fatalf("substituteIo() unexpected error = %v", err)
+				}
+			}
+			if got, _ := os.ReadFile(filePath); string(got) != tt.want {
+				t.Errorf("substituteIo() code = %q, want %q", got, tt.want)
+			}
+			if got := builder.Summary().Substitutions; !reflect.DeepEqual(got, tt.wantSubstitutions) {
+				t.Errorf("substituteIo() substitutions = %v, want %v", got, tt.wantSubstitutions)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+const (
+	unboundedLoopPattern = `\b(?:while\s*\(\s*true\s*\)|for\s*\(\s*;\s*;\s*\))\s*\{`
+	loopGuardVariable    = "playgroundLoopGuard%d"
+	// guardedLoopPattern keeps the loop on the same line, so line numbers of compilation and run errors are kept
+	guardedLoopPattern = "for (long %[1]s = 0; ; ) { if (++%[1]s > %[2]dL) throw new IllegalStateException(\"The loop exceeded %[2]d iterations and was stopped by the playground\");"
+)
+
+var unboundedLoopRegexp = regexp.MustCompile(unboundedLoopPattern)
+
+// guardLoops rewrites all "while (true) {" and "for (;;) {" loops in the file by filePath to loops which throw
+// IllegalStateException after maxIterations iterations. Loops inside comments and string literals are kept.
+// Other loops which may be infinite aren't changed, so this is a best-effort guard and not a sandbox.
+func guardLoops(args ...interface{}) error {
+	filePath := args[0].(string)
+	maxIterations := args[1].(int)
+	log := loggerFromArgs(args, 2)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+
+	matches := unboundedLoopRegexp.FindAllIndex([]byte(removeJavaCommentsAndStrings(string(code))), -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	var result bytes.Buffer
+	last := 0
+	for i, match := range matches {
+		result.Write(code[last:match[0]])
+		result.WriteString(fmt.Sprintf(guardedLoopPattern, fmt.Sprintf(loopGuardVariable, i), maxIterations))
+		last = match[1]
+	}
+	result.Write(code[last:])
+
+	if err = writeKeepingMode(filePath, result.Bytes()); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_guardLoops(t *testing.T) {
+	guard := func(variable string) string {
+		return "for (long " + variable + " = 0; ; ) { if (++" + variable + " > 5L) throw new IllegalStateException(\"The loop exceeded 5 iterations and was stopped by the playground\");"
+	}
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{
+			name: "while true",
+			code: "class Main {\n    void run() {\n        while (true) {\n            step();\n        }\n    }\n}",
+			want: "class Main {\n    void run() {\n        " + guard("playgroundLoopGuard0") + "\n            step();\n        }\n    }\n}",
+		},
+		{
+			// Test that the label of the loop is kept
+			name: "for without condition",
+			code: "class Main {\n    void run() {\n        outer: for(;;){ step(); }\n    }\n}",
+			want: "class Main {\n    void run() {\n        outer: " + guard("playgroundLoopGuard0") + " step(); }\n    }\n}",
+		},
+		{
+			// Test that each loop has its own counter
+			name: "two loops",
+			code: "while(true) {}\nfor ( ; ; ) {}",
+			want: guard("playgroundLoopGuard0") + "}\n" + guard("playgroundLoopGuard1") + "}",
+		},
+		{
+			name: "bounded loop",
+			code: "class Main {\n    void run() {\n        for (int i = 0; i < 10; i++) {\n            while (i < 5) { i++; }\n        }\n    }\n}",
+			want: "class Main {\n    void run() {\n        for (int i = 0; i < 10; i++) {\n            while (i < 5) { i++; }\n        }\n    }\n}",
+		},
+		{
+			// Test that do-while loops and loops in comments and strings are kept
+			name: "loops in comments and strings",
+			code: "// while (true) {\n/* for (;;) { */\nString s = \"while (true) {\";\ndo { step(); } while (true);",
+			want: "// while (true) {\n/* for (;;) { */\nString s = \"while (true) {\";\ndo { step(); } while (true);",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "Main.java")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			if err := guardLoops(filePath, 5); err != nil {
+				t.Fatalf("guardLoops() unexpected error = %v", err)
+			}
+			got, _ := os.ReadFile(filePath)
+			if string(got) != tt.want {
+				t.Errorf("guardLoops() code = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
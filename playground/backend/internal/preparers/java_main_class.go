@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	javaMainMethodPattern  = `\b(public\s+static|static\s+public)\s+(final\s+)?void\s+main\s*\(\s*(final\s+)?String\s*(\[\s*\]|\.\.\.)?\s*[\w$]+\s*(\[\s*\])?\s*\)`
+	javaPackageNamePattern = `(?m)^\s*package\s+([\w.]+)\s*;`
+)
+
+var (
+	javaMainMethodRegexp  = regexp.MustCompile(javaMainMethodPattern)
+	javaPackageNameRegexp = regexp.MustCompile(javaPackageNamePattern)
+)
+
+// ErrMainClassNotFound is returned if none of the Java sources declares main method in a top-level class
+var ErrMainClassNotFound = errors.New("no class with \"public static void main(String[] args)\" method found")
+
+// AmbiguousMainClassError is returned if several top-level classes of the Java sources declare main method
+type AmbiguousMainClassError struct {
+	Classes []string
+}
+
+func (e *AmbiguousMainClassError) Error() string {
+	return fmt.Sprintf("Several classes declare main method: %s, code should contain only one main method", strings.Join(e.Classes, ", "))
+}
+
+// IsMainClassError checks that err is returned by FindJavaMainClass or FindKotlinMainClass because of the user's code
+func IsMainClassError(err error) bool {
+	var ambiguous *AmbiguousMainClassError
+	return errors.Is(err, ErrMainClassNotFound) || errors.Is(err, ErrKotlinMainNotFound) || errors.As(err, &ambiguous)
+}
+
+// FindJavaMainClass scans all Java sources in sourceFolder and returns the fully qualified name
+// of the top-level class which declares main method. Methods inside comments, string literals
+// and nested classes are ignored.
+func FindJavaMainClass(sourceFolder string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(sourceFolder, "*"+javaSourceFileExtension))
+	if err != nil {
+		return "", err
+	}
+	var classes []string
+	for _, file := range files {
+		code, err := readSourceFile(file)
+		if err != nil {
+			return "", err
+		}
+		classes = append(classes, findMainClasses(string(code))...)
+	}
+	switch len(classes) {
+	case 0:
+		return "", ErrMainClassNotFound
+	case 1:
+		return classes[0], nil
+	default:
+		sort.Strings(classes)
+		return "", &AmbiguousMainClassError{Classes: classes}
+	}
+}
+
+// findMainClasses returns fully qualified names of top-level classes of code which declare main method
+func findMainClasses(code string) []string {
+	stripped := removeJavaCommentsAndStrings(code)
+	headerEnd, types := findTopLevelTypes(stripped)
+	if len(types) == 0 {
+		return nil
+	}
+	packageName := ""
+	if match := javaPackageNameRegexp.FindStringSubmatch(stripped[:headerEnd]); match != nil {
+		packageName = match[1] + "."
+	}
+
+	var classes []string
+	for _, javaType := range types {
+		body := stripped[javaType.start:javaType.end]
+		for _, match := range javaMainMethodRegexp.FindAllStringIndex(body, -1) {
+			if braceDepth(body[:match[0]]) == 1 {
+				classes = append(classes, packageName+javaType.name)
+				break
+			}
+		}
+	}
+	return classes
+}
+
+// braceDepth returns the depth of curly braces at the end of code
+func braceDepth(code string) int {
+	return strings.Count(code, "{") - strings.Count(code, "}")
+}
@@ -0,0 +1,81 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFindJavaMainClass(t *testing.T) {
+	mainClass := "package org.apache.beam.examples;\n\nclass Main {\n    public static void main(String[] args) {\n    }\n}"
+	helperClass := "package org.apache.beam.examples;\n\nclass Helper {\n    static int value() {\n        return 1;\n    }\n}"
+	innerMainClass := "class Outer {\n    // public static void main(String[] args) {}\n    static class Inner {\n        public static void main(String[] args) {\n        }\n    }\n}"
+	importedPackageClass := "import org.apache.beam.examples.*;\n\nclass Main {\n    public static void main(String... args) {\n        System.out.println(\"public static void main(String[] args)\");\n    }\n}"
+	secondMainClass := "class Other {\n    static public void main(String args[]) {\n    }\n}"
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "main in the second file of a multi-file run",
+			files: map[string]string{"Helper.java": helperClass, "Main.java": mainClass},
+			want:  "org.apache.beam.examples.Main",
+		},
+		{
+			// Test that main in an inner class or a comment is ignored
+			name:    "main in an inner class",
+			files:   map[string]string{"Outer.java": innerMainClass},
+			wantErr: ErrMainClassNotFound,
+		},
+		{
+			// Test that a class with the package changed to the import has no package
+			name:  "main with stripped package",
+			files: map[string]string{"123e4567.java": importedPackageClass},
+			want:  "Main",
+		},
+		{
+			name:    "several mains",
+			files:   map[string]string{"Main.java": mainClass, "Other.java": secondMainClass},
+			wantErr: &AmbiguousMainClassError{Classes: []string{"Other", "org.apache.beam.examples.Main"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, code := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0600); err != nil {
+					t.Fatalf("error during test setup: %s", err.Error())
+				}
+			}
+			got, err := FindJavaMainClass(dir)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("FindJavaMainClass() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FindJavaMainClass() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr != nil && !IsMainClassError(err) {
+				t.Errorf("IsMainClassError() = false, want true")
+			}
+		})
+	}
+}
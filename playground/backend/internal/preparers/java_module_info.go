@@ -0,0 +1,143 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const moduleDirectivePattern = `(?m)^[ \t]*(?:requires|exports|opens|uses|provides)\s+[A-Za-z_$][^;{}]*;`
+
+var moduleDirectiveRegexp = regexp.MustCompile(moduleDirectivePattern)
+
+// ModuleInfoWarning is the message of the warning which is added by the module info stripper
+const ModuleInfoWarning = "the code contains module-info.java declarations at lines %s which are removed, " +
+	"since JPMS modules aren't supported by the playground. The code is run without the module"
+
+//WithModuleInfoStripper adds preparer to remove the module declaration and module directives from the code with classes
+func (builder *JavaPreparersBuilder) WithModuleInfoStripper() *JavaPreparersBuilder {
+	moduleInfoStripper := Preparer{
+		Name:    ModuleInfoStripperName,
+		Prepare: stripModuleInfo,
+		Args:    []interface{}{builder.filePath, builder.logger},
+		Pattern: moduleDeclarationPattern,
+	}
+	builder.AddPreparer(moduleInfoStripper)
+	return builder
+}
+
+// DeclaresJavaModule checks that code contains the module declaration or module directives like requires and exports.
+// Declarations inside comments and string literals are ignored.
+func DeclaresJavaModule(code string) bool {
+	return len(findModuleInfo(removeJavaCommentsAndStrings(code))) != 0
+}
+
+// stripModuleInfo processes file by filePath and removes the module declaration and module directives,
+// so classes of the code are run without the module. Lines are kept empty so line numbers of compilation errors
+// match the original code. The code without classes is kept as it is to be rejected by the module info rejector.
+// Returns Warning with lines of removed declarations if the code is changed.
+func stripModuleInfo(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	stripped := removeJavaCommentsAndStrings(string(code))
+	declarations := findModuleInfo(stripped)
+	if len(declarations) == 0 {
+		return nil
+	}
+	if _, types := findTopLevelTypes(blankCode(stripped, declarations)); len(types) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(declarations))
+	for _, declaration := range declarations {
+		lines = append(lines, strconv.Itoa(strings.Count(stripped[:declaration.start], "\n")+1))
+	}
+	if err = writeKeepingMode(filePath, []byte(removeCode(string(code), declarations))); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	message := fmt.Sprintf(ModuleInfoWarning, strings.Join(lines, ", "))
+	log.Warnf("Preparation: %s\n", message)
+	return &Warning{Message: message}
+}
+
+// findModuleInfo returns module declarations and module directives of code without comments and string literals.
+// Directives outside the module declaration are searched only before the first type or block of the code,
+// since the same words may be names in the code of types.
+func findModuleInfo(code string) []codeEdit {
+	var declarations []codeEdit
+	for _, match := range moduleDeclarationRegexp.FindAllStringIndex(code, -1) {
+		declaration := strings.TrimLeft(code[match[0]:match[1]], " \t\r\n")
+		start := strings.LastIndexByte(code[:match[1]-len(declaration)], '\n') + 1
+		end := len(code)
+		if closing := strings.IndexByte(code[match[1]:], '}'); closing >= 0 {
+			end = match[1] + closing + 1
+		}
+		declarations = append(declarations, codeEdit{start: start, end: end})
+	}
+
+	withoutModule := blankCode(code, declarations)
+	header := len(withoutModule)
+	if block := strings.IndexByte(withoutModule, '{'); block >= 0 {
+		header = block
+	}
+	if match := topLevelTypeRegexp.FindStringIndex(withoutModule[:header]); match != nil {
+		header = match[0]
+	}
+	for _, match := range moduleDirectiveRegexp.FindAllStringIndex(withoutModule[:header], -1) {
+		declarations = append(declarations, codeEdit{start: match[0], end: match[1]})
+	}
+	return declarations
+}
+
+// blankCode replaces code between start and end of each edit with spaces except line breaks, so offsets are kept
+func blankCode(code string, edits []codeEdit) string {
+	blanked := []byte(code)
+	for _, edit := range edits {
+		for i := edit.start; i < edit.end; i++ {
+			if blanked[i] != '\n' {
+				blanked[i] = ' '
+			}
+		}
+	}
+	return string(blanked)
+}
+
+// removeCode removes code between start and end of each edit except line breaks, so lines of the other code are kept
+func removeCode(code string, edits []codeEdit) string {
+	removed := make([]bool, len(code))
+	for _, edit := range edits {
+		for i := edit.start; i < edit.end; i++ {
+			removed[i] = code[i] != '\n'
+		}
+	}
+	var result strings.Builder
+	for i := 0; i < len(code); i++ {
+		if !removed[i] {
+			result.WriteByte(code[i])
+		}
+	}
+	return result.String()
+}
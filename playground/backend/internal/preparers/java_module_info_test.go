@@ -0,0 +1,115 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDeclaresJavaModule(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{
+			name: "module descriptor",
+			code: "open module com.example.app {\n    requires java.sql;\n}\n",
+			want: true,
+		},
+		{
+			name: "module directives before class",
+			code: "requires java.sql;\n\npublic class Main {\n}\n",
+			want: true,
+		},
+		{
+			name: "module in comments and strings",
+			code: "// module com.example.app {\n/* requires java.sql; */\nclass Main {\n    String module = \"module app {\";\n}\n",
+			want: false,
+		},
+		{
+			// Test that names of the code which match directives aren't declarations of the module
+			name: "directives inside class",
+			code: "class Main {\n    static String requires;\n    void exports() {\n        requires = \"\";\n    }\n}\n",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeclaresJavaModule(tt.code); got != tt.want {
+				t.Errorf("DeclaresJavaModule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_stripModuleInfo(t *testing.T) {
+	tests := []struct {
+		name         string
+		code         string
+		want         string
+		wantWarnings []string
+	}{
+		{
+			name:         "module before class",
+			code:         "module app {\n    requires java.sql;\n}\n\nclass Main {\n}\n",
+			want:         "\n\n\n\nclass Main {\n}\n",
+			wantWarnings: []string{fmt.Sprintf(ModuleInfoWarning, "1")},
+		},
+		{
+			name:         "directives with imports",
+			code:         "import java.util.List;\n    requires java.sql;\nexports app;\nclass Main {\n}\n",
+			want:         "import java.util.List;\n\n\nclass Main {\n}\n",
+			wantWarnings: []string{fmt.Sprintf(ModuleInfoWarning, "2, 3")},
+		},
+		{
+			// Test that the module descriptor without classes is kept to be rejected by the module info rejector
+			name: "module descriptor",
+			code: "module app {\n    exports app;\n}\n",
+			want: "module app {\n    exports app;\n}\n",
+		},
+		{
+			name: "module in comment",
+			code: "/* module app { } */\nclass Main {\n}\n",
+			want: "/* module app { } */\nclass Main {\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "Main.java")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(filePath)
+			builder.JavaPreparers().WithModuleInfoStripper()
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if err := preparer.Prepare(preparer.Args...); err != nil {
+					t.Fatalf("stripModuleInfo() unexpected error = %v", err)
+				}
+			}
+			if got := builder.Summary().Warnings; !reflect.DeepEqual(got, tt.wantWarnings) {
+				t.Errorf("stripModuleInfo() warnings = %v, want %v", got, tt.wantWarnings)
+			}
+			if got, _ := os.ReadFile(filePath); string(got) != tt.want {
+				t.Errorf("stripModuleInfo() code = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
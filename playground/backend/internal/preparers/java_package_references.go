@@ -0,0 +1,477 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	importStatementPattern     = `(?m)^[ \t]*import\s+(static\s+)?([\w$.]+(?:\.\*)?)\s*;[ \t]*\r?\n?`
+	staticMemberPattern        = `\bstatic\b[^;{}=()]*?([A-Za-z_$][\w$]*)\s*[(=;]`
+	multipleDeclarationPattern = `^\s*(?:@[\w$.]+\s+)*(?:(?:final|static|private|protected|public|transient|volatile)\s+)*[A-Za-z_$][\w$.]*(?:\s*<[\w$.,?<>\s\[\]]*>)?(?:\s*\[\s*\])*\s+[A-Za-z_$][\w$]*[^;]*,\s*$`
+	lambdaParametersPattern    = `^[\w$,\s]*$`
+	typeKeywordPattern         = `\b(?:class|interface|enum|record)\b`
+	wildcardImport             = "*"
+)
+
+var (
+	importStatementRegexp     = regexp.MustCompile(importStatementPattern)
+	staticMemberRegexp        = regexp.MustCompile(staticMemberPattern)
+	multipleDeclarationRegexp = regexp.MustCompile(multipleDeclarationPattern)
+	lambdaParametersRegexp    = regexp.MustCompile(lambdaParametersPattern)
+	typeKeywordRegexp         = regexp.MustCompile(typeKeywordPattern)
+	// javaExpressionKeywords are keywords which can be followed by a name which isn't declared there
+	javaExpressionKeywords = map[string]bool{
+		"return": true, "new": true, "throw": true, "case": true, "else": true, "do": true, "yield": true, "assert": true,
+	}
+)
+
+// javaPackage keeps the name of the package which is declared in the code until it is changed by the package changer
+type javaPackage struct {
+	name string
+}
+
+// codeEdit replaces code between start and end with text
+type codeEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// rewritePackageReferences processes file by filePath and removes references to the package changed by the package
+// changer to classes which are declared in the file or in other Java files next to it. Once the package declaration is
+// changed to the import these classes belong to the unnamed package, so such references can't be compiled.
+func rewritePackageReferences(args ...interface{}) error {
+	filePath := args[0].(string)
+	declaredPackage := args[1].(*javaPackage)
+	log := loggerFromArgs(args, 2)
+
+	if declaredPackage.name == "" {
+		return nil
+	}
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	siblings, err := siblingJavaTypes(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during read files next to: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	rewritten := rewriteReferences(string(code), declaredPackage.name, siblings)
+	if rewritten == string(code) {
+		return nil
+	}
+	if err = writeKeepingMode(filePath, []byte(rewritten)); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// siblingJavaTypes returns code without comments and strings of top-level types of Java files
+// in the folder of the file by filePath except the file itself by names of types
+func siblingJavaTypes(filePath string) (map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(filePath), "*"+javaSourceFileExtension))
+	if err != nil {
+		return nil, err
+	}
+	types := map[string]string{}
+	for _, file := range files {
+		if filepath.Base(file) == filepath.Base(filePath) {
+			continue
+		}
+		code, err := readSourceFile(file)
+		if err != nil {
+			return nil, err
+		}
+		stripped := removeJavaCommentsAndStrings(string(code))
+		_, fileTypes := findTopLevelTypes(stripped)
+		for _, javaType := range fileTypes {
+			types[javaType.name] = stripped[javaType.start:javaType.end]
+		}
+	}
+	return types, nil
+}
+
+// rewriteReferences removes references to the package by packageName to the local types, i.e. types declared
+// in the code or in siblings. Imports of local types are dropped and static imports of their members are replaced
+// with members qualified by the type name. Fully qualified names of local types in the code are replaced with
+// simple names. Comments and string literals aren't changed.
+func rewriteReferences(code, packageName string, siblings map[string]string) string {
+	stripped := removeJavaCommentsAndStrings(code)
+	headerEnd, ownTypes := findTopLevelTypes(stripped)
+
+	localTypes := map[string]string{}
+	for name, typeCode := range siblings {
+		localTypes[name] = typeCode
+	}
+	for _, javaType := range ownTypes {
+		localTypes[javaType.name] = stripped[javaType.start:javaType.end]
+	}
+
+	var edits []codeEdit
+	// members of static imports which are replaced with qualified names by names of their types
+	members := map[string]string{}
+	for _, statement := range importStatementRegexp.FindAllStringSubmatchIndex(stripped, -1) {
+		if statement[0] >= headerEnd {
+			break
+		}
+		name := stripped[statement[4]:statement[5]]
+		if !strings.HasPrefix(name, packageName+".") {
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(name, packageName+"."), ".")
+		typeCode, isLocal := localTypes[parts[0]]
+		isStatic := statement[2] >= 0
+		switch {
+		case !isLocal:
+			continue
+		case !isStatic && len(parts) == 1:
+		case isStatic && len(parts) == 2 && parts[1] == wildcardImport:
+			for _, member := range staticMembers(typeCode) {
+				members[member] = parts[0]
+			}
+		case isStatic && len(parts) == 2:
+			members[parts[1]] = parts[0]
+		default:
+			continue
+		}
+		edits = append(edits, codeEdit{start: statement[0], end: statement[1]})
+	}
+
+	edits = append(edits, qualifiedReferenceEdits(stripped, headerEnd, packageName, localTypes)...)
+	edits = append(edits, staticMemberEdits(stripped, headerEnd, members, ownTypes)...)
+	return applyEdits(code, edits)
+}
+
+// qualifiedReferenceEdits returns edits which remove the package name from fully qualified names of local types
+// after the header of the stripped code
+func qualifiedReferenceEdits(stripped string, headerEnd int, packageName string, localTypes map[string]string) []codeEdit {
+	var edits []codeEdit
+	prefix := packageName + "."
+	for offset := headerEnd; ; {
+		index := strings.Index(stripped[offset:], prefix)
+		if index < 0 {
+			return edits
+		}
+		start := offset + index
+		offset = start + len(prefix)
+		if start > 0 && isJavaNamePart(rune(stripped[start-1])) {
+			continue
+		}
+		end := offset
+		for end < len(stripped) && isJavaIdentifierPart(rune(stripped[end])) {
+			end++
+		}
+		if _, isLocal := localTypes[stripped[offset:end]]; isLocal {
+			edits = append(edits, codeEdit{start: start, end: offset})
+		}
+	}
+}
+
+// staticMemberEdits returns edits which qualify unqualified references to statically imported members
+// after the header of the stripped code by names of their types.
+// References inside the types which declare the members are kept as is. Declarations with names of members aren't
+// references, and they shadow members the same way as in Java: fields and methods declared in the code shadow
+// members everywhere, parameters and local variables shadow members until the end of their scopes.
+// Variables shadow only fields and methods shadow only methods.
+func staticMemberEdits(stripped string, headerEnd int, members map[string]string, ownTypes []javaType) []codeEdit {
+	var edits []codeEdit
+	for member, typeName := range members {
+		var references []memberReference
+		// declaredInCode keeps whether the code declares a method or a field with the member name by isCall
+		declaredInCode := map[bool]bool{}
+		var localScopes []codeEdit
+		for offset := headerEnd; ; {
+			index := strings.Index(stripped[offset:], member)
+			if index < 0 {
+				break
+			}
+			start := offset + index
+			offset = start + len(member)
+			if start > 0 && isJavaIdentifierPart(rune(stripped[start-1])) {
+				continue
+			}
+			if offset < len(stripped) && isJavaIdentifierPart(rune(stripped[offset])) {
+				continue
+			}
+			if previous := strings.TrimRight(stripped[:start], " \t\r\n"); strings.HasSuffix(previous, ".") || strings.HasSuffix(previous, ":") {
+				continue
+			}
+			if isInsideType(start, typeName, ownTypes) {
+				continue
+			}
+			isCall := strings.HasPrefix(stripped[nextNonSpace(stripped, offset):], "(")
+			if !isDeclaration(stripped, start, offset) {
+				references = append(references, memberReference{offset: start, isCall: isCall})
+				continue
+			}
+			if scopeEnd, isLocal := declarationScope(stripped, start); isLocal {
+				localScopes = append(localScopes, codeEdit{start: start, end: scopeEnd})
+			} else {
+				declaredInCode[isCall] = true
+			}
+		}
+		for _, reference := range references {
+			if declaredInCode[reference.isCall] || !reference.isCall && isInsideScopes(reference.offset, localScopes) {
+				continue
+			}
+			edits = append(edits, codeEdit{start: reference.offset, end: reference.offset, text: typeName + "."})
+		}
+	}
+	return edits
+}
+
+// memberReference is an unqualified reference to the statically imported member
+type memberReference struct {
+	offset int
+	// isCall is true if the reference is a method call, otherwise it is a reference to a field
+	isCall bool
+}
+
+// isDeclaration checks that the identifier between start and end of the stripped code is a name which is declared
+// there, i.e. a name of a field, a method, a parameter or a local variable
+func isDeclaration(stripped string, start, end int) bool {
+	if isLambdaParameter(stripped, start, end) {
+		return true
+	}
+	previous := previousNonSpace(stripped, start)
+	if previous < 0 {
+		return false
+	}
+	switch symbol := stripped[previous]; {
+	case isJavaIdentifierPart(rune(symbol)):
+		wordStart := previous
+		for wordStart > 0 && isJavaIdentifierPart(rune(stripped[wordStart-1])) {
+			wordStart--
+		}
+		// the name follows its type, e.g. "String name" or "void name("
+		return !javaExpressionKeywords[stripped[wordStart:previous+1]]
+	case symbol == ']':
+		// the name follows the array type, e.g. "String[] name"
+		beforeBracket := previousNonSpace(stripped, previous)
+		return beforeBracket >= 0 && stripped[beforeBracket] == '['
+	case symbol == '>':
+		// the name follows the generic type, e.g. "List<String> name"
+		return closesTypeArguments(stripped, previous)
+	case symbol == ',':
+		// the name is one of several variables of the declaration, e.g. "int first, name;"
+		opener := enclosingOpener(stripped, start)
+		if opener >= 0 && stripped[opener] != '{' {
+			return false
+		}
+		statementStart := strings.LastIndexAny(stripped[:start], ";{}") + 1
+		return multipleDeclarationRegexp.MatchString(stripped[statementStart:start])
+	}
+	return false
+}
+
+// isLambdaParameter checks that the identifier between start and end of the stripped code is a parameter of a lambda
+// without types, e.g. "name -> ..." or "(first, name) -> ..."
+func isLambdaParameter(stripped string, start, end int) bool {
+	if strings.HasPrefix(stripped[nextNonSpace(stripped, end):], "->") {
+		return true
+	}
+	opener := enclosingOpener(stripped, start)
+	if opener < 0 || stripped[opener] != '(' {
+		return false
+	}
+	closer := closingParen(stripped, opener)
+	if closer < 0 || !strings.HasPrefix(stripped[nextNonSpace(stripped, closer):], "->") {
+		return false
+	}
+	return lambdaParametersRegexp.MatchString(stripped[opener+1 : closer-1])
+}
+
+// closesTypeArguments checks that the angle bracket at the index of the stripped code closes type arguments,
+// e.g. "List<Map<String, Integer>>", and isn't a comparison
+func closesTypeArguments(stripped string, index int) bool {
+	depth := 0
+	for i := index; i >= 0; i-- {
+		switch symbol := stripped[i]; {
+		case symbol == '>':
+			depth++
+		case symbol == '<':
+			depth--
+			if depth == 0 {
+				previous := previousNonSpace(stripped, i)
+				return previous >= 0 && isJavaIdentifierPart(rune(stripped[previous]))
+			}
+		case !isJavaNamePart(rune(symbol)) && !strings.ContainsRune(" \t\r\n,?[]", rune(symbol)):
+			return false
+		}
+	}
+	return false
+}
+
+// declarationScope returns the end of the scope of the name declared at start of the stripped code and true if
+// the name is a parameter or a local variable. Returns false if the name is a member of the type.
+// Parameters are visible in the block after their parentheses, e.g. the body of the method or the catch block,
+// otherwise names are visible until the end of the block which declares them.
+func declarationScope(stripped string, start int) (int, bool) {
+	opener := enclosingOpener(stripped, start)
+	if opener >= 0 && stripped[opener] == '(' {
+		closer := closingParen(stripped, opener)
+		if closer < 0 {
+			return len(stripped), true
+		}
+		if body := nextNonSpace(stripped, closer); strings.HasPrefix(stripped[body:], "->") {
+			if body = nextNonSpace(stripped, body+len("->")); body < len(stripped) && stripped[body] == '{' {
+				return closingBrace(stripped, body), true
+			}
+		} else if body = strings.IndexAny(stripped[closer:], "{;"); body >= 0 && stripped[closer+body] == '{' {
+			return closingBrace(stripped, closer+body), true
+		}
+		for opener >= 0 && stripped[opener] != '{' {
+			opener = enclosingOpener(stripped, opener)
+		}
+	} else if opener >= 0 && isTypeBody(stripped, opener) {
+		return 0, false
+	}
+	if opener < 0 {
+		return len(stripped), true
+	}
+	return closingBrace(stripped, opener), true
+}
+
+// isTypeBody checks that the brace at the index of the stripped code opens the body of a class, an interface,
+// an enum or a record
+func isTypeBody(stripped string, index int) bool {
+	headerStart := strings.LastIndexAny(stripped[:index], ";{}") + 1
+	return typeKeywordRegexp.MatchString(stripped[headerStart:index])
+}
+
+// enclosingOpener returns the index of the innermost parenthesis, brace or bracket of the stripped code
+// which isn't closed before the index or -1 if there is no such symbol
+func enclosingOpener(stripped string, index int) int {
+	depth := 0
+	for i := index - 1; i >= 0; i-- {
+		switch stripped[i] {
+		case ')', '}', ']':
+			depth++
+		case '(', '{', '[':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// closingBrace returns the index after the brace which closes the one at open or the length of the stripped code
+// if it isn't closed
+func closingBrace(stripped string, open int) int {
+	depth := 0
+	for i := open; i < len(stripped); i++ {
+		switch stripped[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(stripped)
+}
+
+// isInsideScopes checks that offset is inside one of scopes
+func isInsideScopes(offset int, scopes []codeEdit) bool {
+	for _, scope := range scopes {
+		if offset >= scope.start && offset < scope.end {
+			return true
+		}
+	}
+	return false
+}
+
+// nextNonSpace returns the index of the first symbol of the code from the index which isn't a whitespace
+func nextNonSpace(code string, index int) int {
+	for index < len(code) && strings.ContainsRune(" \t\r\n", rune(code[index])) {
+		index++
+	}
+	return index
+}
+
+// previousNonSpace returns the index of the last symbol of the code before the index which isn't a whitespace
+// or -1 if there is no such symbol
+func previousNonSpace(code string, index int) int {
+	index--
+	for index >= 0 && strings.ContainsRune(" \t\r\n", rune(code[index])) {
+		index--
+	}
+	return index
+}
+
+// staticMembers returns names of static fields and methods which are declared directly in the stripped code of the type
+func staticMembers(typeCode string) []string {
+	var members []string
+	depth := 0
+	depths := make([]int, len(typeCode))
+	for i := 0; i < len(typeCode); i++ {
+		depths[i] = depth
+		switch typeCode[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+	}
+	for _, match := range staticMemberRegexp.FindAllStringSubmatchIndex(typeCode, -1) {
+		if depths[match[0]] == 1 {
+			members = append(members, typeCode[match[2]:match[3]])
+		}
+	}
+	return members
+}
+
+// isInsideType checks that offset is inside one of types with received name
+func isInsideType(offset int, name string, types []javaType) bool {
+	for _, javaType := range types {
+		if javaType.name == name && offset >= javaType.start && offset < javaType.end {
+			return true
+		}
+	}
+	return false
+}
+
+// applyEdits applies non-overlapping edits to the code
+func applyEdits(code string, edits []codeEdit) string {
+	if len(edits) == 0 {
+		return code
+	}
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+	var builder strings.Builder
+	last := 0
+	for _, edit := range edits {
+		builder.WriteString(code[last:edit.start])
+		builder.WriteString(edit.text)
+		last = edit.end
+	}
+	builder.WriteString(code[last:])
+	return builder.String()
+}
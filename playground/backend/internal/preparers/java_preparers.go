@@ -17,27 +17,106 @@ package preparers
 
 import (
 	"beam.apache.org/playground/backend/internal/logger"
-	"bufio"
+	"bytes"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 const (
 	classWithPublicModifierPattern    = "public class "
 	classWithoutPublicModifierPattern = "class "
 	packagePattern                    = `^(package) (([\w]+\.)+[\w]+);`
+	packageDeclarationPattern         = `^package\s+([^\s;]*)\s*;`
+	packageNamePattern                = `^([\w]+\.)+[\w]+$`
 	importStringPattern               = `import $2.*;`
 	newLinePattern                    = "\n"
-	pathSeparatorPattern              = os.PathSeparator
 	tmpFileSuffix                     = "tmp"
 	publicClassNamePattern            = "public class (.*?) [{|implements(.*)]"
+	unseededRandomPattern             = `\bnew\s+((?:java\.util\.)?Random)\s*\(\s*\)`
+	seededRandomPattern               = "new %s(%dL)"
+	javaRandomSeed                    = 42
+	javaFileMode                      = 0600
+	topLevelTypePattern               = `\b(class|interface|enum|record)\s+([A-Za-z_$][\w$]*)`
+	headerStatementPattern            = `(?m)^\s*(package|import)\s[^;]*;`
+	moduleDeclarationPattern          = `(?m)^\s*(?:@[\w.]+(?:\([^)]*\))?\s+)*(?:open\s+)?module\s+([A-Za-z_$][\w$]*(?:\s*\.\s*[A-Za-z_$][\w$]*)*)\s*\{`
+	headerKeywordPattern              = `^\s*(package|import|((public|final|abstract)\s+)*(class|interface|enum|record))\s`
+	publicModifierPattern             = `\bpublic\b`
+	testAnnotationPattern             = `@(?:org\.junit\.(?:jupiter\.api\.)?)?Test\b`
+	mainMethodBodyPattern             = `\bstatic\s+void\s+main\s*\([^)]*\)[^{;]*\{`
+	outputManagementPattern           = `\bSystem\s*\.\s*(setOut|setErr|out\s*\.\s*flush|err\s*\.\s*flush)\s*\(`
+	outputCaptureSetup                = "\n        try {"
+	outputCaptureTeardown             = "        } finally {\n            System.out.flush();\n            System.err.flush();\n        }\n    "
+	javaSourceFileExtension           = ".java"
+	classFileNamePattern              = `^[\p{L}_$][\p{L}\p{N}_$]*$`
+	fragmentMainSetup                 = "class Fragment {\n    public static void main(String[] args) throws Exception {\n"
+	fragmentMainTeardown              = "    }\n}\n"
+	javaLangPackage                   = "java.lang"
 )
 
+// Patterns are compiled once since preparers are applied for each run
+var (
+	packageDeclarationRegexp = regexp.MustCompile(packageDeclarationPattern)
+	packageNameRegexp        = regexp.MustCompile(packageNamePattern)
+	publicClassNameRegexp    = regexp.MustCompile(publicClassNamePattern)
+	unseededRandomRegexp     = regexp.MustCompile(unseededRandomPattern)
+	outputManagementRegexp   = regexp.MustCompile(outputManagementPattern)
+	mainMethodBodyRegexp     = regexp.MustCompile(mainMethodBodyPattern)
+	topLevelTypeRegexp       = regexp.MustCompile(topLevelTypePattern)
+	headerStatementRegexp    = regexp.MustCompile(headerStatementPattern)
+	headerKeywordRegexp      = regexp.MustCompile(headerKeywordPattern)
+	moduleDeclarationRegexp  = regexp.MustCompile(moduleDeclarationPattern)
+	publicModifierRegexp     = regexp.MustCompile(publicModifierPattern)
+	testAnnotationRegexp     = regexp.MustCompile(testAnnotationPattern)
+	classFileNameRegexp      = regexp.MustCompile(classFileNamePattern)
+)
+
+// Default markers of the fragment which is run by the fragment wrapper
+const (
+	DefaultFragmentStartMarker = "// BEGIN"
+	DefaultFragmentEndMarker   = "// END"
+)
+
+// fragmentMarkerPlaceholder replaces lines with fragment markers to find them outside of comments and strings
+const fragmentMarkerPlaceholder = "fragmentMarker"
+
+// DefaultLoopGuardMaxIterations is the number of iterations of an unbounded loop after which the loop guard stops it
+const DefaultLoopGuardMaxIterations = 10000000
+
+// DefaultForbiddenApis are APIs which the forbidden API guard of the code processing rejects:
+// internal APIs of the JDK which bypass its access checks
+var DefaultForbiddenApis = []string{
+	"sun.misc.Unsafe",
+	"sun.reflect.",
+	"jdk.internal.",
+}
+
+// BuildDirectivePrefixes are prefixes of lines with dependency declarations of jbang and Groovy Grape
+// which are removed by the build directive stripper
+var BuildDirectivePrefixes = []string{
+	"///usr/bin/env jbang",
+	"//DEPS ",
+	"//REPOS ",
+	"//JAVA ",
+	"//SOURCES ",
+	"//FILES ",
+	"//JAVAC_OPTIONS ",
+	"//JAVA_OPTIONS ",
+	"//COMPILE_OPTIONS ",
+	"//RUNTIME_OPTIONS ",
+	"@Grab(",
+	"@Grapes(",
+	"@GrabResolver(",
+	"@GrabConfig(",
+	"@GrabExclude(",
+}
+
 //JavaPreparersBuilder facet of PreparersBuilder
 type JavaPreparersBuilder struct {
 	PreparersBuilder
@@ -48,62 +127,274 @@ func (builder *PreparersBuilder) JavaPreparers() *JavaPreparersBuilder {
 	return &JavaPreparersBuilder{*builder}
 }
 
+//WithBuildDirectiveStripper adds preparer to remove lines which start with one of prefixes
+func (builder *JavaPreparersBuilder) WithBuildDirectiveStripper(prefixes []string) *JavaPreparersBuilder {
+	buildDirectiveStripper := Preparer{
+		Name:        BuildDirectiveStripperName,
+		Prepare:     stripBuildDirectives,
+		Args:        []interface{}{builder.filePath, prefixes, builder.logger},
+		Transform:   stripPrefixedLinesTransform(prefixes),
+		Description: "removed build tool directives",
+	}
+	builder.AddPreparer(buildDirectiveStripper)
+	return builder
+}
+
+//WithUnicodeEscapeDecoder adds preparer to decode unicode escapes in package, import and class declarations
+func (builder *JavaPreparersBuilder) WithUnicodeEscapeDecoder() *JavaPreparersBuilder {
+	unicodeEscapeDecoder := Preparer{
+		Name:        UnicodeEscapeDecoderName,
+		Prepare:     decodeUnicodeEscapes,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Transform:   decodeUnicodeEscapesTransform,
+		Description: "decoded unicode escapes in declarations",
+	}
+	builder.AddPreparer(unicodeEscapeDecoder)
+	return builder
+}
+
 //WithPublicClassRemover adds preparer to remove public class
 func (builder *JavaPreparersBuilder) WithPublicClassRemover() *JavaPreparersBuilder {
 	removePublicClassPreparer := Preparer{
-		Prepare: removePublicClassModifier,
-		Args:    []interface{}{builder.filePath, classWithPublicModifierPattern, classWithoutPublicModifierPattern},
+		Name:        PublicClassRemoverName,
+		Prepare:     removePublicClassModifier,
+		Args:        []interface{}{builder.filePath, classWithPublicModifierPattern, classWithoutPublicModifierPattern, builder.logger},
+		Transform:   replaceTransform(classWithPublicModifierPattern, classWithoutPublicModifierPattern),
+		Description: "removed the public modifier of classes",
+		Pattern:     classWithPublicModifierPattern,
 	}
 	builder.AddPreparer(removePublicClassPreparer)
 	return builder
 }
 
-//WithPackageChanger adds preparer to change package
+//WithPackageChanger adds preparers to change the package to the import and to remove references to the package
+//of classes which are located next to the code, since these classes can't be referred by the package after the change
 func (builder *JavaPreparersBuilder) WithPackageChanger() *JavaPreparersBuilder {
+	declaredPackage := &javaPackage{}
 	changePackagePreparer := Preparer{
-		Prepare: replace,
-		Args:    []interface{}{builder.filePath, packagePattern, importStringPattern},
+		Name:        PackageChangerName,
+		Prepare:     changePackage,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Transform:   changePackageTransform(declaredPackage),
+		Description: "replaced the package declaration with the import of the package",
+		Pattern:     packageDeclarationPattern,
 	}
 	builder.AddPreparer(changePackagePreparer)
+	packageReferenceRewriter := Preparer{
+		Name:        PackageChangerName,
+		Prepare:     rewritePackageReferences,
+		Args:        []interface{}{builder.filePath, declaredPackage, builder.logger},
+		Description: "removed references to the package of classes next to the code",
+	}
+	builder.AddPreparer(packageReferenceRewriter)
 	return builder
 }
 
-//WithPackageRemover adds preparer to remove package
+//WithPackageRemover adds preparer to remove package.
+//The line of the package declaration is deleted, so the code starts the same way as the code without the package.
 func (builder *JavaPreparersBuilder) WithPackageRemover() *JavaPreparersBuilder {
 	removePackagePreparer := Preparer{
-		Prepare: replace,
-		Args:    []interface{}{builder.filePath, packagePattern, newLinePattern},
+		Name:        PackageRemoverName,
+		Prepare:     transformFile,
+		Args:        []interface{}{builder.filePath, []LineTransform{removePackageTransform}, builder.logger},
+		Transform:   removePackageTransform,
+		Description: "removed the package declaration",
+		Pattern:     packagePattern,
 	}
 	builder.AddPreparer(removePackagePreparer)
 	return builder
 }
 
+//WithForbiddenApiGuard adds preparer to check that code doesn't use forbidden APIs
+func (builder *JavaPreparersBuilder) WithForbiddenApiGuard(apis []string) *JavaPreparersBuilder {
+	forbiddenApiGuard := Preparer{
+		Name:    ForbiddenApiGuardName,
+		Prepare: checkForbiddenApis,
+		Args:    []interface{}{builder.filePath, apis, builder.logger},
+	}
+	builder.AddPreparer(forbiddenApiGuard)
+	return builder
+}
+
+//WithModuleInfoRejector adds preparer to reject module descriptors which can't be run as a single file
+func (builder *JavaPreparersBuilder) WithModuleInfoRejector() *JavaPreparersBuilder {
+	moduleInfoRejector := Preparer{
+		Name:    ModuleInfoRejectorName,
+		Prepare: rejectModuleInfo,
+		Args:    []interface{}{builder.filePath, builder.logger},
+		Pattern: moduleDeclarationPattern,
+	}
+	builder.AddPreparer(moduleInfoRejector)
+	return builder
+}
+
+//WithFragmentWrapper adds preparer to run only the code between lines which consist of startMarker and endMarker
+func (builder *JavaPreparersBuilder) WithFragmentWrapper(startMarker, endMarker string) *JavaPreparersBuilder {
+	fragmentWrapper := Preparer{
+		Name:        FragmentWrapperName,
+		Prepare:     wrapFragment,
+		Args:        []interface{}{builder.filePath, startMarker, endMarker, builder.logger},
+		Description: "wrapped the marked fragment into the main method",
+	}
+	builder.AddPreparer(fragmentWrapper)
+	return builder
+}
+
+//WithSeedInjector adds preparer to create all java.util.Random instances with the fixed seed
+func (builder *JavaPreparersBuilder) WithSeedInjector() *JavaPreparersBuilder {
+	seedInjector := Preparer{
+		Name:        SeedInjectorName,
+		Prepare:     injectRandomSeed,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Description: "set the fixed seed of java.util.Random",
+		Pattern:     unseededRandomPattern,
+	}
+	builder.AddPreparer(seedInjector)
+	return builder
+}
+
+//WithLoopGuard adds preparer to stop while(true) and for(;;) loops after maxIterations iterations
+func (builder *JavaPreparersBuilder) WithLoopGuard(maxIterations int) *JavaPreparersBuilder {
+	loopGuard := Preparer{
+		Name:        LoopGuardName,
+		Prepare:     guardLoops,
+		Args:        []interface{}{builder.filePath, maxIterations, builder.logger},
+		Description: fmt.Sprintf("limited unbounded loops to %d iterations", maxIterations),
+		Pattern:     unboundedLoopPattern,
+	}
+	builder.AddPreparer(loopGuard)
+	return builder
+}
+
+//WithClassSplitter adds preparer to write each top-level type into its own file
+func (builder *JavaPreparersBuilder) WithClassSplitter() *JavaPreparersBuilder {
+	classSplitter := Preparer{
+		Name:        ClassSplitterName,
+		Prepare:     splitClasses,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Description: "moved each top-level type to its own file",
+	}
+	builder.AddPreparer(classSplitter)
+	return builder
+}
+
+//WithOutputCapture adds preparer to flush standard streams at the end of main method
+func (builder *JavaPreparersBuilder) WithOutputCapture() *JavaPreparersBuilder {
+	outputCapture := Preparer{
+		Name:        OutputCaptureName,
+		Prepare:     captureOutput,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Description: "flushed standard output at the end of main method",
+		Pattern:     mainMethodBodyPattern,
+	}
+	builder.AddPreparer(outputCapture)
+	return builder
+}
+
+//WithTestClassChecker adds preparer to check that the test class is the only public class
+func (builder *JavaPreparersBuilder) WithTestClassChecker() *JavaPreparersBuilder {
+	testClassChecker := Preparer{
+		Name:    TestClassCheckerName,
+		Prepare: checkTestClass,
+		Args:    []interface{}{builder.filePath, builder.logger},
+		Pattern: publicModifierPattern,
+	}
+	builder.AddPreparer(testClassChecker)
+	return builder
+}
+
 //WithFileNameChanger adds preparer to remove package
 func (builder *JavaPreparersBuilder) WithFileNameChanger() *JavaPreparersBuilder {
 	unitTestFileNameChanger := Preparer{
-		Prepare: changeJavaTestFileName,
-		Args:    []interface{}{builder.filePath},
+		Name:        FileNameChangerName,
+		Prepare:     changeJavaTestFileName,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Description: "renamed the file after the public class",
 	}
 	builder.AddPreparer(unitTestFileNameChanger)
 	return builder
 }
 
+//WithFileNameReconciler adds preparer to rename the file after its primary class if their names differ
+func (builder *JavaPreparersBuilder) WithFileNameReconciler() *JavaPreparersBuilder {
+	fileNameReconciler := Preparer{
+		Name:        FileNameReconcilerName,
+		Prepare:     reconcileJavaFileName,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Description: "renamed the file after the primary class",
+	}
+	builder.AddPreparer(fileNameReconciler)
+	return builder
+}
+
 // GetJavaPreparers returns preparation methods that should be applied to Java code
 func GetJavaPreparers(builder *PreparersBuilder, isUnitTest bool, isKata bool) {
 	if !isUnitTest && !isKata {
 		builder.JavaPreparers().
+			WithModuleInfoRejector().
+			WithBuildDirectiveStripper(BuildDirectivePrefixes).
+			WithUnicodeEscapeDecoder().
 			WithPublicClassRemover().
-			WithPackageChanger()
+			WithPackageChanger().
+			WithForbiddenApiGuard(DefaultForbiddenApis).
+			WithOutputCapture().
+			WithFileNameReconciler()
+		builder.warnIfSkipped(PublicClassRemoverName, "the public class may not match the file name")
 	}
 	if isUnitTest {
 		builder.JavaPreparers().
+			WithModuleInfoRejector().
+			WithBuildDirectiveStripper(BuildDirectivePrefixes).
+			WithUnicodeEscapeDecoder().
 			WithPackageChanger().
+			WithForbiddenApiGuard(DefaultForbiddenApis).
+			WithTestClassChecker().
 			WithFileNameChanger()
+		builder.warnIfSkipped(PackageChangerName, "the unit test may not be found by the test runner")
+		builder.warnIfSkipped(FileNameChangerName, "the file name may not match the unit test class")
 	}
 	if isKata {
 		builder.JavaPreparers().
+			WithModuleInfoRejector().
+			WithBuildDirectiveStripper(BuildDirectivePrefixes).
+			WithUnicodeEscapeDecoder().
 			WithPublicClassRemover().
-			WithPackageRemover()
+			WithPackageRemover().
+			WithForbiddenApiGuard(DefaultForbiddenApis).
+			WithOutputCapture()
+		builder.warnIfSkipped(PublicClassRemoverName, "the public class may not match the file name")
+	}
+}
+
+// JavaRegistry returns preparers of Java code which can be skipped or forced by name
+func JavaRegistry() Registry {
+	return Registry{
+		BuildDirectiveStripperName: func(builder *PreparersBuilder) {
+			builder.JavaPreparers().WithBuildDirectiveStripper(BuildDirectivePrefixes)
+		},
+		ForbiddenApiGuardName: func(builder *PreparersBuilder) {
+			builder.JavaPreparers().WithForbiddenApiGuard(DefaultForbiddenApis)
+		},
+		FragmentWrapperName: func(builder *PreparersBuilder) {
+			builder.JavaPreparers().WithFragmentWrapper(DefaultFragmentStartMarker, DefaultFragmentEndMarker)
+		},
+		ModuleInfoRejectorName:   func(builder *PreparersBuilder) { builder.JavaPreparers().WithModuleInfoRejector() },
+		ModuleInfoStripperName:   func(builder *PreparersBuilder) { builder.JavaPreparers().WithModuleInfoStripper() },
+		UnicodeEscapeDecoderName: func(builder *PreparersBuilder) { builder.JavaPreparers().WithUnicodeEscapeDecoder() },
+		PublicClassRemoverName:   func(builder *PreparersBuilder) { builder.JavaPreparers().WithPublicClassRemover() },
+		PackageChangerName:       func(builder *PreparersBuilder) { builder.JavaPreparers().WithPackageChanger() },
+		PackageRemoverName:       func(builder *PreparersBuilder) { builder.JavaPreparers().WithPackageRemover() },
+		TestClassCheckerName:     func(builder *PreparersBuilder) { builder.JavaPreparers().WithTestClassChecker() },
+		FileNameChangerName:      func(builder *PreparersBuilder) { builder.JavaPreparers().WithFileNameChanger() },
+		SeedInjectorName:         func(builder *PreparersBuilder) { builder.JavaPreparers().WithSeedInjector() },
+		LoopGuardName:            func(builder *PreparersBuilder) { builder.JavaPreparers().WithLoopGuard(DefaultLoopGuardMaxIterations) },
+		ClassSplitterName:        func(builder *PreparersBuilder) { builder.JavaPreparers().WithClassSplitter() },
+		StdinCheckerName:         func(builder *PreparersBuilder) { builder.JavaPreparers().WithStdinChecker(false) },
+		OutputCaptureName:        func(builder *PreparersBuilder) { builder.JavaPreparers().WithOutputCapture() },
+		FileNameReconcilerName:   func(builder *PreparersBuilder) { builder.JavaPreparers().WithFileNameReconciler() },
+		LineEndingNormalizerName: func(builder *PreparersBuilder) { builder.WithLineEndingNormalizer(LineEndingLf) },
+		Utf8ValidatorName:        func(builder *PreparersBuilder) { builder.WithUtf8Validator() },
+		IncludeResolverName:      func(builder *PreparersBuilder) { builder.WithIncludeResolver(builder.codeDir()) },
 	}
 }
 
@@ -112,83 +403,176 @@ func replace(args ...interface{}) error {
 	filePath := args[0].(string)
 	pattern := args[1].(string)
 	newPattern := args[2].(string)
+	log := loggerFromArgs(args, 3)
 
-	file, err := os.Open(filePath)
-	if err != nil {
-		logger.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
-		return err
-	}
-	defer file.Close()
+	return transformFile(filePath, []LineTransform{replaceTransform(pattern, newPattern)}, log)
+}
 
-	tmp, err := createTempFile(filePath)
-	if err != nil {
-		logger.Errorf("Preparation: Error during create new temporary file, err: %s\n", err.Error())
-		return err
+// InvalidPackageError is returned by the package changer if the package name can't be turned into an import
+type InvalidPackageError struct {
+	Package string
+}
+
+func (e *InvalidPackageError) Error() string {
+	return fmt.Sprintf("Invalid package name: \"%s\", package name should consist of at least two segments separated by dots", e.Package)
+}
+
+// changePackage processes file by filePath and changes the package declaration to the import of all classes of the package
+func changePackage(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	return transformFile(filePath, []LineTransform{changePackageTransform(&javaPackage{})}, log)
+}
+
+// changePackageTransform returns LineTransform which changes the package declaration to the import
+// and keeps the name of the changed package in declaredPackage.
+// Returns InvalidPackageError if the declared package doesn't match packageNamePattern.
+func changePackageTransform(declaredPackage *javaPackage) LineTransform {
+	toImport := replaceTransform(packagePattern, importStringPattern)
+	return func(line string) (string, error) {
+		match := packageDeclarationRegexp.FindStringSubmatch(line)
+		if match != nil && !packageNameRegexp.MatchString(match[1]) {
+			return "", &InvalidPackageError{Package: match[1]}
+		}
+		if match != nil {
+			declaredPackage.name = match[1]
+		}
+		return toImport(line)
 	}
-	defer tmp.Close()
+}
 
-	// uses to indicate when need to add new line to tmp file
-	err = writeWithReplace(file, tmp, pattern, newPattern)
-	if err != nil {
-		logger.Errorf("Preparation: Error during write data to tmp file, err: %s\n", err.Error())
-		return err
+// removePackageTransform removes the package declaration from the line.
+// The line is deleted if there is nothing else in it.
+func removePackageTransform(line string) (string, error) {
+	reg := compilePattern(packagePattern)
+	if !reg.MatchString(line) {
+		return line, nil
+	}
+	if rest := reg.ReplaceAllString(line, ""); strings.TrimSpace(rest) != "" {
+		return rest, nil
 	}
+	return "", errLineDeleted
+}
 
-	// replace original file with temporary file with renaming
-	if err = os.Rename(tmp.Name(), filePath); err != nil {
-		logger.Errorf("Preparation: Error during rename temporary file, err: %s\n", err.Error())
-		return err
+// stripBuildDirectives processes file by filePath and clears all lines which start with one of prefixes
+func stripBuildDirectives(args ...interface{}) error {
+	filePath := args[0].(string)
+	prefixes := args[1].([]string)
+	log := loggerFromArgs(args, 2)
+
+	return transformFile(filePath, []LineTransform{stripPrefixedLinesTransform(prefixes)}, log)
+}
+
+// stripPrefixedLinesTransform returns LineTransform which clears lines starting with one of prefixes.
+// Lines are kept empty so line numbers of compilation errors match the original code.
+func stripPrefixedLinesTransform(prefixes []string) LineTransform {
+	return func(line string) (string, error) {
+		trimmed := strings.TrimLeft(line, " \t")
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(trimmed, prefix) {
+				return "", nil
+			}
+		}
+		return line, nil
 	}
-	return nil
 }
 
-func removePublicClassModifier(args ...interface{}) error {
-	err := replace(args...)
-	return err
+// UnsupportedUnicodeEscapeError is returned by the unicode escape decoder
+// if an escape in a declaration is decoded to a line terminator
+type UnsupportedUnicodeEscapeError struct {
+	Line string
 }
 
-// writeWithReplace rewrites all lines from file with replacing all patterns to newPattern to another file
-func writeWithReplace(from *os.File, to *os.File, pattern, newPattern string) error {
-	newLine := false
-	reg := regexp.MustCompile(pattern)
-	scanner := bufio.NewScanner(from)
+func (e *UnsupportedUnicodeEscapeError) Error() string {
+	return fmt.Sprintf("Unsupported unicode escape in line: \"%s\", line terminators can't be written as unicode escapes in declarations", e.Line)
+}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		err := replaceAndWriteLine(newLine, to, line, reg, newPattern)
-		if err != nil {
-			logger.Errorf("Preparation: Error during write \"%s\" to tmp file, err: %s\n", line, err.Error())
-			return err
+// decodeUnicodeEscapes processes file by filePath and decodes unicode escapes in package, import and class declarations
+func decodeUnicodeEscapes(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	return transformFile(filePath, []LineTransform{decodeUnicodeEscapesTransform}, log)
+}
+
+// decodeUnicodeEscapesTransform is LineTransform which decodes unicode escapes of the line
+// if the decoded line is a package, import or class declaration, so other preparers can match it.
+// Other lines are kept as they are since escapes in string literals must stay escaped.
+// Returns UnsupportedUnicodeEscapeError if the declaration contains an escaped line terminator.
+func decodeUnicodeEscapesTransform(line string) (string, error) {
+	if !strings.Contains(line, `\u`) {
+		return line, nil
+	}
+	decoded := decodeJavaUnicodeEscapes(line)
+	if !headerKeywordRegexp.MatchString(decoded) {
+		return line, nil
+	}
+	if strings.ContainsAny(decoded, "\r\n") {
+		return "", &UnsupportedUnicodeEscapeError{Line: line}
+	}
+	return decoded, nil
+}
+
+// decodeJavaUnicodeEscapes replaces unicode escapes as \u0070 with their characters.
+// As in Java, a backslash preceded by an odd number of backslashes doesn't start an escape,
+// the escape may have several 'u' characters and invalid escapes are kept as they are.
+func decodeJavaUnicodeEscapes(code string) string {
+	var builder strings.Builder
+	backslashes := 0
+	for i := 0; i < len(code); i++ {
+		if code[i] == '\\' && backslashes%2 == 0 {
+			if char, length, ok := parseUnicodeEscape(code[i:]); ok {
+				builder.WriteRune(char)
+				backslashes = 0
+				i += length - 1
+				continue
+			}
+		}
+		if code[i] == '\\' {
+			backslashes++
+		} else {
+			backslashes = 0
 		}
-		newLine = true
+		builder.WriteByte(code[i])
 	}
-	return scanner.Err()
+	return builder.String()
 }
 
-// replaceAndWriteLine replaces pattern from line to newPattern and writes updated line to the file
-func replaceAndWriteLine(newLine bool, to *os.File, line string, reg *regexp.Regexp, newPattern string) error {
-	err := addNewLine(newLine, to)
-	if err != nil {
-		logger.Errorf("Preparation: Error during write \"%s\" to tmp file, err: %s\n", newLinePattern, err.Error())
-		return err
+// parseUnicodeEscape returns the character and the length of the unicode escape at the start of the code
+func parseUnicodeEscape(code string) (rune, int, bool) {
+	i := 1
+	for i < len(code) && code[i] == 'u' {
+		i++
 	}
-	line = reg.ReplaceAllString(line, newPattern)
-	if _, err = io.WriteString(to, line); err != nil {
-		logger.Errorf("Preparation: Error during write \"%s\" to tmp file, err: %s\n", line, err.Error())
-		return err
+	if i == 1 || i+4 > len(code) {
+		return 0, 0, false
 	}
-	return nil
+	value, err := strconv.ParseUint(code[i:i+4], 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	return rune(value), i + 4, true
+}
+
+func removePublicClassModifier(args ...interface{}) error {
+	err := replace(args...)
+	return err
 }
 
 // createTempFile creates temporary file next to originalFile
 func createTempFile(originalFilePath string) (*os.File, error) {
-	// all folders which are included in filePath
-	filePathSlice := strings.Split(originalFilePath, string(pathSeparatorPattern))
-	fileName := filePathSlice[len(filePathSlice)-1]
+	tmpFileName := fmt.Sprintf("%s_%s", tmpFileSuffix, filepath.Base(originalFilePath))
+	return os.Create(filepath.Join(filepath.Dir(originalFilePath), tmpFileName))
+}
 
-	tmpFileName := fmt.Sprintf("%s_%s", tmpFileSuffix, fileName)
-	tmpFilePath := strings.Replace(originalFilePath, fileName, tmpFileName, 1)
-	return os.Create(tmpFilePath)
+// replaceWithTempFile closes temporary file and replaces original file with it by renaming.
+// Files are closed before renaming since open files can't be renamed on Windows.
+func replaceWithTempFile(tmp *os.File, originalFilePath string) error {
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), originalFilePath)
 }
 
 // addNewLine adds a new line at the end of the file
@@ -204,7 +588,8 @@ func addNewLine(newLine bool, file *os.File) error {
 
 func changeJavaTestFileName(args ...interface{}) error {
 	filePath := args[0].(string)
-	className, err := getPublicClassName(filePath)
+	log := loggerFromArgs(args, 1)
+	className, err := getPublicClassName(filePath, log)
 	if err != nil {
 		return err
 	}
@@ -215,20 +600,664 @@ func changeJavaTestFileName(args ...interface{}) error {
 	return nil
 }
 
+// ClassFileNameError is returned if the file with the code can't be renamed after the class derived from the code,
+// since the name of the class isn't an identifier, e.g. it contains path separators or "..".
+type ClassFileNameError struct {
+	ClassName string
+}
+
+func (e *ClassFileNameError) Error() string {
+	return fmt.Sprintf("Invalid class name: \"%s\", the file with the code is named after the class, so the name should be an identifier", e.ClassName)
+}
+
+// renameJavaFile renames the file by filePath after className keeping its folder and extension.
+// Returns ClassFileNameError if className isn't an identifier, so the new path can't leave the folder of the file.
 func renameJavaFile(filePath string, className string) error {
-	currentFileName := filepath.Base(filePath)
-	newFilePath := strings.Replace(filePath, currentFileName, fmt.Sprintf("%s%s", className, filepath.Ext(currentFileName)), 1)
+	if !classFileNameRegexp.MatchString(className) {
+		return &ClassFileNameError{ClassName: className}
+	}
+	filePath = filepath.Clean(filePath)
+	newFilePath := filepath.Join(filepath.Dir(filePath), className+filepath.Ext(filePath))
 	err := os.Rename(filePath, newFilePath)
 	return err
 }
 
-func getPublicClassName(filePath string) (string, error) {
-	code, err := ioutil.ReadFile(filePath)
+// reconcileJavaFileName renames the file by filePath after its primary class if their names differ.
+// The primary class is the top-level class which declares main method or the first top-level type
+// if there is no such class. The file isn't renamed if another file already has the name of the class.
+func reconcileJavaFileName(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	className := primaryClassName(string(code))
+	fileName := filepath.Base(filePath)
+	if className == "" || className+javaSourceFileExtension == fileName {
+		return nil
+	}
+	newFilePath := filepath.Join(filepath.Dir(filePath), className+javaSourceFileExtension)
+	if _, err := os.Stat(newFilePath); err == nil {
+		log.Warnf("Preparation: File %s isn't renamed after the class %s, since the file already exists\n", fileName, className)
+		return nil
+	}
+	return renameJavaFile(filePath, className)
+}
+
+// primaryClassName returns the name of the top-level class of code which declares main method.
+// If there is no such class or there are several ones returns the name of the first top-level type.
+func primaryClassName(code string) string {
+	if classes := findMainClasses(code); len(classes) == 1 {
+		return classes[0][strings.LastIndex(classes[0], ".")+1:]
+	}
+	_, types := findTopLevelTypes(removeJavaCommentsAndStrings(code))
+	if len(types) == 0 {
+		return ""
+	}
+	return types[0].name
+}
+
+func getPublicClassName(filePath string, log *logger.Entry) (string, error) {
+	code, err := readSourceFile(filePath)
 	if err != nil {
-		logger.Errorf("Preparer: Error during open file: %s, err: %s\n", filePath, err.Error())
+		log.Errorf("Preparer: Error during open file: %s, err: %s\n", filePath, err.Error())
 		return "", err
 	}
-	re := regexp.MustCompile(publicClassNamePattern)
-	className := re.FindStringSubmatch(string(code))[1]
-	return className, err
+	match := publicClassNameRegexp.FindStringSubmatch(string(code))
+	if match == nil {
+		return "", &TestClassError{}
+	}
+	return match[1], nil
+}
+
+// TestClassError is returned by the test class checker if the test class isn't the only public class of the unit test
+type TestClassError struct {
+	PublicClasses []string
+	TestClasses   []string
+}
+
+func (e *TestClassError) Error() string {
+	switch {
+	case len(e.PublicClasses) == 0:
+		return "Unit test doesn't declare a public class. Declare the test class as public"
+	case len(e.PublicClasses) > 1:
+		return fmt.Sprintf("Unit test declares several public classes: %s. Only the test class should be public", strings.Join(e.PublicClasses, ", "))
+	default:
+		return fmt.Sprintf("Unit test declares the public class %s, but tests are declared in %s. Declare the test class as public", e.PublicClasses[0], strings.Join(e.TestClasses, ", "))
+	}
+}
+
+// checkTestClass checks that the unit test by filePath declares exactly one public top-level class
+// and this class contains tests if any top-level type is annotated with @Test,
+// since the file is renamed after the public class.
+// Declarations inside comments and string literals are ignored.
+func checkTestClass(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	stripped := removeJavaCommentsAndStrings(string(code))
+	_, types := findTopLevelTypes(stripped)
+	var publicClasses, testClasses []string
+	for _, javaType := range types {
+		declaration := stripped[javaType.start:javaType.end]
+		keyword := topLevelTypeRegexp.FindStringSubmatchIndex(declaration)
+		if testAnnotationRegexp.MatchString(declaration[keyword[0]:]) {
+			testClasses = append(testClasses, javaType.name)
+		}
+		if declaration[keyword[2]:keyword[3]] == "class" && publicModifierRegexp.MatchString(declaration[:keyword[0]]) {
+			publicClasses = append(publicClasses, javaType.name)
+		}
+	}
+	if len(publicClasses) != 1 {
+		return &TestClassError{PublicClasses: publicClasses, TestClasses: testClasses}
+	}
+	for _, testClass := range testClasses {
+		if testClass == publicClasses[0] {
+			return nil
+		}
+	}
+	if len(testClasses) == 0 {
+		return nil
+	}
+	return &TestClassError{PublicClasses: publicClasses, TestClasses: testClasses}
+}
+
+// injectRandomSeed rewrites all "new Random()" in the file by filePath to "new Random(javaRandomSeed)"
+// to make the output of the code reproducible. Occurrences inside comments and string literals are kept.
+func injectRandomSeed(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+
+	matches := unseededRandomRegexp.FindAllSubmatchIndex([]byte(removeJavaCommentsAndStrings(string(code))), -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	var result bytes.Buffer
+	last := 0
+	for _, match := range matches {
+		result.Write(code[last:match[0]])
+		result.WriteString(fmt.Sprintf(seededRandomPattern, code[match[2]:match[3]], javaRandomSeed))
+		last = match[1]
+	}
+	result.Write(code[last:])
+
+	if err = os.WriteFile(filePath, result.Bytes(), javaFileMode); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// captureOutput wraps the body of main method of the file by filePath into try-finally block which flushes
+// System.out and System.err, so output written by the code is captured even if it isn't flushed by the code.
+// Code which already sets or flushes standard streams is kept as is.
+func captureOutput(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	stripped := removeJavaCommentsAndStrings(string(code))
+	if outputManagementRegexp.MatchString(stripped) {
+		return nil
+	}
+	match := mainMethodBodyRegexp.FindStringIndex(stripped)
+	if match == nil {
+		return nil
+	}
+	bodyStart := match[1]
+	bodyEnd := -1
+	for i, depth := bodyStart, 1; i < len(stripped); i++ {
+		if stripped[i] == '{' {
+			depth++
+		} else if stripped[i] == '}' {
+			depth--
+			if depth == 0 {
+				bodyEnd = i
+				break
+			}
+		}
+	}
+	if bodyEnd < 0 {
+		return nil
+	}
+
+	var result bytes.Buffer
+	result.Write(code[:bodyStart])
+	result.WriteString(outputCaptureSetup)
+	result.WriteString(strings.TrimRight(string(code[bodyStart:bodyEnd]), " \t"))
+	result.WriteString(outputCaptureTeardown)
+	result.Write(code[bodyEnd:])
+
+	if err = os.WriteFile(filePath, result.Bytes(), javaFileMode); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// MissingMarkerError is returned by the fragment wrapper if only one of markers of the fragment is found
+type MissingMarkerError struct {
+	Marker string
+}
+
+func (e *MissingMarkerError) Error() string {
+	return fmt.Sprintf("Fragment marker \"%s\" is not found, the fragment to run should start and end with markers", e.Marker)
+}
+
+// wrapFragment processes file by filePath and replaces all code except package and import statements
+// with the main method which contains lines between the start and the end markers.
+// Markers are lines which consist of startMarker and endMarker only. The file is kept as is if there are no markers.
+func wrapFragment(args ...interface{}) error {
+	filePath := args[0].(string)
+	startMarker := args[1].(string)
+	endMarker := args[2].(string)
+	log := loggerFromArgs(args, 3)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	lines := strings.Split(string(code), newLinePattern)
+	start, end := -1, -1
+	starts, ends := markerLines(lines, startMarker), markerLines(lines, endMarker)
+	if len(starts) > 0 {
+		start = starts[0]
+	}
+	for _, i := range ends {
+		if start >= 0 && i > start {
+			end = i
+			break
+		}
+	}
+	switch {
+	case start < 0 && len(ends) == 0:
+		return nil
+	case start < 0:
+		return &MissingMarkerError{Marker: startMarker}
+	case end < 0:
+		return &MissingMarkerError{Marker: endMarker}
+	}
+
+	headerEnd, _ := findTopLevelTypes(removeJavaCommentsAndStrings(string(code)))
+	var result strings.Builder
+	if header := strings.TrimRight(string(code[:headerEnd]), " \t\n"); header != "" {
+		result.WriteString(header + newLinePattern + newLinePattern)
+	}
+	result.WriteString(fragmentMainSetup)
+	for _, line := range lines[start+1 : end] {
+		result.WriteString(line + newLinePattern)
+	}
+	result.WriteString(fragmentMainTeardown)
+
+	if err = os.WriteFile(filePath, []byte(result.String()), javaFileMode); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// markerLines returns indexes of lines which consist of marker only.
+// Candidate lines are replaced by the placeholder before comments and strings are removed,
+// so lines inside block comments, string or text block literals aren't markers.
+func markerLines(lines []string, marker string) []int {
+	candidates := make([]string, len(lines))
+	for i, line := range lines {
+		candidates[i] = line
+		if strings.TrimSpace(line) == marker {
+			candidates[i] = fragmentMarkerPlaceholder
+		}
+	}
+	stripped := strings.Split(removeJavaCommentsAndStrings(strings.Join(candidates, newLinePattern)), newLinePattern)
+	var indexes []int
+	for i, line := range stripped {
+		if line == fragmentMarkerPlaceholder {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// javaType is a top-level type declaration of Java code
+type javaType struct {
+	name  string
+	start int
+	end   int
+}
+
+// splitClasses writes each top-level type of the file by filePath into its own file named after the type.
+// The package and imports of the file are duplicated into each new file and the original file is removed.
+// The class with main method is resolved from the split files by FindJavaMainClass.
+func splitClasses(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	stripped := removeJavaCommentsAndStrings(string(code))
+	headerEnd, types := findTopLevelTypes(stripped)
+	if len(types) < 2 {
+		return nil
+	}
+
+	header := strings.TrimRight(string(code[:headerEnd]), " \t\n")
+	written := false
+	for _, javaType := range types {
+		body := strings.TrimLeft(string(code[javaType.start:javaType.end]), " \t\n")
+		typeFilePath := filepath.Join(filepath.Dir(filePath), javaType.name+javaSourceFileExtension)
+		if typeFilePath == filePath {
+			written = true
+		}
+		content := body + newLinePattern
+		if header != "" {
+			content = header + newLinePattern + newLinePattern + content
+		}
+		if err = os.WriteFile(typeFilePath, []byte(content), javaFileMode); err != nil {
+			log.Errorf("Preparation: Error during write file: %s, err: %s\n", typeFilePath, err.Error())
+			return err
+		}
+	}
+	if !written {
+		if err = os.Remove(filePath); err != nil {
+			log.Errorf("Preparation: Error during remove file: %s, err: %s\n", filePath, err.Error())
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findTopLevelTypes returns the end of package and import statements and top-level type declarations
+// of code without comments and string literals.
+// Each type starts right after the end of the previous one, so comments and annotations of the type are kept.
+func findTopLevelTypes(code string) (int, []javaType) {
+	depths := make([]int, len(code)+1)
+	depth := 0
+	for i := 0; i < len(code); i++ {
+		depths[i] = depth
+		switch code[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+	}
+	depths[len(code)] = depth
+
+	headerEnd := 0
+	matches := topLevelTypeRegexp.FindAllStringSubmatchIndex(code, -1)
+	firstType := len(code)
+	for _, match := range matches {
+		if depths[match[0]] == 0 {
+			firstType = match[0]
+			break
+		}
+	}
+	for _, match := range headerStatementRegexp.FindAllStringIndex(code[:firstType], -1) {
+		if depths[match[0]] == 0 {
+			headerEnd = match[1]
+		}
+	}
+
+	var types []javaType
+	start := headerEnd
+	for _, match := range matches {
+		if match[0] < start || depths[match[0]] != 0 {
+			continue
+		}
+		open := strings.IndexByte(code[match[1]:], '{')
+		if open < 0 {
+			break
+		}
+		end := match[1] + open + 1
+		for end < len(code) && depths[end] > 0 {
+			end++
+		}
+		types = append(types, javaType{name: code[match[4]:match[5]], start: start, end: end})
+		start = end
+	}
+	return headerEnd, types
+}
+
+// ModuleDescriptorError is returned by the module info rejector if code is a module descriptor
+// or contains module directives without the module declaration
+type ModuleDescriptorError struct {
+	// Module is the name of the declared module, it is empty if code contains only module directives
+	Module string
+	// Line is the line of the module declaration or the first module directive
+	Line int
+}
+
+func (e *ModuleDescriptorError) Error() string {
+	if e.Module == "" {
+		return fmt.Sprintf("Code contains module directives of module descriptors (module-info.java) at line %d, they aren't supported in single-file runs. Remove directives like requires and exports and run the classes instead", e.Line)
+	}
+	return fmt.Sprintf("Code declares the module \"%s\", module descriptors (module-info.java) aren't supported in single-file runs. Remove the module declaration and run the classes of the module instead", e.Module)
+}
+
+// rejectModuleInfo checks that file by filePath doesn't declare a module and doesn't contain module directives,
+// i.e. it finds the same declarations as DeclaresJavaModule. Declarations inside comments and string literals are ignored.
+func rejectModuleInfo(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	stripped := removeJavaCommentsAndStrings(string(code))
+	declarations := findModuleInfo(stripped)
+	if len(declarations) == 0 {
+		return nil
+	}
+	moduleError := &ModuleDescriptorError{Line: strings.Count(stripped[:declarations[0].start], "\n") + 1}
+	if match := moduleDeclarationRegexp.FindStringSubmatch(stripped); match != nil {
+		moduleError.Module = strings.Join(strings.Fields(match[1]), "")
+	}
+	return moduleError
+}
+
+// ForbiddenApiUsage is a reference to a forbidden API in the code
+type ForbiddenApiUsage struct {
+	Api  string
+	Line int
+}
+
+// ForbiddenApiError is returned by the preparer if code contains references to forbidden APIs
+type ForbiddenApiError struct {
+	Usages []ForbiddenApiUsage
+}
+
+func (e *ForbiddenApiError) Error() string {
+	usages := make([]string, 0, len(e.Usages))
+	for _, usage := range e.Usages {
+		usages = append(usages, fmt.Sprintf("%s at line %d", usage.Api, usage.Line))
+	}
+	return fmt.Sprintf("Code uses forbidden APIs: %s", strings.Join(usages, ", "))
+}
+
+// checkForbiddenApis checks that file by filePath doesn't contain references to apis.
+// API is a fully-qualified name of a class or a method. API which ends with a dot forbids the whole package.
+// Besides fully-qualified names, references by names which are imported by explicit, wildcard and static imports
+// are found, e.g. "Files.write" after "import java.nio.file.*;". References inside comments and string literals are ignored.
+func checkForbiddenApis(args ...interface{}) error {
+	filePath := args[0].(string)
+	apis := args[1].([]string)
+	log := loggerFromArgs(args, 2)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+
+	stripped := removeJavaCommentsAndStrings(string(code))
+	imports := javaImports(stripped)
+	aliases := make(map[string][]string, len(apis))
+	for _, api := range apis {
+		aliases[api] = importedApiNames(api, imports)
+	}
+	var usages []ForbiddenApiUsage
+	for number, line := range strings.Split(stripped, newLinePattern) {
+		for _, api := range apis {
+			if containsApi(line, api) {
+				usages = append(usages, ForbiddenApiUsage{Api: api, Line: number + 1})
+				continue
+			}
+			for _, alias := range aliases[api] {
+				if containsApi(line, alias) {
+					usages = append(usages, ForbiddenApiUsage{Api: api, Line: number + 1})
+					break
+				}
+			}
+		}
+	}
+	if len(usages) != 0 {
+		return &ForbiddenApiError{Usages: usages}
+	}
+	return nil
+}
+
+// javaImport is an import statement of Java code
+type javaImport struct {
+	name       string
+	isStatic   bool
+	isWildcard bool
+}
+
+// javaImports returns imports of the stripped code including the implicit import of java.lang
+func javaImports(stripped string) []javaImport {
+	headerEnd, _ := findTopLevelTypes(stripped)
+	imports := []javaImport{{name: javaLangPackage, isWildcard: true}}
+	for _, statement := range importStatementRegexp.FindAllStringSubmatchIndex(stripped, -1) {
+		if statement[0] >= headerEnd {
+			break
+		}
+		name := stripped[statement[4]:statement[5]]
+		imports = append(imports, javaImport{
+			name:       strings.TrimSuffix(name, "."+wildcardImport),
+			isStatic:   statement[2] >= 0,
+			isWildcard: strings.HasSuffix(name, "."+wildcardImport),
+		})
+	}
+	return imports
+}
+
+// importedApiNames returns names which refer to the api in the code with imports, e.g. "Files.write" for
+// "java.nio.file.Files.write" and "import java.nio.file.Files;". APIs of whole packages can be referred only by
+// fully-qualified names or imports of the package, which contain the fully-qualified name themselves.
+func importedApiNames(api string, imports []javaImport) []string {
+	if strings.HasSuffix(api, ".") {
+		return nil
+	}
+	var names []string
+	for _, javaImport := range imports {
+		var name string
+		switch {
+		case javaImport.isWildcard && strings.HasPrefix(api, javaImport.name+"."):
+			// "import java.nio.file.*;" and "import static java.nio.file.Files.*;" import names of their members
+			name = strings.TrimPrefix(api, javaImport.name+".")
+			if javaImport.isStatic && strings.Contains(name, ".") {
+				continue
+			}
+		case !javaImport.isWildcard && (api == javaImport.name || strings.HasPrefix(api, javaImport.name+".")):
+			// "import java.nio.file.Files;" and "import static java.nio.file.Files.write;" import the last name
+			name = javaImport.name[strings.LastIndex(javaImport.name, ".")+1:] + strings.TrimPrefix(api, javaImport.name)
+		default:
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// containsApi checks that line contains api which isn't a part of another name
+func containsApi(line, api string) bool {
+	for start := 0; ; {
+		index := strings.Index(line[start:], api)
+		if index < 0 {
+			return false
+		}
+		index += start
+		end := index + len(api)
+		previous, _ := utf8.DecodeLastRuneInString(line[:index])
+		next, _ := utf8.DecodeRuneInString(line[end:])
+		before := index == 0 || !isJavaNamePart(previous)
+		after := strings.HasSuffix(api, ".") || end == len(line) || !isJavaIdentifierPart(next)
+		if before && after {
+			return true
+		}
+		start = index + 1
+	}
+}
+
+func isJavaIdentifierPart(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isJavaNamePart(r rune) bool {
+	return r == '.' || isJavaIdentifierPart(r)
+}
+
+// removeJavaCommentsAndStrings replaces content of comments, string, text block and char literals with spaces.
+// Byte offsets of all other symbols and line breaks are kept.
+func removeJavaCommentsAndStrings(code string) string {
+	const (
+		codeState = iota
+		lineCommentState
+		blockCommentState
+		stringState
+		textBlockState
+		charState
+	)
+	src := []byte(code)
+	result := make([]byte, len(src))
+	state := codeState
+	isTextBlockQuotes := func(i int) bool {
+		return i+2 < len(src) && src[i] == '"' && src[i+1] == '"' && src[i+2] == '"'
+	}
+	for i := 0; i < len(src); i++ {
+		r := src[i]
+		next := byte(0)
+		if i+1 < len(src) {
+			next = src[i+1]
+		}
+		result[i] = ' '
+		if r == '\n' {
+			result[i] = r
+		}
+		switch state {
+		case codeState:
+			switch {
+			case r == '/' && next == '/':
+				state = lineCommentState
+				i++
+				result[i] = ' '
+			case r == '/' && next == '*':
+				state = blockCommentState
+				i++
+				result[i] = ' '
+			case isTextBlockQuotes(i):
+				state = textBlockState
+				i += 2
+				result[i-1], result[i] = ' ', ' '
+			case r == '"':
+				state = stringState
+			case r == '\'':
+				state = charState
+			default:
+				result[i] = r
+			}
+		case lineCommentState:
+			if r == '\n' {
+				state = codeState
+			}
+		case blockCommentState:
+			if r == '*' && next == '/' {
+				state = codeState
+				i++
+				result[i] = ' '
+			}
+		case stringState, charState:
+			switch {
+			case r == '\\' && next != '\n':
+				i++
+				result[i] = ' '
+			case r == '"' && state == stringState, r == '\'' && state == charState, r == '\n':
+				state = codeState
+			}
+		case textBlockState:
+			switch {
+			case r == '\\' && next != '\n':
+				i++
+				result[i] = ' '
+			case isTextBlockQuotes(i):
+				state = codeState
+				i += 2
+				result[i-1], result[i] = ' ', ' '
+			}
+		}
+	}
+	return string(result)
 }
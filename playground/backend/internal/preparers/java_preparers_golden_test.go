@@ -0,0 +1,72 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers_test
+
+import (
+	"beam.apache.org/playground/backend/internal/preparers"
+	"beam.apache.org/playground/backend/internal/preparers/preparertest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var forbiddenApis = []string{"sun.misc.Unsafe", "java.nio.file.Files.write", "sun.reflect.", "java.lang.reflect.Method", "java.lang.ProcessBuilder"}
+
+// javaChains are chains of Java preparers by folders of fixtures in testdata/java
+var javaChains = map[string]preparertest.Chain{
+	"package_changer":        func(builder *preparers.PreparersBuilder) { builder.JavaPreparers().WithPackageChanger() },
+	"class_splitter":         func(builder *preparers.PreparersBuilder) { builder.JavaPreparers().WithClassSplitter() },
+	"output_capture":         func(builder *preparers.PreparersBuilder) { builder.JavaPreparers().WithOutputCapture() },
+	"unicode_escape_decoder": func(builder *preparers.PreparersBuilder) { builder.JavaPreparers().WithUnicodeEscapeDecoder() },
+	"module_info_rejector":   func(builder *preparers.PreparersBuilder) { builder.JavaPreparers().WithModuleInfoRejector() },
+	"seed_injector":          func(builder *preparers.PreparersBuilder) { builder.JavaPreparers().WithSeedInjector() },
+	"module_info_stripper": func(builder *preparers.PreparersBuilder) {
+		builder.JavaPreparers().WithModuleInfoStripper()
+		preparers.GetJavaPreparers(builder, false, false)
+	},
+	"build_directive_stripper": func(builder *preparers.PreparersBuilder) {
+		builder.JavaPreparers().WithBuildDirectiveStripper(preparers.BuildDirectivePrefixes)
+	},
+	"forbidden_api_guard": func(builder *preparers.PreparersBuilder) {
+		builder.JavaPreparers().WithForbiddenApiGuard(forbiddenApis)
+	},
+	"fragment_wrapper": func(builder *preparers.PreparersBuilder) {
+		builder.JavaPreparers().WithFragmentWrapper(preparers.DefaultFragmentStartMarker, preparers.DefaultFragmentEndMarker)
+	},
+	"code":      func(builder *preparers.PreparersBuilder) { preparers.GetJavaPreparers(builder, false, false) },
+	"unit_test": func(builder *preparers.PreparersBuilder) { preparers.GetJavaPreparers(builder, true, false) },
+	"kata":      func(builder *preparers.PreparersBuilder) { preparers.GetJavaPreparers(builder, false, true) },
+}
+
+// TestJavaPreparersGolden applies the chain of each folder to its fixtures and compares results with golden files.
+// Run the test with -update flag to regenerate golden files.
+func TestJavaPreparersGolden(t *testing.T) {
+	fixtures, _ := filepath.Glob(filepath.Join("testdata", "java", "*", "*.java"))
+	if len(fixtures) == 0 {
+		t.Fatal("no fixtures found in testdata/java")
+	}
+	for _, fixture := range fixtures {
+		folder := filepath.Base(filepath.Dir(fixture))
+		chain, ok := javaChains[folder]
+		if !ok {
+			t.Fatalf("no chain of preparers for fixtures in %s", folder)
+		}
+		t.Run(filepath.ToSlash(strings.TrimPrefix(fixture, filepath.Join("testdata", "java")+string(filepath.Separator))), func(t *testing.T) {
+			result := preparertest.RunChain(t, chain, fixture)
+			preparertest.AssertGolden(t, result, strings.TrimSuffix(fixture, ".java")+".golden")
+		})
+	}
+}
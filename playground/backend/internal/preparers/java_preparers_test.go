@@ -18,13 +18,15 @@ package preparers
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
 	"beam.apache.org/playground/backend/internal/fs_tool"
-	"beam.apache.org/playground/backend/internal/validators"
+	"beam.apache.org/playground/backend/internal/logger"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/google/uuid"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
-	"sync"
 	"testing"
 )
 
@@ -33,13 +35,8 @@ func Test_replace(t *testing.T) {
 	codeWithoutPublicClass := "package org.apache.beam.sdk.transforms; \n class Class {\n    public static void main(String[] args) {\n        System.out.println(\"Hello World!\");\n    }\n}"
 	codeWithImportedPackage := "import org.apache.beam.sdk.transforms.*; \n class Class {\n    public static void main(String[] args) {\n        System.out.println(\"Hello World!\");\n    }\n}"
 
-	path, err := os.Getwd()
-	if err != nil {
-		panic(err)
-	}
-	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), filepath.Join(path, "temp"))
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), t.TempDir())
 	_ = lc.CreateFolders()
-	defer os.RemoveAll(filepath.Join(path, "temp"))
 	_ = lc.CreateSourceCodeFile(codeWithPublicClass)
 
 	type args struct {
@@ -88,6 +85,154 @@ func Test_replace(t *testing.T) {
 	}
 }
 
+func TestGetJavaPreparersOutputCapture(t *testing.T) {
+	tests := []struct {
+		name       string
+		isUnitTest bool
+		isKata     bool
+		want       int
+	}{
+		{name: "code", isUnitTest: false, isKata: false, want: 1},
+		{name: "kata", isUnitTest: false, isKata: true, want: 1},
+		{name: "unit test", isUnitTest: true, isKata: false, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewPreparersBuilder("MOCK_FILEPATH")
+			GetJavaPreparers(builder, tt.isUnitTest, tt.isKata)
+			got := 0
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if preparer.Name == OutputCaptureName {
+					got++
+				}
+			}
+			if got != tt.want {
+				t.Errorf("GetJavaPreparers() adds output capture %v times, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_createTempFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "source.folder")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	tmp, err := createTempFile(filepath.Join(dir, "Class.java"))
+	if err != nil {
+		t.Fatalf("createTempFile() unexpected error = %v", err)
+	}
+	defer tmp.Close()
+	if want := filepath.Join(dir, tmpFileSuffix+"_Class.java"); tmp.Name() != want {
+		t.Errorf("createTempFile() = %v, want %v", tmp.Name(), want)
+	}
+}
+
+func Test_renameJavaFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "Class.java.folder")
+	if err := os.Mkdir(dir, 0700); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	// Test that the folder name which contains the file name is kept
+	filePath := filepath.Join(dir, "Class.java")
+	if err := os.WriteFile(filePath, []byte("class Class {}"), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	if err := renameJavaFile(filePath, "Main"); err != nil {
+		t.Fatalf("renameJavaFile() unexpected error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "Main.java")); err != nil {
+		t.Errorf("renameJavaFile() file Main.java doesn't exist in %s", dir)
+	}
+}
+
+func Test_renameJavaFileClassName(t *testing.T) {
+	tests := []struct {
+		name      string
+		className string
+		wantErr   bool
+	}{
+		{
+			name:      "class name",
+			className: "WordCount",
+		},
+		{
+			// Test that identifiers with digits, underscores, dollars and non-ASCII letters are allowed
+			name:      "class name with other identifier characters",
+			className: "Über_Count$2",
+		},
+		{
+			// Test that the class name can't move the file to the parent folder
+			name:      "class name with path traversal",
+			className: "../../Evil",
+			wantErr:   true,
+		},
+		{
+			name:      "class name with path separator",
+			className: "sub/Evil",
+			wantErr:   true,
+		},
+		{
+			name:      "class name with dots",
+			className: "..",
+			wantErr:   true,
+		},
+		{
+			name:      "empty class name",
+			className: "",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			dir := filepath.Join(root, "workspace", "src")
+			if err := os.MkdirAll(dir, 0700); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			filePath := filepath.Join(dir, "Main.java")
+			if err := os.WriteFile(filePath, []byte("class Main {}"), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			err := renameJavaFile(filePath, tt.className)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("renameJavaFile() unexpected error = %v", err)
+				}
+				if _, err := os.Stat(filepath.Join(dir, tt.className+".java")); err != nil {
+					t.Errorf("renameJavaFile() file %s.java doesn't exist in %s", tt.className, dir)
+				}
+				return
+			}
+			if got, ok := err.(*ClassFileNameError); !ok || got.ClassName != tt.className {
+				t.Errorf("renameJavaFile() error = %v, want ClassFileNameError for %q", err, tt.className)
+			}
+			if _, err := os.Stat(filePath); err != nil {
+				t.Errorf("renameJavaFile() file %s is moved", filePath)
+			}
+		})
+	}
+}
+
+func Test_replaceLogsPipelineId(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger.SetHandlers([]logger.Handler{logger.NewJsonHandler(out)})
+	defer logger.SetHandlers([]logger.Handler{})
+	pipelineId := uuid.New().String()
+
+	if err := replace("someFile.java", classWithPublicModifierPattern, classWithoutPublicModifierPattern, logger.WithPipelineId(pipelineId)); err == nil {
+		t.Fatalf("replace() expected error for the missing file")
+	}
+
+	line := map[string]interface{}{}
+	if err := json.Unmarshal(bytes.Split(out.Bytes(), []byte("\n"))[0], &line); err != nil {
+		t.Fatalf("replace() didn't log the error: %s", out.String())
+	}
+	if line["severity"] != "ERROR" || line["pipelineId"] != pipelineId {
+		t.Errorf("replace() logged %v, want ERROR with pipelineId %s", line, pipelineId)
+	}
+}
+
 func TestGetJavaPreparers(t *testing.T) {
 	type args struct {
 		filePath   string
@@ -100,19 +245,22 @@ func TestGetJavaPreparers(t *testing.T) {
 		want int
 	}{
 		{
+			// Test that public class remover and package changer are merged into a single pass
+			// followed by the rewriter of references to the changed package
 			name: "Test number of preparers for code",
 			args: args{"MOCK_FILEPATH", false, false},
-			want: 2,
+			want: 6,
 		},
 		{
 			name: "Test number of preparers for unit test",
 			args: args{"MOCK_FILEPATH", true, false},
-			want: 2,
+			want: 6,
 		},
 		{
+			// Test that public class remover and package remover are merged into a single pass
 			name: "Test number of preparers for kata",
 			args: args{"MOCK_FILEPATH", false, true},
-			want: 2,
+			want: 4,
 		},
 	}
 	for _, tt := range tests {
@@ -126,18 +274,115 @@ func TestGetJavaPreparers(t *testing.T) {
 	}
 }
 
+func TestGetJavaPreparersDescribe(t *testing.T) {
+	tests := []struct {
+		name       string
+		isUnitTest bool
+		isKata     bool
+		want       []PreparerDescriptor
+	}{
+		{
+			// Test that the file name reconciler is applied after other preparers of the code
+			name: "code",
+			want: []PreparerDescriptor{
+				{Name: ModuleInfoRejectorName, Pattern: moduleDeclarationPattern, Order: 0},
+				{Name: BuildDirectiveStripperName, Order: 1},
+				{Name: UnicodeEscapeDecoderName, Order: 2},
+				{Name: PublicClassRemoverName, Pattern: classWithPublicModifierPattern, Order: 3},
+				{Name: PackageChangerName, Pattern: packageDeclarationPattern, Order: 4},
+				{Name: PackageChangerName, Order: 5},
+				{Name: ForbiddenApiGuardName, Order: 6},
+				{Name: OutputCaptureName, Pattern: mainMethodBodyPattern, Order: 7},
+				{Name: FileNameReconcilerName, Order: 8},
+			},
+		},
+		{
+			name:       "unit test",
+			isUnitTest: true,
+			want: []PreparerDescriptor{
+				{Name: ModuleInfoRejectorName, Pattern: moduleDeclarationPattern, Order: 0},
+				{Name: BuildDirectiveStripperName, Order: 1},
+				{Name: UnicodeEscapeDecoderName, Order: 2},
+				{Name: PackageChangerName, Pattern: packageDeclarationPattern, Order: 3},
+				{Name: PackageChangerName, Order: 4},
+				{Name: ForbiddenApiGuardName, Order: 5},
+				{Name: TestClassCheckerName, Pattern: publicModifierPattern, Order: 6},
+				{Name: FileNameChangerName, Order: 7},
+			},
+		},
+		{
+			name:   "kata",
+			isKata: true,
+			want: []PreparerDescriptor{
+				{Name: ModuleInfoRejectorName, Pattern: moduleDeclarationPattern, Order: 0},
+				{Name: BuildDirectiveStripperName, Order: 1},
+				{Name: UnicodeEscapeDecoderName, Order: 2},
+				{Name: PublicClassRemoverName, Pattern: classWithPublicModifierPattern, Order: 3},
+				{Name: PackageRemoverName, Pattern: packagePattern, Order: 4},
+				{Name: ForbiddenApiGuardName, Order: 5},
+				{Name: OutputCaptureName, Pattern: mainMethodBodyPattern, Order: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewPreparersBuilder("MOCK_FILEPATH")
+			GetJavaPreparers(builder, tt.isUnitTest, tt.isKata)
+			if got := builder.Describe(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Describe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetJavaPreparersKataPackageRemoval(t *testing.T) {
+	body := "import java.util.List;\n\n%sclass Task {\n    public static void main(String[] args) {\n        System.out.println(List.of(1));\n    }\n}"
+	want := fmt.Sprintf(body, "")
+	tests := []struct {
+		name        string
+		withPackage bool
+		publicClass bool
+	}{
+		{name: "package and public class", withPackage: true, publicClass: true},
+		{name: "package without public class", withPackage: true, publicClass: false},
+		{name: "public class without package", withPackage: false, publicClass: true},
+		{name: "neither package nor public class", withPackage: false, publicClass: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Test that the kata code starts the same way regardless of the package and the public modifier
+			modifier := ""
+			if tt.publicClass {
+				modifier = "public "
+			}
+			code := fmt.Sprintf(body, modifier)
+			if tt.withPackage {
+				code = "package org.apache.beam.katas;\n" + code
+			}
+			filePath := filepath.Join(t.TempDir(), "Task.java")
+			if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(filePath)
+			builder.JavaPreparers().WithPublicClassRemover().WithPackageRemover()
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if err := preparer.Prepare(preparer.Args...); err != nil {
+					t.Fatalf("Prepare() unexpected error = %v", err)
+				}
+			}
+			got, _ := os.ReadFile(filePath)
+			if string(got) != want {
+				t.Errorf("prepared code = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 func Test_changeJavaTestFileName(t *testing.T) {
 	codeWithPublicClass := "package org.apache.beam.sdk.transforms; \n public class Class {\n    public static void main(String[] args) {\n        System.out.println(\"Hello World!\");\n    }\n}"
-	path, err := os.Getwd()
-	if err != nil {
-		panic(err)
-	}
-	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), filepath.Join(path, "temp"))
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_JAVA, uuid.New(), t.TempDir())
 	_ = lc.CreateFolders()
-	defer os.RemoveAll(filepath.Join(path, "temp"))
 	_ = lc.CreateSourceCodeFile(codeWithPublicClass)
-	validationResults := sync.Map{}
-	validationResults.Store(validators.UnitTestValidatorName, true)
 
 	type args struct {
 		args []interface{}
@@ -151,7 +396,7 @@ func Test_changeJavaTestFileName(t *testing.T) {
 		{
 			// Test that file changes its name to the name of its public class
 			name:     "file with java unit test code to be renamed",
-			args:     args{[]interface{}{lc.Paths.AbsoluteSourceFilePath, &validationResults}},
+			args:     args{[]interface{}{lc.Paths.AbsoluteSourceFilePath}},
 			wantErr:  false,
 			wantName: "Class.java",
 		},
@@ -161,7 +406,7 @@ func Test_changeJavaTestFileName(t *testing.T) {
 			if err := changeJavaTestFileName(tt.args.args...); (err != nil) != tt.wantErr {
 				t.Errorf("changeJavaTestFileName() error = %v, wantErr %v", err, tt.wantErr)
 			}
-			files, err := filepath.Glob(fmt.Sprintf("%s/*java", lc.Paths.AbsoluteSourceFileFolderPath))
+			files, err := filepath.Glob(filepath.Join(lc.Paths.AbsoluteSourceFileFolderPath, "*java"))
 			if err != nil {
 				t.Errorf("changeJavaTestFileName() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -171,3 +416,181 @@ func Test_changeJavaTestFileName(t *testing.T) {
 		})
 	}
 }
+
+func Test_changeJavaTestFileNameTraversal(t *testing.T) {
+	// the public class pattern matches any characters before the brace, so the class name is crafted from the code
+	code := "public class ../../Evil {\n    @Test\n    public void testCount() {}\n}\n"
+	root := t.TempDir()
+	dir := filepath.Join(root, "workspace", "src")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	filePath := filepath.Join(dir, "Main.java")
+	if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+
+	err := changeJavaTestFileName(filePath)
+	if got, ok := err.(*ClassFileNameError); !ok || got.ClassName != "../../Evil" {
+		t.Errorf("changeJavaTestFileName() error = %v, want ClassFileNameError", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "Evil.java")); err == nil {
+		t.Errorf("changeJavaTestFileName() file is moved outside of the workspace")
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		t.Errorf("changeJavaTestFileName() file %s is moved", filePath)
+	}
+}
+
+func Test_checkTestClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr *TestClassError
+	}{
+		{
+			// Test that the unit test with a single public test class passes the check
+			name: "one public class",
+			code: "import org.junit.Test;\n\nclass Helper {}\n\npublic class WordCountTest {\n    @Test\n    public void testCount() {}\n}\n",
+		},
+		{
+			// Test that public classes inside comments, strings and other classes are ignored
+			name: "nested public class",
+			code: "// public class Comment {}\npublic class WordCountTest {\n    String code = \"public class Code {}\";\n    public static class Nested {}\n    @org.junit.Test\n    public void testCount() {}\n}\n",
+		},
+		{
+			// Test that all public classes are listed in the error
+			name:    "two public classes",
+			code:    "public class WordCountTest {\n    @Test\n    public void testCount() {}\n}\n\npublic class Helper {}\n",
+			wantErr: &TestClassError{PublicClasses: []string{"WordCountTest", "Helper"}, TestClasses: []string{"WordCountTest"}},
+		},
+		{
+			name:    "no public class",
+			code:    "class WordCountTest {\n    @Test\n    public void testCount() {}\n}\n",
+			wantErr: &TestClassError{TestClasses: []string{"WordCountTest"}},
+		},
+		{
+			// Test that the public class should contain tests if there are tests in the code
+			name:    "public class without tests",
+			code:    "public class Helper {}\n\nclass WordCountTest {\n    @Test\n    public void testCount() {}\n}\n",
+			wantErr: &TestClassError{PublicClasses: []string{"Helper"}, TestClasses: []string{"WordCountTest"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "Main.java")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			err := checkTestClass(filePath)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("checkTestClass() unexpected error = %v", err)
+				}
+				return
+			}
+			if got, ok := err.(*TestClassError); !ok || !reflect.DeepEqual(got, tt.wantErr) {
+				t.Errorf("checkTestClass() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_reconcileJavaFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		code     string
+		wantName string
+	}{
+		{
+			// Test that the file isn't renamed if its name matches the class
+			name:     "matching names",
+			fileName: "Main.java",
+			code:     "class Main {\n    public static void main(String[] args) {}\n}\n",
+			wantName: "Main.java",
+		},
+		{
+			// Test that the file is renamed after the class with main method
+			name:     "mismatched names",
+			fileName: "Example.java",
+			code:     "package org.example;\n\nclass Helper {}\n\nclass Main {\n    public static void main(String[] args) {}\n}\n",
+			wantName: "Main.java",
+		},
+		{
+			// Test that the file is renamed after the first top-level type if there is no main method
+			name:     "without main method",
+			fileName: "Example.java",
+			code:     "// class Comment\nclass Helper {}\n\ninterface Other {}\n",
+			wantName: "Helper.java",
+		},
+		{
+			name:     "without classes",
+			fileName: "Example.java",
+			code:     "// no code\n",
+			wantName: "Example.java",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			filePath := filepath.Join(dir, tt.fileName)
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			if err := reconcileJavaFileName(filePath); err != nil {
+				t.Fatalf("reconcileJavaFileName() unexpected error = %v", err)
+			}
+			files, _ := filepath.Glob(filepath.Join(dir, "*"))
+			if len(files) != 1 || filepath.Base(files[0]) != tt.wantName {
+				t.Errorf("reconcileJavaFileName() files = %v, want %v", files, tt.wantName)
+			}
+			if code, _ := os.ReadFile(filepath.Join(dir, tt.wantName)); string(code) != tt.code {
+				t.Errorf("reconcileJavaFileName() changed code to %q", code)
+			}
+		})
+	}
+}
+
+// benchmarkJavaCode returns Java code with the package, imports and a public class with a lot of methods
+func benchmarkJavaCode(className string) []byte {
+	var builder strings.Builder
+	builder.WriteString("package org.apache.beam.examples;\n\nimport java.util.List;\nimport java.util.Random;\n\n")
+	builder.WriteString("public class " + className + " {\n")
+	for i := 0; i < 200; i++ {
+		builder.WriteString(fmt.Sprintf("    // method number %d\n    static int method%d(int value) {\n        return value + %d;\n    }\n\n", i, i, i))
+	}
+	builder.WriteString("    public static void main(String[] args) {\n        System.out.println(method1(new Random().nextInt()));\n    }\n}\n")
+	return []byte(builder.String())
+}
+
+// benchmarkPrepareJava applies Java preparers to the code b.N times
+func benchmarkPrepareJava(b *testing.B, isUnitTest bool) {
+	dir := b.TempDir()
+	filePath := filepath.Join(dir, "123e4567.java")
+	code := benchmarkJavaCode("Task")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		_ = os.RemoveAll(dir)
+		_ = os.MkdirAll(dir, 0700)
+		_ = os.WriteFile(filePath, code, 0600)
+		b.StartTimer()
+		builder := NewPreparersBuilder(filePath)
+		GetJavaPreparers(builder, isUnitTest, false)
+		for _, preparer := range *builder.Build().GetPreparers() {
+			if err := preparer.Prepare(preparer.Args...); err != nil {
+				b.Fatalf("Prepare() unexpected error = %v", err)
+			}
+		}
+	}
+}
+
+func BenchmarkPrepareJavaStandard(b *testing.B) {
+	benchmarkPrepareJava(b, false)
+}
+
+func BenchmarkPrepareJavaUnitTest(b *testing.B) {
+	benchmarkPrepareJava(b, true)
+}
@@ -0,0 +1,316 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	kotlinPackagePattern      = `^package\s+(([\w]+\.)*[\w]+)\s*;?\s*$`
+	kotlinImportStringPattern = `import $1.*`
+	kotlinMainFunPattern      = `\bfun\s+main\s*\(\s*(\)?)`
+	kotlinObjectPattern       = `^\s*(?:[\w]+\s+)*object\s+([\w]+)`
+	kotlinMainWithArgs        = "fun main(args: Array<String>) = %s.main(args)"
+	kotlinMainWithoutArgs     = "fun main() = %s.main()"
+	kotlinClassPattern        = `^\s*(?:[\w]+\s+)*(?:class|object)\s+([\w]+)`
+	kotlinJvmNamePattern      = `(?m)^\s*@file\s*:\s*JvmName\s*\(\s*"([\w$]+)"\s*\)`
+	kotlinFileMode            = 0600
+	kotlinSourceFileExtension = ".kt"
+	kotlinDefaultFileName     = "Main"
+	kotlinFacadeClassSuffix   = "Kt"
+)
+
+var (
+	kotlinMainFunRegexp     = regexp.MustCompile(kotlinMainFunPattern)
+	kotlinObjectRegexp      = regexp.MustCompile(kotlinObjectPattern)
+	kotlinClassRegexp       = regexp.MustCompile(kotlinClassPattern)
+	kotlinJvmNameRegexp     = regexp.MustCompile(kotlinJvmNamePattern)
+	kotlinPackageNameRegexp = regexp.MustCompile("(?m)" + kotlinPackagePattern)
+)
+
+// ErrKotlinMainNotFound is returned if none of the Kotlin sources declares a top-level main function
+var ErrKotlinMainNotFound = errors.New("no top-level \"fun main\" function found")
+
+//KotlinPreparersBuilder facet of PreparersBuilder
+type KotlinPreparersBuilder struct {
+	PreparersBuilder
+}
+
+//KotlinPreparers chains to type *PreparersBuilder and returns a *KotlinPreparersBuilder
+func (builder *PreparersBuilder) KotlinPreparers() *KotlinPreparersBuilder {
+	return &KotlinPreparersBuilder{*builder}
+}
+
+//WithPackageChanger adds preparer to change package
+func (builder *KotlinPreparersBuilder) WithPackageChanger() *KotlinPreparersBuilder {
+	changePackagePreparer := Preparer{
+		Name:        PackageChangerName,
+		Prepare:     replace,
+		Args:        []interface{}{builder.filePath, kotlinPackagePattern, kotlinImportStringPattern, builder.logger},
+		Description: "replaced the package declaration with the import of the package",
+	}
+	builder.AddPreparer(changePackagePreparer)
+	return builder
+}
+
+//WithPackageRemover adds preparer to remove package
+func (builder *KotlinPreparersBuilder) WithPackageRemover() *KotlinPreparersBuilder {
+	removePackagePreparer := Preparer{
+		Name:        PackageRemoverName,
+		Prepare:     replace,
+		Args:        []interface{}{builder.filePath, kotlinPackagePattern, newLinePattern, builder.logger},
+		Description: "removed the package declaration",
+	}
+	builder.AddPreparer(removePackagePreparer)
+	return builder
+}
+
+//WithTopLevelMainDetector adds preparer to ensure that code has a top-level main function
+func (builder *KotlinPreparersBuilder) WithTopLevelMainDetector() *KotlinPreparersBuilder {
+	topLevelMainPreparer := Preparer{
+		Name:        TopLevelMainDetectorName,
+		Prepare:     addTopLevelMain,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Description: "added the top-level main function",
+	}
+	builder.AddPreparer(topLevelMainPreparer)
+	return builder
+}
+
+//WithFileNameChanger adds preparer to rename the file after the entry point of the code
+func (builder *KotlinPreparersBuilder) WithFileNameChanger() *KotlinPreparersBuilder {
+	fileNameChanger := Preparer{
+		Name:        FileNameChangerName,
+		Prepare:     changeKotlinFileName,
+		Args:        []interface{}{builder.filePath, builder.logger},
+		Description: "renamed the file after the entry point",
+	}
+	builder.AddPreparer(fileNameChanger)
+	return builder
+}
+
+// GetKotlinPreparers returns preparation methods that should be applied to Kotlin code
+func GetKotlinPreparers(builder *PreparersBuilder, isUnitTest bool, isKata bool) {
+	if !isUnitTest && !isKata {
+		builder.KotlinPreparers().
+			WithPackageChanger().
+			WithTopLevelMainDetector().
+			WithFileNameChanger()
+		builder.warnIfSkipped(TopLevelMainDetectorName, "the code may have no entry point")
+	}
+	if isUnitTest {
+		builder.KotlinPreparers().
+			WithPackageChanger().
+			WithFileNameChanger()
+		builder.warnIfSkipped(PackageChangerName, "the unit test may not be found by the test runner")
+		builder.warnIfSkipped(FileNameChangerName, "the file name may not match the unit test class")
+	}
+	if isKata {
+		builder.KotlinPreparers().
+			WithPackageRemover().
+			WithTopLevelMainDetector().
+			WithFileNameChanger()
+		builder.warnIfSkipped(TopLevelMainDetectorName, "the code may have no entry point")
+	}
+}
+
+// KotlinRegistry returns preparers of Kotlin code which can be skipped or forced by name
+func KotlinRegistry() Registry {
+	return Registry{
+		PackageChangerName:       func(builder *PreparersBuilder) { builder.KotlinPreparers().WithPackageChanger() },
+		PackageRemoverName:       func(builder *PreparersBuilder) { builder.KotlinPreparers().WithPackageRemover() },
+		TopLevelMainDetectorName: func(builder *PreparersBuilder) { builder.KotlinPreparers().WithTopLevelMainDetector() },
+		FileNameChangerName:      func(builder *PreparersBuilder) { builder.KotlinPreparers().WithFileNameChanger() },
+		StdinCheckerName:         func(builder *PreparersBuilder) { builder.KotlinPreparers().WithStdinChecker(false) },
+		LineEndingNormalizerName: func(builder *PreparersBuilder) { builder.WithLineEndingNormalizer(LineEndingLf) },
+		Utf8ValidatorName:        func(builder *PreparersBuilder) { builder.WithUtf8Validator() },
+		IncludeResolverName:      func(builder *PreparersBuilder) { builder.WithIncludeResolver(builder.codeDir()) },
+	}
+}
+
+// addTopLevelMain checks that file by filePath contains a top-level main function.
+// If main function is declared inside an object, adds a top-level main function which delegates to it.
+func addTopLevelMain(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+
+	code, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+
+	isTopLevel, objectName, withoutArgs := findKotlinMain(string(code))
+	if isTopLevel {
+		return nil
+	}
+	if objectName == "" {
+		log.Errorf("Preparation: There is no main function in the file: %s\n", filePath)
+		return errors.New("main function is not found")
+	}
+
+	mainFun := fmt.Sprintf(kotlinMainWithArgs, objectName)
+	if withoutArgs {
+		mainFun = fmt.Sprintf(kotlinMainWithoutArgs, objectName)
+	}
+	code = append(code, []byte(newLinePattern+mainFun+newLinePattern)...)
+	if err = os.WriteFile(filePath, code, kotlinFileMode); err != nil {
+		log.Errorf("Preparation: Error during write \"%s\" to file: %s, err: %s\n", mainFun, filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// findKotlinMain looks for a main function in the code.
+// Returns true if main function is declared at the top level, otherwise returns the name
+// of the top-level object which declares main function and whether this function has no parameters.
+func findKotlinMain(code string) (bool, string, bool) {
+	scanner := bufio.NewScanner(strings.NewReader(code))
+	depth := 0
+	currentObject, mainObject, withoutArgs := "", "", false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if depth == 0 {
+			currentObject = ""
+			if match := kotlinObjectRegexp.FindStringSubmatch(line); match != nil {
+				currentObject = match[1]
+			}
+		}
+		if match := kotlinMainFunRegexp.FindStringSubmatch(line); match != nil {
+			switch {
+			case depth == 0 && currentObject == "":
+				return true, "", false
+			case depth <= 1 && currentObject != "" && mainObject == "":
+				mainObject, withoutArgs = currentObject, match[1] != ""
+			}
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+	}
+	return false, mainObject, withoutArgs
+}
+
+// changeKotlinFileName renames the file by filePath after the entry point of the code if their names differ.
+// The file isn't renamed if another file already has the name of the entry point.
+func changeKotlinFileName(args ...interface{}) error {
+	filePath := args[0].(string)
+	log := loggerFromArgs(args, 1)
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	name := kotlinFileName(string(code))
+	if name == "" || name+kotlinSourceFileExtension == filepath.Base(filePath) {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(filePath), name+kotlinSourceFileExtension)); err == nil {
+		log.Warnf("Preparation: File %s isn't renamed after %s, since the file already exists\n", filepath.Base(filePath), name)
+		return nil
+	}
+	return renameJavaFile(filePath, name)
+}
+
+// kotlinFileName returns the name of the file with the code by its entry point: the top-level class or object
+// which declares main function, kotlinDefaultFileName if main function is declared at the top level only, otherwise the first
+// top-level class or object, e.g. the class of the unit test. Returns an empty string if there is no such declaration.
+func kotlinFileName(code string) string {
+	scanner := bufio.NewScanner(strings.NewReader(code))
+	depth := 0
+	currentClass, firstClass, mainClass, isTopLevel := "", "", "", false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if depth == 0 {
+			currentClass = ""
+			if match := kotlinClassRegexp.FindStringSubmatch(line); match != nil {
+				currentClass = match[1]
+				if firstClass == "" {
+					firstClass = currentClass
+				}
+			}
+		}
+		if kotlinMainFunRegexp.MatchString(line) {
+			switch {
+			case depth == 0 && currentClass == "":
+				isTopLevel = true
+			case depth <= 1 && currentClass != "" && mainClass == "":
+				mainClass = currentClass
+			}
+		}
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+	}
+	switch {
+	case mainClass != "":
+		return mainClass
+	case isTopLevel:
+		return kotlinDefaultFileName
+	default:
+		return firstClass
+	}
+}
+
+// FindKotlinMainClass scans all Kotlin sources in sourceFolder and returns the fully qualified name of the class
+// which is generated for the top-level main function, e.g. MainKt for Main.kt or the name from the JvmName annotation.
+func FindKotlinMainClass(sourceFolder string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(sourceFolder, "*"+kotlinSourceFileExtension))
+	if err != nil {
+		return "", err
+	}
+	var classes []string
+	for _, file := range files {
+		code, err := readSourceFile(file)
+		if err != nil {
+			return "", err
+		}
+		if isTopLevel, _, _ := findKotlinMain(string(code)); isTopLevel {
+			classes = append(classes, kotlinFacadeClassName(string(code), file))
+		}
+	}
+	switch len(classes) {
+	case 0:
+		return "", ErrKotlinMainNotFound
+	case 1:
+		return classes[0], nil
+	default:
+		sort.Strings(classes)
+		return "", &AmbiguousMainClassError{Classes: classes}
+	}
+}
+
+// kotlinFacadeClassName returns the fully qualified name of the class which contains top-level functions of code
+// from the file by filePath
+func kotlinFacadeClassName(code, filePath string) string {
+	packageName := ""
+	if match := kotlinPackageNameRegexp.FindStringSubmatch(code); match != nil {
+		packageName = match[1] + "."
+	}
+	if match := kotlinJvmNameRegexp.FindStringSubmatch(code); match != nil {
+		return packageName + match[1]
+	}
+	name := []rune(strings.TrimSuffix(filepath.Base(filePath), kotlinSourceFileExtension))
+	name[0] = unicode.ToUpper(name[0])
+	return packageName + string(name) + kotlinFacadeClassSuffix
+}
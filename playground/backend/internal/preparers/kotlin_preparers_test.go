@@ -0,0 +1,272 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const kotlinTestFileName = "original.kt"
+
+func Test_replaceKotlinPackage(t *testing.T) {
+	codeWithPackage := "package org.apache.beam.examples\n\nfun main(args: Array<String>) {\n    println(\"Hello World!\")\n}"
+	codeWithPackageAndSemicolon := "package org.apache.beam.examples;\n\nfun main(args: Array<String>) {\n    println(\"Hello World!\")\n}"
+	codeWithImportedPackage := "import org.apache.beam.examples.*\n\nfun main(args: Array<String>) {\n    println(\"Hello World!\")\n}"
+	codeWithoutPackage := "\n\n\nfun main(args: Array<String>) {\n    println(\"Hello World!\")\n}"
+
+	type args struct {
+		code       string
+		newPattern string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantCode string
+	}{
+		{
+			// Test that package without semicolon changes to import all dependencies from this package
+			name:     "change package without semicolon",
+			args:     args{codeWithPackage, kotlinImportStringPattern},
+			wantCode: codeWithImportedPackage,
+		},
+		{
+			name:     "change package with semicolon",
+			args:     args{codeWithPackageAndSemicolon, kotlinImportStringPattern},
+			wantCode: codeWithImportedPackage,
+		},
+		{
+			name:     "remove package without semicolon",
+			args:     args{codeWithPackage, newLinePattern},
+			wantCode: codeWithoutPackage,
+		},
+		{
+			name:     "code without package",
+			args:     args{codeWithImportedPackage, kotlinImportStringPattern},
+			wantCode: codeWithImportedPackage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(kotlinTestFileName, []byte(tt.args.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			defer os.Remove(kotlinTestFileName)
+			if err := replace(kotlinTestFileName, kotlinPackagePattern, tt.args.newPattern); err != nil {
+				t.Errorf("replace() error = %v", err)
+			}
+			data, _ := os.ReadFile(kotlinTestFileName)
+			if string(data) != tt.wantCode {
+				t.Errorf("replace() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_addTopLevelMain(t *testing.T) {
+	topLevelMain := "import org.apache.beam.sdk.Pipeline\n\nfun main(args: Array<String>) {\n    println(\"Hello World!\")\n}\n"
+	objectMain := "object WordCount {\n    @JvmStatic\n    fun main(args: Array<String>) {\n        println(\"Hello World!\")\n    }\n}\n"
+	objectMainWithoutArgs := "object WordCount {\n    fun main() {\n        println(\"Hello World!\")\n    }\n}\n"
+	classWithMain := "class WordCount {\n    fun main(args: Array<String>) {\n        println(\"Hello World!\")\n    }\n}\n"
+	topLevelMainAfterObject := "object Options {\n    val name = \"name\"\n}\n\nfun main() {\n    println(Options.name)\n}\n"
+
+	tests := []struct {
+		name     string
+		code     string
+		wantCode string
+		wantErr  bool
+	}{
+		{
+			name:     "top-level main",
+			code:     topLevelMain,
+			wantCode: topLevelMain,
+			wantErr:  false,
+		},
+		{
+			name:     "top-level main after object",
+			code:     topLevelMainAfterObject,
+			wantCode: topLevelMainAfterObject,
+			wantErr:  false,
+		},
+		{
+			// Test that main inside an object gets a top-level main which delegates to it
+			name:     "object-wrapped main",
+			code:     objectMain,
+			wantCode: objectMain + "\nfun main(args: Array<String>) = WordCount.main(args)\n",
+			wantErr:  false,
+		},
+		{
+			name:     "object-wrapped main without args",
+			code:     objectMainWithoutArgs,
+			wantCode: objectMainWithoutArgs + "\nfun main() = WordCount.main()\n",
+			wantErr:  false,
+		},
+		{
+			name:     "main inside class",
+			code:     classWithMain,
+			wantCode: classWithMain,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(kotlinTestFileName, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			defer os.Remove(kotlinTestFileName)
+			if err := addTopLevelMain(kotlinTestFileName); (err != nil) != tt.wantErr {
+				t.Errorf("addTopLevelMain() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			data, _ := os.ReadFile(kotlinTestFileName)
+			if string(data) != tt.wantCode {
+				t.Errorf("addTopLevelMain() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGetKotlinPreparers(t *testing.T) {
+	type args struct {
+		filePath   string
+		isUnitTest bool
+		isKata     bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "Test number of preparers for code",
+			args: args{"MOCK_FILEPATH", false, false},
+			want: 3,
+		},
+		{
+			name: "Test number of preparers for unit test",
+			args: args{"MOCK_FILEPATH", true, false},
+			want: 2,
+		},
+		{
+			name: "Test number of preparers for kata",
+			args: args{"MOCK_FILEPATH", false, true},
+			want: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewPreparersBuilder(tt.args.filePath)
+			GetKotlinPreparers(builder, tt.args.isUnitTest, tt.args.isKata)
+			if got := builder.Build().GetPreparers(); len(*got) != tt.want {
+				t.Errorf("GetKotlinPreparers() returns %v Preparers, want %v", len(*got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_changeKotlinFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		wantName string
+	}{
+		{
+			name:     "top-level main",
+			code:     "import org.apache.beam.sdk.Pipeline\n\nfun main(args: Array<String>) {\n    println(\"Hello World!\")\n}\n",
+			wantName: "Main.kt",
+		},
+		{
+			// Test that the object is preferred to the top-level main function which delegates to it
+			name:     "object with main",
+			code:     "object WordCount {\n    @JvmStatic\n    fun main(args: Array<String>) {\n        println(\"Hello World!\")\n    }\n}\n\nfun main(args: Array<String>) = WordCount.main(args)\n",
+			wantName: "WordCount.kt",
+		},
+		{
+			name:     "unit test",
+			code:     "import kotlin.test.Test\n\nclass TaskTest {\n    @Test\n    fun test() {}\n}\n",
+			wantName: "TaskTest.kt",
+		},
+		{
+			name:     "without declarations",
+			code:     "val x = 1\n",
+			wantName: "original.kt",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			filePath := filepath.Join(dir, kotlinTestFileName)
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			if err := changeKotlinFileName(filePath); err != nil {
+				t.Fatalf("changeKotlinFileName() unexpected error = %v", err)
+			}
+			files, _ := filepath.Glob(filepath.Join(dir, "*"))
+			if len(files) != 1 || filepath.Base(files[0]) != tt.wantName {
+				t.Errorf("changeKotlinFileName() files = %v, want %v", files, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFindKotlinMainClass(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "top-level main",
+			files: map[string]string{"Main.kt": "fun main() {\n    println(\"Hello World!\")\n}\n"},
+			want:  "MainKt",
+		},
+		{
+			// Test that the package is kept since the package remover and the package changer may be skipped
+			name:  "main with package and file name in lower case",
+			files: map[string]string{"wordCount.kt": "package org.apache.beam.examples\n\nfun main() {}\n"},
+			want:  "org.apache.beam.examples.WordCountKt",
+		},
+		{
+			name:  "main with JvmName",
+			files: map[string]string{"Main.kt": "@file:JvmName(\"WordCount\")\n\nfun main() {}\n"},
+			want:  "WordCount",
+		},
+		{
+			name:    "main in object only",
+			files:   map[string]string{"WordCount.kt": "object WordCount {\n    @JvmStatic\n    fun main(args: Array<String>) {}\n}\n"},
+			wantErr: ErrKotlinMainNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, code := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(code), 0600); err != nil {
+					t.Fatalf("error during test setup: %s", err.Error())
+				}
+			}
+			got, err := FindKotlinMainClass(dir)
+			if err != tt.wantErr {
+				t.Fatalf("FindKotlinMainClass() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("FindKotlinMainClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
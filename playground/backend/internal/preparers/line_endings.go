@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Styles of line endings which are supported by WithLineEndingNormalizer
+const (
+	LineEndingLf   = "lf"
+	LineEndingCrlf = "crlf"
+)
+
+var (
+	crlf = []byte("\r\n")
+	cr   = []byte("\r")
+	lf   = []byte("\n")
+)
+
+//WithLineEndingNormalizer adds preparer to convert all line endings of the code to the style (LineEndingLf or LineEndingCrlf).
+//The preparer is always applied after all other preparers, so the prepared code doesn't depend on the platform of the user.
+func (builder *PreparersBuilder) WithLineEndingNormalizer(style string) *PreparersBuilder {
+	lineEndingNormalizer := Preparer{
+		Name:    LineEndingNormalizerName,
+		Prepare: normalizeLineEndings,
+		Args:    []interface{}{builder.filePath, style, builder.logger},
+	}
+	builder.AddPreparer(lineEndingNormalizer)
+	return builder
+}
+
+// normalizeLineEndings converts line endings of all files with the extension of the file by filePath in its folder,
+// so files which are renamed or created by previous preparers are normalized as well
+func normalizeLineEndings(args ...interface{}) error {
+	filePath := args[0].(string)
+	style := args[1].(string)
+	log := loggerFromArgs(args, 2)
+
+	if style != LineEndingLf && style != LineEndingCrlf {
+		return fmt.Errorf("unsupported line ending style: %s, supported styles: %s, %s", style, LineEndingLf, LineEndingCrlf)
+	}
+	files, err := sourceFiles(filePath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		code, err := os.ReadFile(file)
+		if err != nil {
+			log.Errorf("Preparation: Error during open file: %s, err: %s\n", file, err.Error())
+			return err
+		}
+		normalized := convertLineEndings(code, style)
+		if bytes.Equal(code, normalized) {
+			continue
+		}
+		if err = writeKeepingMode(file, normalized); err != nil {
+			log.Errorf("Preparation: Error during write file: %s, err: %s\n", file, err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// sourceFiles returns paths of all files with the extension of the file by filePath in its folder
+func sourceFiles(filePath string) ([]string, error) {
+	return filepath.Glob(filepath.Join(filepath.Dir(filePath), "*"+filepath.Ext(filePath)))
+}
+
+// convertLineEndings replaces CRLF, CR and LF line endings of the code with line endings of the style
+func convertLineEndings(code []byte, style string) []byte {
+	code = bytes.ReplaceAll(code, crlf, lf)
+	code = bytes.ReplaceAll(code, cr, lf)
+	if style == LineEndingCrlf {
+		code = bytes.ReplaceAll(code, lf, crlf)
+	}
+	return code
+}
@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_normalizeLineEndings(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		style   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "CRLF to LF",
+			code:    "class Class {\r\n    int i;\r\n}\r\n",
+			style:   LineEndingLf,
+			want:    "class Class {\n    int i;\n}\n",
+			wantErr: false,
+		},
+		{
+			name:    "LF to CRLF",
+			code:    "class Class {\n    int i;\n}\n",
+			style:   LineEndingCrlf,
+			want:    "class Class {\r\n    int i;\r\n}\r\n",
+			wantErr: false,
+		},
+		{
+			// Test that mixed line endings and line endings of classic Mac OS are converted
+			name:    "Mixed to LF",
+			code:    "class Class {\r    int i;\r\n    int j;\n}",
+			style:   LineEndingLf,
+			want:    "class Class {\n    int i;\n    int j;\n}",
+			wantErr: false,
+		},
+		{
+			name:    "Mixed to CRLF",
+			code:    "class Class {\r    int i;\r\n    int j;\n}",
+			style:   LineEndingCrlf,
+			want:    "class Class {\r\n    int i;\r\n    int j;\r\n}",
+			wantErr: false,
+		},
+		{
+			name:    "Unsupported style",
+			code:    "class Class {\n}\n",
+			style:   "cr",
+			want:    "class Class {\n}\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			filePath := filepath.Join(dir, "Class.java")
+			// the file created by another preparer should be normalized as well
+			otherPath := filepath.Join(dir, "Other.java")
+			for _, path := range []string{filePath, otherPath} {
+				if err := os.WriteFile(path, []byte(tt.code), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// the second run checks that normalization is idempotent
+			for run := 1; run <= 2; run++ {
+				err := normalizeLineEndings(filePath, tt.style)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("normalizeLineEndings() run %d error = %v, wantErr %v", run, err, tt.wantErr)
+				}
+				for _, path := range []string{filePath, otherPath} {
+					got, err := os.ReadFile(path)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if string(got) != tt.want {
+						t.Errorf("normalizeLineEndings() run %d %s = %q, want %q", run, filepath.Base(path), string(got), tt.want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPreparersBuilder_WithLineEndingNormalizer(t *testing.T) {
+	builder := NewPreparersBuilder("Class.java")
+	builder.WithLineEndingNormalizer(LineEndingLf)
+	builder.JavaPreparers().WithSeedInjector().WithClassSplitter()
+	prepared := *builder.Build().GetPreparers()
+	if got := prepared[len(prepared)-1].Name; got != LineEndingNormalizerName {
+		t.Errorf("Build() last preparer = %s, want %s", got, LineEndingNormalizerName)
+	}
+	if len(prepared) != 3 {
+		t.Errorf("Build() built %d preparers, want 3", len(prepared))
+	}
+}
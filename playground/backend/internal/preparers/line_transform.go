@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// LineTransform changes a single line of the file with code.
+// Preparers which only need to change code line by line provide LineTransform,
+// so a chain of them is applied with a single read/write/rename of the file.
+type LineTransform func(line string) (string, error)
+
+// errLineDeleted is returned by LineTransform to delete the line from the file together with its line break.
+// Other transforms aren't applied to the deleted line.
+var errLineDeleted = errors.New("the line is deleted")
+
+// compiledPatterns caches compiled patterns of replaceTransform, since preparers are built for each run
+var compiledPatterns sync.Map
+
+// writers are reused by writeWithTransforms to reduce allocations for each run
+var writers = sync.Pool{New: func() interface{} { return bufio.NewWriter(nil) }}
+
+// compilePattern returns compiled pattern from the cache or compiles it and adds to the cache
+func compilePattern(pattern string) *regexp.Regexp {
+	if reg, ok := compiledPatterns.Load(pattern); ok {
+		return reg.(*regexp.Regexp)
+	}
+	reg, _ := compiledPatterns.LoadOrStore(pattern, regexp.MustCompile(pattern))
+	return reg.(*regexp.Regexp)
+}
+
+// replaceTransform returns LineTransform which replaces all patterns in the line to newPattern.
+// Lines without the pattern are returned as is without copying.
+func replaceTransform(pattern, newPattern string) LineTransform {
+	reg := compilePattern(pattern)
+	return func(line string) (string, error) {
+		if !reg.MatchString(line) {
+			return line, nil
+		}
+		return reg.ReplaceAllString(line, newPattern), nil
+	}
+}
+
+// composeLineTransforms replaces each run of consecutive preparers with LineTransform by a single preparer
+// which applies all transforms of the run in one pass over the file
+func composeLineTransforms(filePath string, functions []Preparer, log *logger.Entry) []Preparer {
+	composed := make([]Preparer, 0, len(functions))
+	for i := 0; i < len(functions); {
+		if functions[i].Transform == nil {
+			composed = append(composed, functions[i])
+			i++
+			continue
+		}
+		j := i
+		var names []string
+		var transforms []LineTransform
+		for ; j < len(functions) && functions[j].Transform != nil; j++ {
+			names = append(names, functions[j].Name)
+			transforms = append(transforms, functions[j].Transform)
+		}
+		composed = append(composed, Preparer{
+			Name:    strings.Join(names, ","),
+			Prepare: transformFile,
+			Args:    []interface{}{filePath, transforms, log},
+		})
+		i = j
+	}
+	return composed
+}
+
+// transformFile processes file by filePath and applies all transforms to each line of the file.
+// The byte order mark is removed and UTF-16 code is transcoded, so the file is written as UTF-8.
+func transformFile(args ...interface{}) error {
+	filePath := args[0].(string)
+	transforms := args[1].([]LineTransform)
+	log := loggerFromArgs(args, 2)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+
+	tmp, err := createTempFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during create new temporary file, err: %s\n", err.Error())
+		return err
+	}
+	defer tmp.Close()
+
+	err = writeWithTransforms(string(code), tmp, transforms, log)
+	if err != nil {
+		log.Errorf("Preparation: Error during write data to tmp file, err: %s\n", err.Error())
+		// the original file is kept as is, so the partially written temporary file isn't needed
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	// replace original file with temporary file with renaming
+	if err = replaceWithTempFile(tmp, filePath); err != nil {
+		log.Errorf("Preparation: Error during rename temporary file, err: %s\n", err.Error())
+		return err
+	}
+	return nil
+}
+
+// writeWithTransforms rewrites all lines of the code with applying all transforms to the file.
+// Lines are sliced from the code without copying, as bufio.ScanLines does, carriage returns are dropped.
+func writeWithTransforms(code string, to io.Writer, transforms []LineTransform, log *logger.Entry) error {
+	writer := writers.Get().(*bufio.Writer)
+	writer.Reset(to)
+	defer func() {
+		writer.Reset(nil)
+		writers.Put(writer)
+	}()
+
+	// uses to indicate when need to add new line to tmp file
+	newLine := false
+	for len(code) > 0 {
+		line := code
+		if i := strings.IndexByte(code, '\n'); i >= 0 {
+			line, code = code[:i], code[i+1:]
+		} else {
+			code = ""
+		}
+		line = strings.TrimSuffix(line, "\r")
+		written, err := transformAndWriteLine(newLine, writer, line, transforms, log)
+		if err != nil {
+			log.Errorf("Preparation: Error during write \"%s\" to tmp file, err: %s\n", line, err.Error())
+			return err
+		}
+		newLine = newLine || written
+	}
+	return writer.Flush()
+}
+
+// transformAndWriteLine applies all transforms to the line and writes updated line to the writer.
+// Returns false if the line is deleted by one of transforms and nothing is written.
+func transformAndWriteLine(newLine bool, to *bufio.Writer, line string, transforms []LineTransform, log *logger.Entry) (bool, error) {
+	var err error
+	for _, transform := range transforms {
+		if line, err = transform(line); err == errLineDeleted {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	if newLine {
+		if err = to.WriteByte('\n'); err != nil {
+			log.Errorf("Preparation: Error during write \"%s\" to tmp file, err: %s\n", newLinePattern, err.Error())
+			return false, err
+		}
+	}
+	if _, err = to.WriteString(line); err != nil {
+		log.Errorf("Preparation: Error during write \"%s\" to tmp file, err: %s\n", line, err.Error())
+		return false, err
+	}
+	return true, nil
+}
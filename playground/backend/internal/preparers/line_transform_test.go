@@ -0,0 +1,181 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const lineTransformTestFileName = "LineTransform.java"
+
+var lineTransformTestCode = "package org.apache.beam.examples;\n\npublic class Class {\n    public static void main(String[] args) {\n        System.out.println(\"Hello World!\");\n    }\n}\n"
+
+// runChained applies each preparer separately the same way as it was done before merging of line transforms
+func runChained(functions []Preparer) error {
+	for _, preparer := range functions {
+		if err := preparer.Prepare(preparer.Args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runSinglePass applies preparers built by the builder with merged line transforms
+func runSinglePass(builder *PreparersBuilder) error {
+	return runChained(*builder.Build().GetPreparers())
+}
+
+func Test_composeLineTransforms(t *testing.T) {
+	tests := []struct {
+		name  string
+		kata  bool
+		tests bool
+	}{
+		{name: "code preparers", kata: false, tests: false},
+		{name: "unit test preparers", kata: false, tests: true},
+		{name: "kata preparers", kata: true, tests: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lineTransformTestFile := filepath.Join(t.TempDir(), lineTransformTestFileName)
+			if err := os.WriteFile(lineTransformTestFile, []byte(lineTransformTestCode), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(lineTransformTestFile)
+			GetJavaPreparers(builder, tt.tests, tt.kata)
+			functions := *builder.preparers.functions
+			if tt.tests {
+				// file name changer renames the file, so only line transforms are compared
+				functions = functions[:1]
+			}
+			if !tt.tests && !tt.kata {
+				// file name reconciler renames the file, so it isn't compared
+				functions = functions[:len(functions)-1]
+			}
+			if err := runChained(functions); err != nil {
+				t.Fatalf("chained preparers unexpected error = %v", err)
+			}
+			wantCode, _ := os.ReadFile(lineTransformTestFile)
+
+			if err := os.WriteFile(lineTransformTestFile, []byte(lineTransformTestCode), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			if err := runChained(composeLineTransforms(lineTransformTestFile, functions, nil)); err != nil {
+				t.Fatalf("single pass preparers unexpected error = %v", err)
+			}
+			gotCode, _ := os.ReadFile(lineTransformTestFile)
+			if string(gotCode) != string(wantCode) {
+				t.Errorf("single pass code = {%v}, chained code {%v}", string(gotCode), string(wantCode))
+			}
+		})
+	}
+}
+
+func Test_transformFile(t *testing.T) {
+	errTransform := errors.New("transform error")
+	tests := []struct {
+		name       string
+		transforms []LineTransform
+		wantCode   string
+		wantErr    bool
+	}{
+		{
+			name: "transforms are applied in order",
+			transforms: []LineTransform{
+				replaceTransform("public class ", "class "),
+				replaceTransform("class Class", "class Main"),
+			},
+			wantCode: strings.TrimSuffix(strings.Replace(lineTransformTestCode, "public class Class", "class Main", 1), "\n"),
+		},
+		{
+			// Test that the deleted line is removed together with its line break
+			name: "transform deletes lines",
+			transforms: []LineTransform{
+				removePackageTransform,
+				func(line string) (string, error) {
+					if line == "" {
+						return "", errLineDeleted
+					}
+					return line, nil
+				},
+			},
+			wantCode: strings.TrimSuffix(strings.TrimPrefix(lineTransformTestCode, "package org.apache.beam.examples;\n\n"), "\n"),
+		},
+		{
+			name: "transform returns error",
+			transforms: []LineTransform{
+				func(line string) (string, error) { return "", errTransform },
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lineTransformTestFile := filepath.Join(t.TempDir(), lineTransformTestFileName)
+			if err := os.WriteFile(lineTransformTestFile, []byte(lineTransformTestCode), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			err := transformFile(lineTransformTestFile, tt.transforms)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("transformFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			data, _ := os.ReadFile(lineTransformTestFile)
+			if string(data) != tt.wantCode {
+				t.Errorf("transformFile() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+			}
+		})
+	}
+}
+
+func benchmarkCode(lines int) []byte {
+	var builder strings.Builder
+	builder.WriteString("package org.apache.beam.examples;\n\npublic class Class {\n")
+	for i := 0; i < lines; i++ {
+		builder.WriteString(fmt.Sprintf("    public class Inner%d {}\n", i))
+	}
+	builder.WriteString("}\n")
+	return []byte(builder.String())
+}
+
+func Benchmark_ChainedPreparers(b *testing.B) {
+	code := benchmarkCode(1000)
+	lineTransformTestFile := filepath.Join(b.TempDir(), lineTransformTestFileName)
+	builder := NewPreparersBuilder(lineTransformTestFile)
+	GetJavaPreparers(builder, false, false)
+	for i := 0; i < b.N; i++ {
+		_ = os.WriteFile(lineTransformTestFile, code, 0600)
+		_ = runChained(*builder.preparers.functions)
+	}
+}
+
+func Benchmark_SinglePassPreparers(b *testing.B) {
+	code := benchmarkCode(1000)
+	lineTransformTestFile := filepath.Join(b.TempDir(), lineTransformTestFileName)
+	builder := NewPreparersBuilder(lineTransformTestFile)
+	GetJavaPreparers(builder, false, false)
+	for i := 0; i < b.N; i++ {
+		_ = os.WriteFile(lineTransformTestFile, code, 0600)
+		_ = runSinglePass(builder)
+	}
+}
@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	// DefaultOutputPlaceholder is the token of string literals which is replaced with the output directory of the run
+	DefaultOutputPlaceholder = "<OUTPUT_DIR>"
+	outputDirMode            = 0700
+	pathSeparator            = "/"
+)
+
+var goLiteralSyntax = literalSyntax{lineComment: "//", blockComment: true, quotes: "\"`"}
+
+// outputPathRewriter redirects outputs of the code to realDir.
+// placeholder is replaced in any string literal, paths are replaced only if the literal is the path or its subpath.
+type outputPathRewriter struct {
+	placeholder string
+	realDir     string
+	paths       []string
+	syntax      literalSyntax
+}
+
+//WithOutputPathRewriter adds preparer to replace the placeholder and paths in string literals with realDir
+func (builder *JavaPreparersBuilder) WithOutputPathRewriter(placeholder, realDir string, paths ...string) *JavaPreparersBuilder {
+	builder.AddPreparer(outputPathRewriterPreparer(&builder.PreparersBuilder, outputPathRewriter{placeholder: placeholder, realDir: realDir, paths: paths, syntax: javaLiteralSyntax}))
+	return builder
+}
+
+//WithOutputPathRewriter adds preparer to replace the placeholder and paths in string literals with realDir
+func (builder *PythonPreparersBuilder) WithOutputPathRewriter(placeholder, realDir string, paths ...string) *PythonPreparersBuilder {
+	builder.AddPreparer(outputPathRewriterPreparer(&builder.PreparersBuilder, outputPathRewriter{placeholder: placeholder, realDir: realDir, paths: paths, syntax: pythonLiteralSyntax}))
+	return builder
+}
+
+//WithOutputPathRewriter adds preparer to replace the placeholder and paths in string literals with realDir
+func (builder *GoPreparersBuilder) WithOutputPathRewriter(placeholder, realDir string, paths ...string) *GoPreparersBuilder {
+	builder.AddPreparer(outputPathRewriterPreparer(&builder.PreparersBuilder, outputPathRewriter{placeholder: placeholder, realDir: realDir, paths: paths, syntax: goLiteralSyntax}))
+	return builder
+}
+
+// outputPathRewriterPreparer returns preparer which applies the rewriter to the code of the builder
+func outputPathRewriterPreparer(builder *PreparersBuilder, rewriter outputPathRewriter) Preparer {
+	return Preparer{
+		Name:        OutputPathRewriterName,
+		Prepare:     rewriteOutputPaths,
+		Args:        []interface{}{builder.filePath, rewriter, builder.logger},
+		Description: "redirected outputs to the directory of the run",
+	}
+}
+
+// rewriteOutputPaths processes file by filePath and redirects outputs of the code to the directory of the rewriter.
+// The directory is created if at least one output is redirected.
+func rewriteOutputPaths(args ...interface{}) error {
+	filePath := args[0].(string)
+	rewriter := args[1].(outputPathRewriter)
+	log := loggerFromArgs(args, 2)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	rewritten := rewriter.rewrite(string(code))
+	if rewritten == string(code) {
+		return nil
+	}
+	if err = os.MkdirAll(rewriter.realDir, outputDirMode); err != nil {
+		log.Errorf("Preparation: Error during create output directory: %s, err: %s\n", rewriter.realDir, err.Error())
+		return err
+	}
+	if err = writeKeepingMode(filePath, []byte(rewritten)); err != nil {
+		log.Errorf("Preparation: Error during write file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	return nil
+}
+
+// rewrite returns code with redirected outputs. Comments and code outside of string literals are kept.
+func (rewriter *outputPathRewriter) rewrite(code string) string {
+	_, literals := scanLiterals(code, rewriter.syntax)
+	var edits []codeEdit
+	for _, literal := range literals {
+		value, ok := rewriter.rewriteLiteral(literal.value)
+		if !ok {
+			continue
+		}
+		delimiter := (literal.end - literal.start - len(literal.value)) / 2
+		edits = append(edits, codeEdit{start: literal.start + delimiter, end: literal.end - delimiter, text: value})
+	}
+	return applyEdits(code, edits)
+}
+
+// rewriteLiteral returns the value of the literal with replaced placeholder or the path
+// which the value starts with. Paths aren't replaced as parts of other paths, e.g. /tmp/output in /tmp/outputs.
+func (rewriter *outputPathRewriter) rewriteLiteral(value string) (string, bool) {
+	if rewriter.placeholder != "" && strings.Contains(value, rewriter.placeholder) {
+		return strings.ReplaceAll(value, rewriter.placeholder, rewriter.realDir), true
+	}
+	for _, path := range rewriter.paths {
+		path = strings.TrimSuffix(path, pathSeparator)
+		if path != "" && (value == path || strings.HasPrefix(value, path+pathSeparator)) {
+			return rewriter.realDir + strings.TrimPrefix(value, path), true
+		}
+	}
+	return "", false
+}
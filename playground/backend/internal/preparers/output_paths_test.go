@@ -0,0 +1,117 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_rewriteOutputPaths(t *testing.T) {
+	outputPaths := []string{"/tmp/output", "/tmp/results/"}
+	tests := []struct {
+		name                string
+		fileName            string
+		code                string
+		want                string
+		wantTransformations []string
+	}{
+		{
+			name:                "placeholder",
+			fileName:            "Main.java",
+			code:                "p.apply(TextIO.write().to(\"<OUTPUT_DIR>/counts\"));\n",
+			want:                "p.apply(TextIO.write().to(\"%[1]s/counts\"));\n",
+			wantTransformations: []string{"redirected outputs to the directory of the run"},
+		},
+		{
+			// Test that the placeholder is replaced inside of f-strings and raw strings of Python
+			name:                "placeholder in Python strings",
+			fileName:            "main.py",
+			code:                "counts | beam.io.WriteToText(f'<OUTPUT_DIR>/{name}')\ncounts | beam.io.WriteToText(r\"<OUTPUT_DIR>\")\n",
+			want:                "counts | beam.io.WriteToText(f'%[1]s/{name}')\ncounts | beam.io.WriteToText(r\"%[1]s\")\n",
+			wantTransformations: []string{"redirected outputs to the directory of the run"},
+		},
+		{
+			name:                "placeholder in Go raw string",
+			fileName:            "main.go",
+			code:                "textio.Write(s, `<OUTPUT_DIR>/counts.txt`, counts) // writes to <OUTPUT_DIR>\n",
+			want:                "textio.Write(s, `%[1]s/counts.txt`, counts) // writes to <OUTPUT_DIR>\n",
+			wantTransformations: []string{"redirected outputs to the directory of the run"},
+		},
+		{
+			// Test that configured paths are replaced as whole paths or their subpaths
+			name:                "configured paths",
+			fileName:            "main.py",
+			code:                "counts | beam.io.WriteToText('/tmp/output')\ncounts | beam.io.WriteToText('/tmp/results/part')\n",
+			want:                "counts | beam.io.WriteToText('%[1]s')\ncounts | beam.io.WriteToText('%[1]s/part')\n",
+			wantTransformations: []string{"redirected outputs to the directory of the run"},
+		},
+		{
+			// Test that string literals which only look like configured paths are kept
+			name:     "unrelated string literals",
+			fileName: "Main.java",
+			code:     "String other = \"/tmp/outputs\";\nString text = \"see /tmp/output\";\nString placeholder = \"<OUTPUT>\";\n",
+			want:     "String other = \"/tmp/outputs\";\nString text = \"see /tmp/output\";\nString placeholder = \"<OUTPUT>\";\n",
+		},
+		{
+			name:     "paths in comments",
+			fileName: "Main.java",
+			code:     "// \"<OUTPUT_DIR>/counts\"\n/* \"/tmp/output\" */\nint count = 0;\n",
+			want:     "// \"<OUTPUT_DIR>/counts\"\n/* \"/tmp/output\" */\nint count = 0;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			outputDir := filepath.Join(dir, "output")
+			filePath := filepath.Join(dir, tt.fileName)
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(filePath)
+			switch filepath.Ext(tt.fileName) {
+			case javaSourceFileExtension:
+				builder.JavaPreparers().WithOutputPathRewriter(DefaultOutputPlaceholder, outputDir, outputPaths...)
+			case ".go":
+				builder.GoPreparers().WithOutputPathRewriter(DefaultOutputPlaceholder, outputDir, outputPaths...)
+			default:
+				builder.PythonPreparers().WithOutputPathRewriter(DefaultOutputPlaceholder, outputDir, outputPaths...)
+			}
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if err := preparer.Prepare(preparer.Args...); err != nil {
+					t.Fatalf("rewriteOutputPaths() unexpected error = %v", err)
+				}
+			}
+			want := tt.want
+			if tt.wantTransformations != nil {
+				want = fmt.Sprintf(tt.want, outputDir)
+			}
+			if got, _ := os.ReadFile(filePath); string(got) != want {
+				t.Errorf("rewriteOutputPaths() code = %q, want %q", got, want)
+			}
+			if got := builder.Summary().Transformations; !reflect.DeepEqual(got, tt.wantTransformations) {
+				t.Errorf("rewriteOutputPaths() transformations = %v, want %v", got, tt.wantTransformations)
+			}
+			// Test that the output directory is created only if outputs are redirected
+			if _, err := os.Stat(outputDir); (err == nil) != (tt.wantTransformations != nil) {
+				t.Errorf("rewriteOutputPaths() output directory exists = %t, want %t", err == nil, tt.wantTransformations != nil)
+			}
+		})
+	}
+}
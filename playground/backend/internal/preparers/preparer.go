@@ -15,10 +15,111 @@
 
 package preparers
 
+import (
+	"beam.apache.org/playground/backend/internal/logger"
+	"fmt"
+	"sort"
+)
+
+// Names of preparers which are used to skip or force them for the code processing
+const (
+	PublicClassRemoverName     = "public_class_remover"
+	PackageChangerName         = "package_changer"
+	PackageRemoverName         = "package_remover"
+	FileNameChangerName        = "file_name_changer"
+	ForbiddenApiGuardName      = "forbidden_api_guard"
+	SeedInjectorName           = "seed_injector"
+	ClassSplitterName          = "class_splitter"
+	OutputCaptureName          = "output_capture"
+	BuildDirectiveStripperName = "build_directive_stripper"
+	UnicodeEscapeDecoderName   = "unicode_escape_decoder"
+	PlaceholderSubstitutorName = "placeholder_substitutor"
+	DefaultOptionsInjectorName = "default_options_injector"
+	ModuleInfoRejectorName     = "module_info_rejector"
+	ModuleInfoStripperName     = "module_info_stripper"
+	FragmentWrapperName        = "fragment_wrapper"
+	CodeFormatterName          = "code_formatter"
+	LogHandlerName             = "log_handler"
+	TopLevelMainDetectorName   = "top_level_main_detector"
+	LineEndingNormalizerName   = "line_ending_normalizer"
+	GzipDecompressorName       = "gzip_decompressor"
+	FileNameReconcilerName     = "file_name_reconciler"
+	IoSubstitutorName          = "io_substitutor"
+	LoopGuardName              = "loop_guard"
+	TestClassCheckerName       = "test_class_checker"
+	OutputPathRewriterName     = "output_path_rewriter"
+	StdinCheckerName           = "stdin_checker"
+	Utf8ValidatorName          = "utf8_validator"
+	IncludeResolverName        = "include_resolver"
+)
+
+// requiredPreparers are preparers which enforce the policy of the playground, so they can't be skipped
+var requiredPreparers = map[string]bool{
+	ForbiddenApiGuardName: true,
+}
+
 // Preparer is used to make preparations with file with code.
 type Preparer struct {
+	Name    string
 	Prepare func(args ...interface{}) error
 	Args    []interface{}
+	// Transform is set if the preparer only changes code line by line.
+	// Consecutive preparers with Transform are merged into one pass over the file by Build.
+	Transform LineTransform
+	// Description is added to RunSummary if the preparer changes the code
+	Description string
+	// Pattern is the regular expression of the code which is changed or checked by the preparer, if there is one
+	Pattern string
+}
+
+// PreparerDescriptor is a serializable description of a preparer of the chain
+type PreparerDescriptor struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern,omitempty"`
+	Order   int    `json:"order"`
+}
+
+// Overrides contains names of preparers which should be skipped or force-enabled for the code processing
+type Overrides struct {
+	Skip  []string
+	Force []string
+}
+
+// Registry maps names of preparers of the sdk to functions which add these preparers to the builder
+type Registry map[string]func(builder *PreparersBuilder)
+
+// Validate checks that all preparers from overrides are known, required preparers aren't skipped
+// and none of preparers is both skipped and forced
+func (registry Registry) Validate(overrides Overrides) error {
+	skipped := make(map[string]bool, len(overrides.Skip))
+	for _, name := range overrides.Skip {
+		if _, ok := registry[name]; !ok {
+			return fmt.Errorf("unknown preparer: %s, known preparers: %v", name, registry.names())
+		}
+		if requiredPreparers[name] {
+			return fmt.Errorf("preparer %s can't be skipped", name)
+		}
+		skipped[name] = true
+	}
+	for _, name := range overrides.Force {
+		if _, ok := registry[name]; !ok {
+			return fmt.Errorf("unknown preparer: %s, known preparers: %v", name, registry.names())
+		}
+		if skipped[name] {
+			return fmt.Errorf("preparer %s can't be both skipped and forced", name)
+		}
+	}
+	return nil
+}
+
+// names returns sorted names of all preparers of the registry
+func (registry Registry) names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 type Preparers struct {
@@ -33,18 +134,151 @@ func (preparers *Preparers) GetPreparers() *[]Preparer {
 type PreparersBuilder struct {
 	preparers *Preparers
 	filePath  string
+	logger    *logger.Entry
+	skipped   map[string]bool
+	summary   *RunSummary
+	sourceMap *sourceMapState
 }
 
 //NewPreparersBuilder constructor for PreparersBuilder
 func NewPreparersBuilder(filePath string) *PreparersBuilder {
-	return &PreparersBuilder{preparers: &Preparers{functions: &[]Preparer{}}, filePath: filePath}
+	return &PreparersBuilder{preparers: &Preparers{functions: &[]Preparer{}}, filePath: filePath, skipped: map[string]bool{}, summary: &RunSummary{}}
+}
+
+//WithSkipped sets names of preparers which are not added to the builder
+func (builder *PreparersBuilder) WithSkipped(names []string) *PreparersBuilder {
+	for _, name := range names {
+		builder.skipped[name] = true
+	}
+	return builder
+}
+
+//WithForced adds preparers with received names from registry if they are not added yet
+func (builder *PreparersBuilder) WithForced(registry Registry, names []string) *PreparersBuilder {
+	for _, name := range names {
+		if add, ok := registry[name]; ok && !builder.contains(name) {
+			add(builder)
+		}
+	}
+	return builder
 }
 
-//Build builds preparers from PreparersBuilder
+//Warnings returns warnings about skipped preparers which are required by the code processing
+func (builder *PreparersBuilder) Warnings() []string {
+	return builder.summary.Warnings
+}
+
+//Summary returns the summary of changes of the code which is filled while built preparers are applied
+func (builder *PreparersBuilder) Summary() *RunSummary {
+	return builder.summary
+}
+
+//WithLogger sets the contextual logger of the code processing which is passed to preparers
+func (builder *PreparersBuilder) WithLogger(log *logger.Entry) *PreparersBuilder {
+	builder.logger = log
+	return builder
+}
+
+//Build builds preparers from PreparersBuilder merging consecutive line transforms into a single preparer.
+//Built preparers add descriptions of their changes and warnings about the code to the summary of the builder.
 func (builder *PreparersBuilder) Build() *Preparers {
-	return builder.preparers
+	summarized := make([]Preparer, 0, len(*builder.preparers.functions))
+	for _, preparer := range orderPreparers(*builder.preparers.functions) {
+		if preparer.Description != "" {
+			builder.summary.register(preparer.Description)
+		}
+		switch {
+		case preparer.Description == "":
+		case preparer.Transform != nil:
+			preparer.Transform = summarizeTransform(preparer.Transform, preparer.Description, builder.summary)
+		default:
+			preparer = summarizePreparer(preparer, builder.filePath, builder.summary)
+		}
+		if preparer.Transform == nil {
+			preparer = collectWarnings(preparer, builder.summary)
+		}
+		summarized = append(summarized, preparer)
+	}
+	functions := builder.withSourceMap(composeLineTransforms(builder.filePath, summarized, builder.logger))
+	return &Preparers{functions: &functions}
+}
+
+//Describe returns descriptors of added preparers in the order they are applied to the code by built preparers.
+//Line transforms which are merged by Build are described separately.
+func (builder *PreparersBuilder) Describe() []PreparerDescriptor {
+	ordered := orderPreparers(*builder.preparers.functions)
+	descriptors := make([]PreparerDescriptor, 0, len(ordered))
+	for i, preparer := range ordered {
+		descriptors = append(descriptors, PreparerDescriptor{Name: preparer.Name, Pattern: preparer.Pattern, Order: i})
+	}
+	return descriptors
 }
 
+// orderPreparers returns preparers in the same order except preparers which should be applied first or last.
+// The gzip decompressor is moved to the start, since other preparers work with the decompressed code.
+// The UTF-8 validator follows it, since other preparers can garble code which isn't valid UTF-8.
+// The include resolver is applied next, so included code is prepared the same way as the code itself.
+// The line ending normalizer is moved to the end, since line endings of the prepared code
+// shouldn't be changed by other preparers.
+// The file name reconciler is applied after other preparers of the code, since they refer to the file by its original name.
+func orderPreparers(functions []Preparer) []Preparer {
+	var first, validators, resolvers, middle, reconcilers, normalizers []Preparer
+	for _, preparer := range functions {
+		switch preparer.Name {
+		case GzipDecompressorName:
+			first = append(first, preparer)
+		case Utf8ValidatorName:
+			validators = append(validators, preparer)
+		case IncludeResolverName:
+			resolvers = append(resolvers, preparer)
+		case FileNameReconcilerName:
+			reconcilers = append(reconcilers, preparer)
+		case LineEndingNormalizerName:
+			normalizers = append(normalizers, preparer)
+		default:
+			middle = append(middle, preparer)
+		}
+	}
+	ordered := make([]Preparer, 0, len(functions))
+	ordered = append(ordered, first...)
+	ordered = append(ordered, validators...)
+	ordered = append(ordered, resolvers...)
+	ordered = append(ordered, middle...)
+	ordered = append(ordered, reconcilers...)
+	return append(ordered, normalizers...)
+}
+
+// AddPreparer adds preparer to the builder if it is not skipped
 func (builder *PreparersBuilder) AddPreparer(newPreparer Preparer) {
+	if builder.skipped[newPreparer.Name] {
+		return
+	}
 	*builder.preparers.functions = append(*builder.preparers.functions, newPreparer)
 }
+
+// warnIfSkipped adds a warning if the preparer with received name is skipped but it is required by the code processing
+func (builder *PreparersBuilder) warnIfSkipped(name, reason string) {
+	if builder.skipped[name] {
+		builder.summary.Warnings = append(builder.summary.Warnings, fmt.Sprintf("Preparer %s is skipped, %s", name, reason))
+	}
+}
+
+// contains checks that the preparer with received name is added to the builder
+func (builder *PreparersBuilder) contains(name string) bool {
+	for _, preparer := range *builder.preparers.functions {
+		if preparer.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// loggerFromArgs returns the contextual logger passed to the preparer as the argument with received index.
+// If there is no such argument returns a logger without fields.
+func loggerFromArgs(args []interface{}, index int) *logger.Entry {
+	if len(args) <= index {
+		return nil
+	}
+	log, _ := args[index].(*logger.Entry)
+	return log
+}
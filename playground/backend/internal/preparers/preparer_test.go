@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRegistry_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides Overrides
+		wantErr   bool
+	}{
+		{
+			name:      "empty overrides",
+			overrides: Overrides{},
+			wantErr:   false,
+		},
+		{
+			name:      "known preparers",
+			overrides: Overrides{Skip: []string{PackageChangerName}, Force: []string{SeedInjectorName}},
+			wantErr:   false,
+		},
+		{
+			name:      "unknown skipped preparer",
+			overrides: Overrides{Skip: []string{"unknown"}},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown forced preparer",
+			overrides: Overrides{Force: []string{LogHandlerName}},
+			wantErr:   true,
+		},
+		{
+			name:      "required preparer is skipped",
+			overrides: Overrides{Skip: []string{ForbiddenApiGuardName}},
+			wantErr:   true,
+		},
+		{
+			name:      "preparer is both skipped and forced",
+			overrides: Overrides{Skip: []string{SeedInjectorName}, Force: []string{SeedInjectorName}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := JavaRegistry().Validate(tt.overrides); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPreparersBuilder_Overrides(t *testing.T) {
+	type args struct {
+		isUnitTest bool
+		overrides  Overrides
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantNames    []string
+		wantWarnings int
+	}{
+		{
+			name:         "without overrides",
+			args:         args{isUnitTest: false, overrides: Overrides{}},
+			wantNames:    []string{ModuleInfoRejectorName, BuildDirectiveStripperName + "," + UnicodeEscapeDecoderName + "," + PublicClassRemoverName + "," + PackageChangerName, PackageChangerName, ForbiddenApiGuardName, OutputCaptureName, FileNameReconcilerName},
+			wantWarnings: 0,
+		},
+		{
+			name:         "skip preparer",
+			args:         args{isUnitTest: false, overrides: Overrides{Skip: []string{PackageChangerName}}},
+			wantNames:    []string{ModuleInfoRejectorName, BuildDirectiveStripperName + "," + UnicodeEscapeDecoderName + "," + PublicClassRemoverName, ForbiddenApiGuardName, OutputCaptureName, FileNameReconcilerName},
+			wantWarnings: 0,
+		},
+		{
+			name:         "force preparer",
+			args:         args{isUnitTest: false, overrides: Overrides{Force: []string{SeedInjectorName, PackageChangerName}}},
+			wantNames:    []string{ModuleInfoRejectorName, BuildDirectiveStripperName + "," + UnicodeEscapeDecoderName + "," + PublicClassRemoverName + "," + PackageChangerName, PackageChangerName, ForbiddenApiGuardName, OutputCaptureName, SeedInjectorName, FileNameReconcilerName},
+			wantWarnings: 0,
+		},
+		{
+			// Test that skipping a preparer required by unit tests produces a warning
+			name:         "skip required preparer",
+			args:         args{isUnitTest: true, overrides: Overrides{Skip: []string{PackageChangerName}}},
+			wantNames:    []string{ModuleInfoRejectorName, BuildDirectiveStripperName + "," + UnicodeEscapeDecoderName, ForbiddenApiGuardName, TestClassCheckerName, FileNameChangerName},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := NewPreparersBuilder("MOCK_FILEPATH").WithSkipped(tt.args.overrides.Skip)
+			GetJavaPreparers(builder, tt.args.isUnitTest, false)
+			builder.WithForced(JavaRegistry(), tt.args.overrides.Force)
+			var names []string
+			for _, preparer := range *builder.Build().GetPreparers() {
+				names = append(names, preparer.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("GetPreparers() names = %v, want %v", names, tt.wantNames)
+			}
+			if got := len(builder.Warnings()); got != tt.wantWarnings {
+				t.Errorf("Warnings() returns %v warnings, want %v", got, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestPreparersBuilder_SkippedPreparerKeepsCode(t *testing.T) {
+	code := "package org.apache.beam.examples;\n\npublic class Class {\n    public static void main(String[] args) {\n    }\n}"
+	tests := []struct {
+		name     string
+		skip     []string
+		wantCode string
+	}{
+		{
+			name:     "all line transforms",
+			skip:     []string{OutputCaptureName},
+			wantCode: "import org.apache.beam.examples.*;\n\nclass Class {\n    public static void main(String[] args) {\n    }\n}",
+		},
+		{
+			name:     "package changer is skipped",
+			skip:     []string{PackageChangerName, OutputCaptureName},
+			wantCode: "package org.apache.beam.examples;\n\nclass Class {\n    public static void main(String[] args) {\n    }\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "Class.java")
+			if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(filePath).WithSkipped(tt.skip)
+			GetJavaPreparers(builder, false, false)
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if err := preparer.Prepare(preparer.Args...); err != nil {
+					t.Fatalf("Prepare() unexpected error = %v", err)
+				}
+			}
+			data, _ := os.ReadFile(filePath)
+			if string(data) != tt.wantCode {
+				t.Errorf("prepared code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package preparertest provides helpers for golden-file tests of preparers
+package preparertest
+
+import (
+	"beam.apache.org/playground/backend/internal/preparers"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	fileHeader  = "-- %s --\n"
+	errorHeader = "-- error --\n"
+	goldenMode  = 0600
+	folderMode  = 0700
+)
+
+var update = flag.Bool("update", false, "regenerate golden files of preparer tests")
+
+// Chain adds preparers under test to the builder
+type Chain func(builder *preparers.PreparersBuilder)
+
+// PreparedResult is the state of the fixture after a chain of preparers is applied
+type PreparedResult struct {
+	// Files contains code of all files in the folder of the fixture by their slash-separated relative names
+	Files map[string]string
+	// Err is the error of the first failed preparer
+	Err     error
+	Summary *preparers.RunSummary
+}
+
+// RunChain copies the fixture into a temporary folder and applies preparers of the chain to the copy
+// in the same way as the executor does. Execution stops at the first preparer that fails.
+// If there is a folder next to the fixture named as the fixture without extension,
+// its files are copied next to the fixture as sibling files, e.g. helper classes of a multi-file run.
+func RunChain(t testing.TB, chain Chain, fixturePath string) PreparedResult {
+	t.Helper()
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, filepath.Base(fixturePath))
+	copyFixture(t, fixturePath, filePath)
+	siblings, _ := filepath.Glob(filepath.Join(strings.TrimSuffix(fixturePath, filepath.Ext(fixturePath)), "*"))
+	for _, sibling := range siblings {
+		copyFixture(t, sibling, filepath.Join(dir, filepath.Base(sibling)))
+	}
+
+	builder := preparers.NewPreparersBuilder(filePath)
+	chain(builder)
+	result := PreparedResult{Files: map[string]string{}}
+	for _, preparer := range *builder.Build().GetPreparers() {
+		args := append(preparer.Args, &sync.Map{})
+		if result.Err = preparer.Prepare(args...); result.Err != nil {
+			break
+		}
+	}
+	result.Summary = builder.Summary()
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, _ := filepath.Rel(dir, path)
+		result.Files[filepath.ToSlash(name)] = string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("error during read prepared files: %s", err.Error())
+	}
+	return result
+}
+
+// copyFixture copies the fixture by fixturePath to filePath
+func copyFixture(t testing.TB, fixturePath, filePath string) {
+	t.Helper()
+	code, err := os.ReadFile(fixturePath)
+	if err != nil {
+		t.Fatalf("error during read fixture %s: %s", fixturePath, err.Error())
+	}
+	if err = os.WriteFile(filePath, code, goldenMode); err != nil {
+		t.Fatalf("error during copy fixture %s: %s", fixturePath, err.Error())
+	}
+}
+
+// String renders all files of the result sorted by name and the error in the format of golden files:
+// each file starts with "-- name --" line and the error starts with "-- error --" line.
+// Line endings are normalized and each section ends with a line break.
+func (result PreparedResult) String() string {
+	names := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	for _, name := range names {
+		builder.WriteString(fmt.Sprintf(fileHeader, name))
+		builder.WriteString(section(result.Files[name]))
+	}
+	if result.Err != nil {
+		builder.WriteString(errorHeader)
+		builder.WriteString(section(result.Err.Error()))
+	}
+	return builder.String()
+}
+
+// AssertGolden checks that the result matches the golden file.
+// If the test is run with -update flag, the golden file is rewritten with the result instead.
+func AssertGolden(t testing.TB, result PreparedResult, goldenPath string) {
+	t.Helper()
+	got := result.String()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), folderMode); err != nil {
+			t.Fatalf("error during create folder of golden file %s: %s", goldenPath, err.Error())
+		}
+		if err := os.WriteFile(goldenPath, []byte(got), goldenMode); err != nil {
+			t.Fatalf("error during update golden file %s: %s", goldenPath, err.Error())
+		}
+		return
+	}
+	data, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("error during read golden file %s: %s, run the test with -update flag to create it", goldenPath, err.Error())
+	}
+	if want := normalizeLineEndings(string(data)); got != want {
+		t.Errorf("prepared code doesn't match golden file %s, run the test with -update flag to regenerate it\n--- got\n%s--- want\n%s", goldenPath, got, want)
+	}
+}
+
+// section returns text with normalized line endings which ends with a line break
+func section(text string) string {
+	text = normalizeLineEndings(text)
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text
+}
+
+func normalizeLineEndings(text string) string {
+	return strings.ReplaceAll(text, "\r\n", "\n")
+}
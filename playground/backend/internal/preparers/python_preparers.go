@@ -33,6 +33,17 @@ func GetPythonPreparers(builder *PreparersBuilder) {
 		WithLogHandler()
 }
 
+// PythonRegistry returns preparers of Python code which can be skipped or forced by name
+func PythonRegistry() Registry {
+	return Registry{
+		LogHandlerName:           func(builder *PreparersBuilder) { builder.PythonPreparers().WithLogHandler() },
+		StdinCheckerName:         func(builder *PreparersBuilder) { builder.PythonPreparers().WithStdinChecker(false) },
+		LineEndingNormalizerName: func(builder *PreparersBuilder) { builder.WithLineEndingNormalizer(LineEndingLf) },
+		Utf8ValidatorName:        func(builder *PreparersBuilder) { builder.WithUtf8Validator() },
+		IncludeResolverName:      func(builder *PreparersBuilder) { builder.WithIncludeResolver(builder.codeDir()) },
+	}
+}
+
 //PythonPreparersBuilder facet of PreparersBuilder
 type PythonPreparersBuilder struct {
 	PreparersBuilder
@@ -46,8 +57,10 @@ func (builder *PreparersBuilder) PythonPreparers() *PythonPreparersBuilder {
 //WithLogHandler adds code for logging
 func (builder *PythonPreparersBuilder) WithLogHandler() *PythonPreparersBuilder {
 	addLogHandler := Preparer{
-		Prepare: addCodeToFile,
-		Args:    []interface{}{builder.filePath, addLogHandlerCode},
+		Name:        LogHandlerName,
+		Prepare:     addCodeToFile,
+		Args:        []interface{}{builder.filePath, addLogHandlerCode, builder.logger},
+		Description: "added the logging setup",
 	}
 	builder.AddPreparer(addLogHandler)
 	return builder
@@ -57,30 +70,32 @@ func (builder *PythonPreparersBuilder) WithLogHandler() *PythonPreparersBuilder
 func addCodeToFile(args ...interface{}) error {
 	filePath := args[0].(string)
 	additionalCode := args[1].(string)
+	log := loggerFromArgs(args, 2)
 
 	file, err := os.Open(filePath)
 	if err != nil {
-		logger.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
 		return err
 	}
 	defer file.Close()
 
 	tmp, err := createTempFile(filePath)
 	if err != nil {
-		logger.Errorf("Preparation: Error during create new temporary file, err: %s\n", err.Error())
+		log.Errorf("Preparation: Error during create new temporary file, err: %s\n", err.Error())
 		return err
 	}
 	defer tmp.Close()
 
-	err = writeCodeToFile(file, tmp, additionalCode)
+	err = writeCodeToFile(file, tmp, additionalCode, log)
 	if err != nil {
-		logger.Errorf("Preparation: Error during write data to tmp file, err: %s\n", err.Error())
+		log.Errorf("Preparation: Error during write data to tmp file, err: %s\n", err.Error())
 		return err
 	}
 
 	// replace original file with temporary file with renaming
-	if err = os.Rename(tmp.Name(), filePath); err != nil {
-		logger.Errorf("Preparation: Error during rename temporary file, err: %s\n", err.Error())
+	_ = file.Close()
+	if err = replaceWithTempFile(tmp, filePath); err != nil {
+		log.Errorf("Preparation: Error during rename temporary file, err: %s\n", err.Error())
 		return err
 	}
 	return nil
@@ -88,8 +103,8 @@ func addCodeToFile(args ...interface{}) error {
 
 // writeCodeToFile rewrites all lines from file with adding additional code to another file
 // New code is added to the top of the file.
-func writeCodeToFile(from *os.File, to *os.File, code string) error {
-	if err := writeToFile(to, code); err != nil {
+func writeCodeToFile(from *os.File, to *os.File, code string, log *logger.Entry) error {
+	if err := writeToFile(to, code, log); err != nil {
 		return err
 	}
 
@@ -97,7 +112,7 @@ func writeCodeToFile(from *os.File, to *os.File, code string) error {
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		if err := writeToFile(to, line+"\n"); err != nil {
+		if err := writeToFile(to, line+"\n", log); err != nil {
 			return err
 		}
 	}
@@ -105,9 +120,9 @@ func writeCodeToFile(from *os.File, to *os.File, code string) error {
 }
 
 // writeToFile writes str to the file.
-func writeToFile(to *os.File, str string) error {
+func writeToFile(to *os.File, str string, log *logger.Entry) error {
 	if _, err := io.WriteString(to, str); err != nil {
-		logger.Errorf("Preparation: Error during write \"%s\" to tmp file, err: %s\n", str, err.Error())
+		log.Errorf("Preparation: Error during write \"%s\" to tmp file, err: %s\n", str, err.Error())
 		return err
 	}
 	return nil
@@ -0,0 +1,304 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"beam.apache.org/playground/backend/internal/source_map"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	sourceMapName = "source_map"
+	// maxSourceMapCells limits the size of the table which is used to align changed lines of the code.
+	// Bigger changes are split by lines which occur once in both versions of the change, and their parts are aligned separately.
+	maxSourceMapCells = 1 << 22
+)
+
+// sourceMapState keeps lines of the code before preparers, files which existed before them and the map which is built after them
+type sourceMapState struct {
+	original []string
+	existing map[string]bool
+	result   *source_map.SourceMap
+}
+
+//WithSourceMap enables tracking of lines of the code which are moved by preparers.
+//After built preparers are applied SourceMap returns original line numbers of lines of each prepared file.
+func (builder *PreparersBuilder) WithSourceMap() *PreparersBuilder {
+	builder.sourceMap = &sourceMapState{}
+	return builder
+}
+
+//SourceMap returns maps from line numbers of prepared files to line numbers of the original code, starting from 1.
+//The map is built for the prepared file and for each file of the code which is added by preparers, e.g. split classes.
+//Lines which are added by preparers are mapped to the closest original line before them.
+//Returns nil if the source map isn't enabled or built preparers aren't applied yet.
+func (builder *PreparersBuilder) SourceMap() *source_map.SourceMap {
+	if builder.sourceMap == nil {
+		return nil
+	}
+	return builder.sourceMap.result
+}
+
+// withSourceMap returns functions with preparers which record lines of the code before all preparers that change it
+// and build the source map after them. The code is recorded after decompression.
+func (builder *PreparersBuilder) withSourceMap(functions []Preparer) []Preparer {
+	if builder.sourceMap == nil {
+		return functions
+	}
+	state := builder.sourceMap
+	start, end := 0, len(functions)
+	for start < end && functions[start].Name == GzipDecompressorName {
+		start++
+	}
+	record := Preparer{
+		Name: sourceMapName,
+		Prepare: func(args ...interface{}) error {
+			code, err := readSourceFile(builder.filePath)
+			if err != nil {
+				return err
+			}
+			existing, err := sourceFileNames(builder.filePath)
+			if err != nil {
+				return err
+			}
+			state.original, state.existing, state.result = splitLines(string(code)), existing, nil
+			return nil
+		},
+	}
+	build := Preparer{
+		Name: sourceMapName,
+		Prepare: func(args ...interface{}) error {
+			fileName := filepath.Base(builder.filePath)
+			if builder.summary.RenamedTo != "" {
+				fileName = builder.summary.RenamedTo
+			}
+			fileNames, err := sourceFileNames(builder.filePath)
+			if err != nil {
+				return err
+			}
+			result := &source_map.SourceMap{Files: map[string]map[int]int{}}
+			for name := range fileNames {
+				if name != fileName && state.existing[name] {
+					continue
+				}
+				code, err := readSourceFile(filepath.Join(filepath.Dir(builder.filePath), name))
+				if err != nil {
+					return err
+				}
+				result.Files[name] = mapLines(splitLines(string(code)), state.original)
+			}
+			state.result = result
+			builder.summary.SourceMap = result
+			return nil
+		},
+	}
+	result := make([]Preparer, 0, len(functions)+2)
+	result = append(result, functions[:start]...)
+	result = append(result, record)
+	result = append(result, functions[start:end]...)
+	result = append(result, build)
+	return append(result, functions[end:]...)
+}
+
+// sourceFileNames returns names of files in the folder of the file by filePath which have the same extension
+func sourceFileNames(filePath string) (map[string]bool, error) {
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	if err != nil {
+		return nil, err
+	}
+	fileNames := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == filepath.Ext(filePath) {
+			fileNames[entry.Name()] = true
+		}
+	}
+	return fileNames, nil
+}
+
+// splitLines returns lines of the code without line endings
+func splitLines(code string) []string {
+	code = strings.TrimSuffix(strings.ReplaceAll(code, "\r\n", newLinePattern), newLinePattern)
+	if code == "" {
+		return nil
+	}
+	return strings.Split(code, newLinePattern)
+}
+
+// mapLines returns the map from numbers of prepared lines to numbers of original lines.
+// Equal lines are aligned by the longest common subsequence, the rest of changed lines are paired in their order.
+func mapLines(prepared, original []string) map[int]int {
+	result := make(map[int]int, len(prepared))
+	// lastOriginal is the original line of the last mapped prepared line
+	lastOriginal := 0
+	mapHunk := func(preparedLines, originalLines []int) {
+		for i, preparedLine := range preparedLines {
+			if i < len(originalLines) {
+				lastOriginal = originalLines[i]
+			}
+			result[preparedLine] = maxInt(lastOriginal, 1)
+		}
+		if len(originalLines) > len(preparedLines) {
+			lastOriginal = originalLines[len(originalLines)-1]
+		}
+	}
+	var preparedHunk, originalHunk []int
+	i, j := 0, 0
+	for _, match := range alignLines(prepared, original) {
+		for ; i < match[0]; i++ {
+			preparedHunk = append(preparedHunk, i+1)
+		}
+		for ; j < match[1]; j++ {
+			originalHunk = append(originalHunk, j+1)
+		}
+		mapHunk(preparedHunk, originalHunk)
+		preparedHunk, originalHunk = preparedHunk[:0], originalHunk[:0]
+		result[i+1] = j + 1
+		lastOriginal = j + 1
+		i, j = i+1, j+1
+	}
+	for ; i < len(prepared); i++ {
+		preparedHunk = append(preparedHunk, i+1)
+	}
+	for ; j < len(original); j++ {
+		originalHunk = append(originalHunk, j+1)
+	}
+	mapHunk(preparedHunk, originalHunk)
+	return result
+}
+
+// alignLines returns pairs of indexes of equal lines of a and b which form their longest common subsequence.
+// Common lines at the start and the end are aligned first, so only changed lines in the middle are compared.
+func alignLines(a, b []string) [][2]int {
+	var prefix, suffix [][2]int
+	for len(prefix) < len(a) && len(prefix) < len(b) && a[len(prefix)] == b[len(prefix)] {
+		prefix = append(prefix, [2]int{len(prefix), len(prefix)})
+	}
+	start := len(prefix)
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && a[endA-1] == b[endB-1] {
+		endA, endB = endA-1, endB-1
+		suffix = append([][2]int{{endA, endB}}, suffix...)
+	}
+	n, m := endA-start, endB-start
+	if n == 0 || m == 0 {
+		return append(prefix, suffix...)
+	}
+	if (n+1)*(m+1) > maxSourceMapCells {
+		return append(alignByUniqueLines(a[start:endA], b[start:endB], start, prefix), suffix...)
+	}
+	// lengths[x*(m+1)+y] is the length of the common subsequence of a[start+x:endA] and b[start+y:endB]
+	lengths := make([]int32, (n+1)*(m+1))
+	for x := n - 1; x >= 0; x-- {
+		for y := m - 1; y >= 0; y-- {
+			if a[start+x] == b[start+y] {
+				lengths[x*(m+1)+y] = lengths[(x+1)*(m+1)+y+1] + 1
+			} else if lengths[(x+1)*(m+1)+y] >= lengths[x*(m+1)+y+1] {
+				lengths[x*(m+1)+y] = lengths[(x+1)*(m+1)+y]
+			} else {
+				lengths[x*(m+1)+y] = lengths[x*(m+1)+y+1]
+			}
+		}
+	}
+	matches := prefix
+	for x, y := 0, 0; x < n && y < m; {
+		switch {
+		case a[start+x] == b[start+y]:
+			matches = append(matches, [2]int{start + x, start + y})
+			x, y = x+1, y+1
+		case lengths[(x+1)*(m+1)+y] >= lengths[x*(m+1)+y+1]:
+			x++
+		default:
+			y++
+		}
+	}
+	return append(matches, suffix...)
+}
+
+// alignByUniqueLines appends to matches pairs of indexes of equal lines of a and b shifted by offset.
+// Lines which occur once in both a and b are aligned first, then parts of a and b between them are aligned by alignLines.
+// If there are no such lines, lines of a and b aren't aligned.
+func alignByUniqueLines(a, b []string, offset int, matches [][2]int) [][2]int {
+	anchors := uniqueCommonLines(a, b)
+	if len(anchors) == 0 {
+		return matches
+	}
+	x, y := 0, 0
+	for _, anchor := range append(anchors, [2]int{len(a), len(b)}) {
+		for _, match := range alignLines(a[x:anchor[0]], b[y:anchor[1]]) {
+			matches = append(matches, [2]int{offset + x + match[0], offset + y + match[1]})
+		}
+		if anchor[0] < len(a) {
+			matches = append(matches, [2]int{offset + anchor[0], offset + anchor[1]})
+		}
+		x, y = anchor[0]+1, anchor[1]+1
+	}
+	return matches
+}
+
+// uniqueCommonLines returns pairs of indexes of lines which occur once in both a and b.
+// Only the longest sequence of pairs which are in the same order in a and b is returned.
+func uniqueCommonLines(a, b []string) [][2]int {
+	counts := make(map[string][2]int)
+	indexesOfB := make(map[string]int)
+	for _, line := range a {
+		count := counts[line]
+		counts[line] = [2]int{count[0] + 1, count[1]}
+	}
+	for i, line := range b {
+		count := counts[line]
+		counts[line] = [2]int{count[0], count[1] + 1}
+		indexesOfB[line] = i
+	}
+	var pairs [][2]int
+	for i, line := range a {
+		if counts[line] == [2]int{1, 1} {
+			pairs = append(pairs, [2]int{i, indexesOfB[line]})
+		}
+	}
+	// tails[k] is the index of the pair which ends the increasing sequence of length k+1 with the smallest index of b
+	var tails []int
+	previous := make([]int, len(pairs))
+	for i, pair := range pairs {
+		k := sort.Search(len(tails), func(k int) bool { return pairs[tails[k]][1] >= pair[1] })
+		previous[i] = -1
+		if k > 0 {
+			previous[i] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+	if len(tails) == 0 {
+		return nil
+	}
+	result := make([][2]int, len(tails))
+	for i, k := tails[len(tails)-1], len(tails)-1; k >= 0; i, k = previous[i], k-1 {
+		result[k] = pairs[i]
+	}
+	return result
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,284 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"beam.apache.org/playground/backend/internal/source_map"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_mapLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		prepared []string
+		original []string
+		want     map[int]int
+	}{
+		{
+			name:     "unchanged code",
+			prepared: []string{"a", "b", "c"},
+			original: []string{"a", "b", "c"},
+			want:     map[int]int{1: 1, 2: 2, 3: 3},
+		},
+		{
+			name:     "deleted first line",
+			prepared: []string{"b", "c"},
+			original: []string{"a", "b", "c"},
+			want:     map[int]int{1: 2, 2: 3},
+		},
+		{
+			// Test that added lines are mapped to the closest original line before them
+			name:     "added lines",
+			prepared: []string{"a", "x", "y", "b"},
+			original: []string{"a", "b"},
+			want:     map[int]int{1: 1, 2: 1, 3: 1, 4: 2},
+		},
+		{
+			name:     "added lines at the start",
+			prepared: []string{"x", "a"},
+			original: []string{"a"},
+			want:     map[int]int{1: 1, 2: 1},
+		},
+		{
+			// Test that changed lines are paired in their order
+			name:     "changed lines",
+			prepared: []string{"a", "B", "C", "d"},
+			original: []string{"a", "b", "c", "d"},
+			want:     map[int]int{1: 1, 2: 2, 3: 3, 4: 4},
+		},
+		{
+			name:     "moved line",
+			prepared: []string{"b", "c", "a"},
+			original: []string{"a", "b", "c"},
+			want:     map[int]int{1: 2, 2: 3, 3: 3},
+		},
+		{
+			name:     "empty original code",
+			prepared: []string{"x"},
+			original: nil,
+			want:     map[int]int{1: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapLines(tt.prepared, tt.original); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mapLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mapLinesOfLargeChange(t *testing.T) {
+	// a line is added after each original line, so the change is too large to be aligned by the table of the whole change
+	var prepared, original []string
+	want := map[int]int{}
+	for i := 0; i < 3000; i++ {
+		line := fmt.Sprintf("line %d", i)
+		original = append(original, line)
+		prepared = append(prepared, line, "    added")
+		want[2*i+1], want[2*i+2] = i+1, i+1
+	}
+	if (len(prepared)+1)*(len(original)+1) <= maxSourceMapCells {
+		t.Fatalf("the change has %d cells, want more than %d", (len(prepared)+1)*(len(original)+1), maxSourceMapCells)
+	}
+	if got := mapLines(prepared, original); !reflect.DeepEqual(got, want) {
+		t.Errorf("mapLines() of the large change differs from the map of lines, %d prepared lines are mapped", len(got))
+	}
+}
+
+func TestPreparersBuilder_WithSourceMap(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		prepare  func(builder *PreparersBuilder)
+		wantCode string
+		want     map[int]int
+	}{
+		{
+			// Test that lines of the fragment are mapped to their original lines after the wrapping into the main method
+			name: "fragment wrapper",
+			code: "import java.util.List;\n\n// BEGIN\nList<String> words = List.of(\"a\");\nint x = 1 / 0;\n// END\n",
+			prepare: func(builder *PreparersBuilder) {
+				builder.JavaPreparers().WithFragmentWrapper(DefaultFragmentStartMarker, DefaultFragmentEndMarker)
+			},
+			wantCode: "import java.util.List;\n\nclass Fragment {\n    public static void main(String[] args) throws Exception {\nList<String> words = List.of(\"a\");\nint x = 1 / 0;\n    }\n}\n",
+			want:     map[int]int{1: 1, 2: 2, 3: 3, 4: 3, 5: 4, 6: 5, 7: 6, 8: 6},
+		},
+		{
+			// Test that lines are shifted back after the removal of the package declaration
+			name: "package remover",
+			code: "package org.apache.beam.examples;\n\npublic class Main {\n    public static void main(String[] args) {\n        int x = 1 / 0;\n    }\n}\n",
+			prepare: func(builder *PreparersBuilder) {
+				builder.JavaPreparers().WithPackageRemover().WithPublicClassRemover()
+			},
+			wantCode: "\nclass Main {\n    public static void main(String[] args) {\n        int x = 1 / 0;\n    }\n}",
+			want:     map[int]int{1: 2, 2: 3, 3: 4, 4: 5, 5: 6, 6: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "Main.java")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(filePath).WithSourceMap()
+			tt.prepare(builder)
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if err := preparer.Prepare(preparer.Args...); err != nil {
+					t.Fatalf("preparer %s error = %v", preparer.Name, err)
+				}
+			}
+			if got, _ := os.ReadFile(filePath); string(got) != tt.wantCode {
+				t.Errorf("preparers code = %q, want %q", got, tt.wantCode)
+			}
+			if got := builder.SourceMap(); !reflect.DeepEqual(got, &source_map.SourceMap{Files: map[string]map[int]int{"Main.java": tt.want}}) {
+				t.Errorf("SourceMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreparersBuilder_SourceMapDisabled(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "Main.java")
+	if err := os.WriteFile(filePath, []byte("package org.apache.beam.examples;\n\nclass Main {}\n"), 0600); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	builder := NewPreparersBuilder(filePath)
+	builder.JavaPreparers().WithPackageRemover()
+	preparers := *builder.Build().GetPreparers()
+	if len(preparers) != 1 {
+		t.Errorf("Build() preparers = %d, want 1 without preparers of the source map", len(preparers))
+	}
+	for _, preparer := range preparers {
+		if err := preparer.Prepare(preparer.Args...); err != nil {
+			t.Fatalf("preparer %s error = %v", preparer.Name, err)
+		}
+	}
+	if got := builder.SourceMap(); got != nil {
+		t.Errorf("SourceMap() = %v, want nil", got)
+	}
+}
+
+func TestSourceMap_TranslateErrorsOfPreparedCode(t *testing.T) {
+	t.Run("renamed java file", func(t *testing.T) {
+		// the error is on line 5 of the original code, the fragment is wrapped into a class which is moved to Fragment.java
+		code := "import java.util.List;\n\n// BEGIN\nList<String> words = List.of(\"a\");\nint x = foo();\n// END\n"
+		dir := t.TempDir()
+		filePath := filepath.Join(dir, "Snippet.java")
+		if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+			t.Fatalf("error during test setup: %s", err.Error())
+		}
+		builder := NewPreparersBuilder(filePath).WithSourceMap()
+		builder.JavaPreparers().WithFragmentWrapper(DefaultFragmentStartMarker, DefaultFragmentEndMarker).WithFileNameReconciler()
+		for _, preparer := range *builder.Build().GetPreparers() {
+			if err := preparer.Prepare(preparer.Args...); err != nil {
+				t.Fatalf("preparer %s error = %v", preparer.Name, err)
+			}
+		}
+		sourceMap := builder.Summary().SourceMap
+		if _, ok := sourceMap.Files["Fragment.java"]; !ok || len(sourceMap.Files) != 1 {
+			t.Fatalf("Summary().SourceMap = %v, want the map of Fragment.java", sourceMap)
+		}
+		prepared, err := os.ReadFile(filepath.Join(dir, "Fragment.java"))
+		if err != nil {
+			t.Fatalf("prepared file error = %v", err)
+		}
+		preparedLine := 0
+		for i, line := range strings.Split(string(prepared), "\n") {
+			if strings.Contains(line, "foo()") {
+				preparedLine = i + 1
+			}
+		}
+		// javac reports the error on the line of the prepared file
+		output := fmt.Sprintf("%s:%d: error: cannot find symbol\nint x = foo();\n        ^", filepath.Join(dir, "Fragment.java"), preparedLine)
+		want := fmt.Sprintf("%s:5: error: cannot find symbol", filepath.Join(dir, "Fragment.java"))
+		if got := sourceMap.Translate(output); !strings.HasPrefix(got, want) {
+			t.Errorf("Translate() = %q, want the error on line 5", got)
+		}
+	})
+	t.Run("split java class", func(t *testing.T) {
+		// the error is on line 11 of the original code, in the class which is moved to Helper.java with the imports
+		code := "package org.apache.beam.examples;\n\nimport java.util.List;\n\npublic class Main {\n    public static void main(String[] args) {\n        System.out.println(Helper.words());\n    }\n}\n\nclass Helper {\n    static List<String> words() {\n        return foo();\n    }\n}\n"
+		dir := t.TempDir()
+		filePath := filepath.Join(dir, "Main.java")
+		if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+			t.Fatalf("error during test setup: %s", err.Error())
+		}
+		builder := NewPreparersBuilder(filePath).WithSourceMap()
+		builder.JavaPreparers().WithPackageRemover().WithClassSplitter()
+		for _, preparer := range *builder.Build().GetPreparers() {
+			if err := preparer.Prepare(preparer.Args...); err != nil {
+				t.Fatalf("preparer %s error = %v", preparer.Name, err)
+			}
+		}
+		sourceMap := builder.Summary().SourceMap
+		if _, ok := sourceMap.Files["Helper.java"]; !ok {
+			t.Fatalf("Summary().SourceMap = %v, want the map of Helper.java", sourceMap)
+		}
+		prepared, err := os.ReadFile(filepath.Join(dir, "Helper.java"))
+		if err != nil {
+			t.Fatalf("prepared file error = %v", err)
+		}
+		preparedLine := 0
+		for i, line := range strings.Split(string(prepared), "\n") {
+			if strings.Contains(line, "foo()") {
+				preparedLine = i + 1
+			}
+		}
+		// javac reports the error on the line of the split file, the line of the main file is kept as is
+		output := fmt.Sprintf("%s:%d: error: cannot find symbol\n        return foo();\n               ^", filepath.Join(dir, "Helper.java"), preparedLine)
+		want := fmt.Sprintf("%s:13: error: cannot find symbol", filepath.Join(dir, "Helper.java"))
+		if got := sourceMap.Translate(output); !strings.HasPrefix(got, want) {
+			t.Errorf("Translate() = %q, want the error on line 13", got)
+		}
+	})
+	t.Run("python traceback", func(t *testing.T) {
+		python, err := exec.LookPath("python3")
+		if err != nil {
+			t.Skip("python3 isn't installed")
+		}
+		// the error is on line 3 of the original code, the logging setup is added before it
+		code := "x = 1\ny = 0\nprint(x / y)\n"
+		dir := t.TempDir()
+		filePath := filepath.Join(dir, "main.py")
+		if err := os.WriteFile(filePath, []byte(code), 0600); err != nil {
+			t.Fatalf("error during test setup: %s", err.Error())
+		}
+		builder := NewPreparersBuilder(filePath).WithSourceMap()
+		builder.PythonPreparers().WithLogHandler()
+		for _, preparer := range *builder.Build().GetPreparers() {
+			if err := preparer.Prepare(preparer.Args...); err != nil {
+				t.Fatalf("preparer %s error = %v", preparer.Name, err)
+			}
+		}
+		cmd := exec.Command(python, filePath)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Fatalf("python3 should fail on the division by zero, output: %s", output)
+		}
+		got := builder.Summary().SourceMap.Translate(string(output))
+		if !strings.Contains(got, "main.py\", line 3") || !strings.Contains(got, "ZeroDivisionError") {
+			t.Errorf("Translate() = %q, want the error on line 3", got)
+		}
+	})
+}
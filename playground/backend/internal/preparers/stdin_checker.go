@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	javaStdinReadPattern   = `\b(System\s*\.\s*in)\b|\b(System\s*\.\s*console)\s*\(`
+	kotlinStdinReadPattern = "\\b(System\\s*\\.\\s*`in`)|(?:^|[^\\w.])(readLine|readln|readlnOrNull)\\s*\\("
+	pythonStdinReadPattern = `(?m)(?:^|[^\w.])(input)\s*\(|\b(sys\s*\.\s*stdin)\b`
+	goStdinReadPattern     = `\b(os\s*\.\s*Stdin)\b|\b(fmt\s*\.\s*Scan(?:f|ln)?)\s*\(`
+	stdinReadFormat        = "%s at line %d"
+)
+
+var (
+	javaStdinReadRegexp   = regexp.MustCompile(javaStdinReadPattern)
+	kotlinStdinReadRegexp = regexp.MustCompile(kotlinStdinReadPattern)
+	pythonStdinReadRegexp = regexp.MustCompile(pythonStdinReadPattern)
+	goStdinReadRegexp     = regexp.MustCompile(goStdinReadPattern)
+)
+
+// InteractiveInputError is returned if the code reads the standard input, since the playground doesn't provide it
+type InteractiveInputError struct {
+	// Reads contains reads of the input with their lines, e.g. "System.in at line 5"
+	Reads []string
+}
+
+func (e *InteractiveInputError) Error() string {
+	return fmt.Sprintf("the code reads the standard input (%s), but interactive input isn't supported by the playground. "+
+		"Use hard-coded values instead of reading them from the input", strings.Join(e.Reads, ", "))
+}
+
+// stdinChecker finds reads of the standard input in the code without comments and string literals.
+// If strict is true the code which reads the input is rejected, otherwise the warning is added to the summary.
+type stdinChecker struct {
+	strict bool
+	reads  *regexp.Regexp
+	syntax literalSyntax
+}
+
+//WithStdinChecker adds preparer to warn about or reject (if strict is true) the code which reads System.in
+func (builder *JavaPreparersBuilder) WithStdinChecker(strict bool) *JavaPreparersBuilder {
+	builder.AddPreparer(stdinCheckerPreparer(&builder.PreparersBuilder, stdinChecker{strict: strict, reads: javaStdinReadRegexp, syntax: javaLiteralSyntax}))
+	return builder
+}
+
+//WithStdinChecker adds preparer to warn about or reject (if strict is true) the code which reads System.in or readLine()
+func (builder *KotlinPreparersBuilder) WithStdinChecker(strict bool) *KotlinPreparersBuilder {
+	builder.AddPreparer(stdinCheckerPreparer(&builder.PreparersBuilder, stdinChecker{strict: strict, reads: kotlinStdinReadRegexp, syntax: javaLiteralSyntax}))
+	return builder
+}
+
+//WithStdinChecker adds preparer to warn about or reject (if strict is true) the code which calls input() or reads sys.stdin
+func (builder *PythonPreparersBuilder) WithStdinChecker(strict bool) *PythonPreparersBuilder {
+	builder.AddPreparer(stdinCheckerPreparer(&builder.PreparersBuilder, stdinChecker{strict: strict, reads: pythonStdinReadRegexp, syntax: pythonLiteralSyntax}))
+	return builder
+}
+
+//WithStdinChecker adds preparer to warn about or reject (if strict is true) the code which reads os.Stdin or calls fmt.Scan
+func (builder *GoPreparersBuilder) WithStdinChecker(strict bool) *GoPreparersBuilder {
+	builder.AddPreparer(stdinCheckerPreparer(&builder.PreparersBuilder, stdinChecker{strict: strict, reads: goStdinReadRegexp, syntax: goLiteralSyntax}))
+	return builder
+}
+
+// stdinCheckerPreparer returns preparer which applies the checker to the code of the builder
+func stdinCheckerPreparer(builder *PreparersBuilder, checker stdinChecker) Preparer {
+	return Preparer{
+		Name:    StdinCheckerName,
+		Prepare: checkStdinReads,
+		Args:    []interface{}{builder.filePath, checker, builder.logger},
+	}
+}
+
+// checkStdinReads processes file by filePath and returns InteractiveInputError if the code reads the standard input
+// and the checker is strict. Otherwise, the error is returned as Warning, so the code is run as it is.
+func checkStdinReads(args ...interface{}) error {
+	filePath := args[0].(string)
+	checker := args[1].(stdinChecker)
+	log := loggerFromArgs(args, 2)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	reads := checker.find(string(code))
+	if len(reads) == 0 {
+		return nil
+	}
+	err = &InteractiveInputError{Reads: reads}
+	if checker.strict {
+		return err
+	}
+	log.Warnf("Preparation: %s\n", err.Error())
+	return &Warning{Message: err.Error()}
+}
+
+// find returns reads of the standard input of the code with their lines
+func (checker stdinChecker) find(code string) []string {
+	stripped, _ := scanLiterals(code, checker.syntax)
+	var reads []string
+	for _, match := range checker.reads.FindAllStringSubmatchIndex(stripped, -1) {
+		for group := 1; group < len(match)/2; group++ {
+			start, end := match[2*group], match[2*group+1]
+			if start < 0 {
+				continue
+			}
+			read := strings.Join(strings.Fields(stripped[start:end]), "")
+			line := strings.Count(stripped[:start], "\n") + 1
+			reads = append(reads, fmt.Sprintf(stdinReadFormat, read, line))
+			break
+		}
+	}
+	return reads
+}
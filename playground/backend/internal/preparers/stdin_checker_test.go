@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_checkStdinReads(t *testing.T) {
+	tests := []struct {
+		name      string
+		fileName  string
+		code      string
+		strict    bool
+		wantReads []string
+	}{
+		{
+			name:      "Java scanner",
+			fileName:  "Main.java",
+			code:      "import java.util.Scanner;\n\nScanner scanner = new Scanner(System . in);\nString name = scanner.nextLine();\n",
+			wantReads: []string{"System.in at line 3"},
+		},
+		{
+			name:      "Java console",
+			fileName:  "Main.java",
+			code:      "String name = System.console().readLine();\n",
+			wantReads: []string{"System.console at line 1"},
+		},
+		{
+			name:      "Kotlin readLine",
+			fileName:  "main.kt",
+			code:      "fun main() {\n    val name = readLine()\n    val reader = System.`in`.bufferedReader()\n}\n",
+			wantReads: []string{"readLine at line 2", "System.`in` at line 3"},
+		},
+		{
+			name:      "Python input",
+			fileName:  "main.py",
+			code:      "import sys\nname = input('Name: ')\nlines = sys.stdin.readlines()\n",
+			wantReads: []string{"input at line 2", "sys.stdin at line 3"},
+		},
+		{
+			// Test that methods and functions which only end with input aren't reads of the standard input
+			name:     "Python functions similar to input",
+			fileName: "main.py",
+			code:     "lines = fileinput.input()\nvalue = options.input()\nvalue = read_input()\n",
+		},
+		{
+			name:      "Go stdin",
+			fileName:  "main.go",
+			code:      "reader := bufio.NewReader(os.Stdin)\nvar name string\nfmt.Scanln(&name)\n",
+			wantReads: []string{"os.Stdin at line 1", "fmt.Scanln at line 3"},
+		},
+		{
+			name:     "reads in comments and strings",
+			fileName: "Main.java",
+			code:     "// new Scanner(System.in)\n/* System.in */\nString hint = \"System.in isn't supported\";\n",
+		},
+		{
+			name:     "Python reads in comments and strings",
+			fileName: "main.py",
+			code:     "# name = input()\n\"\"\"\nlines = sys.stdin.readlines()\n\"\"\"\nprint('input()')\n",
+		},
+		{
+			// Test that the code which reads the standard input is rejected in the strict mode
+			name:      "strict mode",
+			fileName:  "main.py",
+			code:      "name = input()\n",
+			strict:    true,
+			wantReads: []string{"input at line 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), tt.fileName)
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(filePath)
+			switch filepath.Ext(tt.fileName) {
+			case javaSourceFileExtension:
+				builder.JavaPreparers().WithStdinChecker(tt.strict)
+			case ".kt":
+				builder.KotlinPreparers().WithStdinChecker(tt.strict)
+			case ".go":
+				builder.GoPreparers().WithStdinChecker(tt.strict)
+			default:
+				builder.PythonPreparers().WithStdinChecker(tt.strict)
+			}
+			var err error
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if err = preparer.Prepare(preparer.Args...); err != nil {
+					break
+				}
+			}
+			var wantWarnings []string
+			if tt.wantReads != nil {
+				wantErr := &InteractiveInputError{Reads: tt.wantReads}
+				if tt.strict {
+					var interactiveInputErr *InteractiveInputError
+					if !errors.As(err, &interactiveInputErr) || !reflect.DeepEqual(interactiveInputErr, wantErr) {
+						t.Fatalf("checkStdinReads() error = %v, want %v", err, wantErr)
+					}
+					return
+				}
+				wantWarnings = []string{wantErr.Error()}
+			}
+			if err != nil {
+				t.Fatalf("checkStdinReads() unexpected error = %v", err)
+			}
+			if got := builder.Summary().Warnings; !reflect.DeepEqual(got, wantWarnings) {
+				t.Errorf("checkStdinReads() warnings = %v, want %v", got, wantWarnings)
+			}
+			if got, _ := os.ReadFile(filePath); string(got) != tt.code {
+				t.Errorf("checkStdinReads() code = %q, want %q", got, tt.code)
+			}
+		})
+	}
+}
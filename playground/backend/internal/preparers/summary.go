@@ -0,0 +1,191 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"beam.apache.org/playground/backend/internal/source_map"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// RunSummary describes changes which preparers made to the code, so they can be shown to the user.
+// Transformations, RenamedTo and Substitutions are filled while preparers are applied one by one.
+type RunSummary struct {
+	Transformations []string
+	RenamedTo       string
+	Warnings        []string
+	// Substitutions contains inputs of the code which are replaced with local stand-ins
+	Substitutions []string
+	// SourceMap maps lines of prepared files to lines of the original code, it's set if the source map is enabled
+	SourceMap *source_map.SourceMap
+	// order contains descriptions of all built preparers to keep transformations in the order of preparers
+	order []string
+}
+
+// String returns the summary as the text for the user
+func (summary *RunSummary) String() string {
+	var lines []string
+	if len(summary.Transformations) != 0 {
+		lines = append(lines, fmt.Sprintf("Code is changed before the run: %s.", strings.Join(summary.Transformations, ", ")))
+	}
+	if summary.RenamedTo != "" {
+		lines = append(lines, fmt.Sprintf("The file is renamed to %s.", summary.RenamedTo))
+	}
+	if len(summary.Substitutions) != 0 {
+		lines = append(lines, fmt.Sprintf("Inputs are replaced with local stand-ins: %s.", strings.Join(summary.Substitutions, ", ")))
+	}
+	for _, warning := range summary.Warnings {
+		lines = append(lines, fmt.Sprintf("Warning: %s.", warning))
+	}
+	return strings.Join(lines, newLinePattern)
+}
+
+// Warning is returned by preparers which find a problem of the code which doesn't prevent its run.
+// Built preparers add warnings to the summary instead of failing the preparation.
+type Warning struct {
+	Message string
+}
+
+func (w *Warning) Error() string {
+	return w.Message
+}
+
+// register adds the description of the built preparer which may change the code
+func (summary *RunSummary) register(description string) {
+	summary.order = append(summary.order, description)
+}
+
+// addTransformation adds the description of the change to the summary if it isn't added yet.
+// Transformations are kept in the order of preparers which make them.
+func (summary *RunSummary) addTransformation(description string) {
+	for _, transformation := range summary.Transformations {
+		if transformation == description {
+			return
+		}
+	}
+	summary.Transformations = append(summary.Transformations, description)
+	index := func(description string) int {
+		for i, registered := range summary.order {
+			if registered == description {
+				return i
+			}
+		}
+		return len(summary.order)
+	}
+	sort.SliceStable(summary.Transformations, func(i, j int) bool {
+		return index(summary.Transformations[i]) < index(summary.Transformations[j])
+	})
+}
+
+// addSubstitution adds the description of the replaced input to the summary if it isn't added yet
+func (summary *RunSummary) addSubstitution(description string) {
+	for _, substitution := range summary.Substitutions {
+		if substitution == description {
+			return
+		}
+	}
+	summary.Substitutions = append(summary.Substitutions, description)
+}
+
+// addWarning adds the warning to the summary if it isn't added yet
+func (summary *RunSummary) addWarning(warning string) {
+	for _, added := range summary.Warnings {
+		if added == warning {
+			return
+		}
+	}
+	summary.Warnings = append(summary.Warnings, warning)
+}
+
+// setRenamedTo sets the new name of the file with code
+func (summary *RunSummary) setRenamedTo(name string) {
+	summary.RenamedTo = name
+}
+
+// summarizeTransform returns LineTransform which adds the description to the summary if the transform changes a line
+func summarizeTransform(transform LineTransform, description string, summary *RunSummary) LineTransform {
+	return func(line string) (string, error) {
+		result, err := transform(line)
+		if (err == nil && result != line) || err == errLineDeleted {
+			summary.addTransformation(description)
+		}
+		return result, err
+	}
+}
+
+// summarizePreparer returns preparer which adds the description of its preparer to the summary if
+// the code of the file by filePath is changed and sets the new name of the file if it is renamed
+func summarizePreparer(preparer Preparer, filePath string, summary *RunSummary) Preparer {
+	prepare := preparer.Prepare
+	preparer.Prepare = func(args ...interface{}) error {
+		codeBefore, _ := os.ReadFile(filePath)
+		filesBefore := listFiles(filepath.Dir(filePath))
+		if err := prepare(args...); err != nil {
+			return err
+		}
+		codeAfter, err := os.ReadFile(filePath)
+		if err == nil {
+			if !bytes.Equal(codeBefore, codeAfter) {
+				summary.addTransformation(preparer.Description)
+			}
+			return nil
+		}
+		var newFiles []string
+		for file := range listFiles(filepath.Dir(filePath)) {
+			if !filesBefore[file] {
+				newFiles = append(newFiles, file)
+			}
+		}
+		sort.Strings(newFiles)
+		if len(newFiles) == 1 {
+			summary.setRenamedTo(newFiles[0])
+			return nil
+		}
+		summary.addTransformation(preparer.Description)
+		return nil
+	}
+	return preparer
+}
+
+// listFiles returns names of all files in the folder
+func listFiles(folder string) map[string]bool {
+	files := map[string]bool{}
+	entries, _ := os.ReadDir(folder)
+	for _, entry := range entries {
+		files[entry.Name()] = true
+	}
+	return files
+}
+
+// collectWarnings returns preparer which adds Warning returned by its preparer to the summary and doesn't fail
+func collectWarnings(preparer Preparer, summary *RunSummary) Preparer {
+	prepare := preparer.Prepare
+	preparer.Prepare = func(args ...interface{}) error {
+		err := prepare(args...)
+		var warning *Warning
+		if errors.As(err, &warning) {
+			summary.addWarning(warning.Message)
+			return nil
+		}
+		return err
+	}
+	return preparer
+}
@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunSummary(t *testing.T) {
+	kataCode := "//DEPS org.apache.beam:beam-sdks-java-core:2.35.0\npackage org.apache.beam.katas;\n\npublic class Task {\n    public static void main(String[] args) {\n        System.out.println(\"Hello\");\n    }\n}"
+	preparedCode := "class Task {\n    public static void main(String[] args) {\n        System.out.println(\"Hello\");\n    }\n}"
+	testCode := "package org.apache.beam.katas;\n\npublic class TaskTest {\n    @Test\n    public void test() {\n    }\n}"
+
+	type args struct {
+		code       string
+		isUnitTest bool
+		isKata     bool
+		skip       []string
+		paths      map[string]string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		wantSummary RunSummary
+		wantText    string
+	}{
+		{
+			// Test the summary of all preparers of the kata
+			name: "full kata chain",
+			args: args{code: kataCode, isKata: true},
+			wantSummary: RunSummary{Transformations: []string{
+				"removed build tool directives",
+				"removed the public modifier of classes",
+				"removed the package declaration",
+				"flushed standard output at the end of main method",
+			}},
+			wantText: "Code is changed before the run: removed build tool directives, removed the public modifier of classes, " +
+				"removed the package declaration, flushed standard output at the end of main method.",
+		},
+		{
+			// Test that preparers which don't change the code aren't added
+			name:        "prepared kata",
+			args:        args{code: preparedCode, isKata: true, skip: []string{OutputCaptureName}},
+			wantSummary: RunSummary{},
+			wantText:    "",
+		},
+		{
+			name: "unit test with renamed file",
+			args: args{code: testCode, isUnitTest: true},
+			wantSummary: RunSummary{
+				Transformations: []string{"replaced the package declaration with the import of the package"},
+				RenamedTo:       "TaskTest.java",
+			},
+			wantText: "Code is changed before the run: replaced the package declaration with the import of the package.\nThe file is renamed to TaskTest.java.",
+		},
+		{
+			name: "skipped required preparer",
+			args: args{code: testCode, isUnitTest: true, skip: []string{FileNameChangerName}},
+			wantSummary: RunSummary{
+				Transformations: []string{"replaced the package declaration with the import of the package"},
+				Warnings:        []string{"Preparer file_name_changer is skipped, the file name may not match the unit test class"},
+			},
+			wantText: "Code is changed before the run: replaced the package declaration with the import of the package.\n" +
+				"Warning: Preparer file_name_changer is skipped, the file name may not match the unit test class.",
+		},
+		{
+			// Test that replaced inputs are added to the summary
+			name: "replaced inputs",
+			args: args{
+				code:   strings.Replace(preparedCode, `"Hello"`, `"gs://apache-beam-samples/kinglear.txt"`, 1),
+				isKata: true,
+				skip:   []string{OutputCaptureName},
+				paths:  map[string]string{"gs://apache-beam-samples/kinglear.txt": "/opt/samples/kinglear.txt"},
+			},
+			wantSummary: RunSummary{Substitutions: []string{"gs://apache-beam-samples/kinglear.txt -> /opt/samples/kinglear.txt"}},
+			wantText:    "Inputs are replaced with local stand-ins: gs://apache-beam-samples/kinglear.txt -> /opt/samples/kinglear.txt.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "123e4567.java")
+			if err := os.WriteFile(filePath, []byte(tt.args.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			builder := NewPreparersBuilder(filePath).WithSkipped(tt.args.skip)
+			GetJavaPreparers(builder, tt.args.isUnitTest, tt.args.isKata)
+			if tt.args.paths != nil {
+				builder.JavaPreparers().WithIoSubstitutor(tt.args.paths, nil)
+			}
+			for _, preparer := range *builder.Build().GetPreparers() {
+				if err := preparer.Prepare(preparer.Args...); err != nil {
+					t.Fatalf("Prepare() unexpected error = %v", err)
+				}
+			}
+			summary := builder.Summary()
+			if !reflect.DeepEqual(summary.Transformations, tt.wantSummary.Transformations) ||
+				summary.RenamedTo != tt.wantSummary.RenamedTo ||
+				!reflect.DeepEqual(summary.Warnings, tt.wantSummary.Warnings) ||
+				!reflect.DeepEqual(summary.Substitutions, tt.wantSummary.Substitutions) {
+				t.Errorf("Summary() = %+v, want %+v", summary, tt.wantSummary)
+			}
+			if got := summary.String(); got != tt.wantText {
+				t.Errorf("String() = %q, want %q", got, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+// Dependencies are declared by //DEPS
+//DEPSLIST is not a directive
+class Class {
+    // @Grab is not used here
+}
\ No newline at end of file
@@ -0,0 +1,4 @@
+@Grab('org.apache.beam:beam-sdks-java-core:2.35.0')
+  @GrabResolver(name='beam', root='https://repo.example.com/')
+class Class {
+}
\ No newline at end of file
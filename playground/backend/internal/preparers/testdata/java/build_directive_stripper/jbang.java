@@ -0,0 +1,5 @@
+///usr/bin/env jbang "$0" "$@" ; exit $?
+//DEPS org.apache.beam:beam-sdks-java-core:2.35.0
+
+class Class {
+}
\ No newline at end of file
@@ -0,0 +1,13 @@
+import java.util.function.Supplier;
+
+@FunctionalInterface
+interface Greeting {
+    String greet();
+}
+
+class Main {
+    public static void main(String[] args) {
+        Greeting greeting = () -> "class Fake {}";
+        System.out.println(greeting.greet());
+    }
+}
\ No newline at end of file
@@ -0,0 +1,7 @@
+class Main {
+    class Inner {
+    }
+
+    public static void main(String[] args) {
+    }
+}
\ No newline at end of file
@@ -0,0 +1,17 @@
+package org.apache.beam.examples;
+
+import static java.lang.Math.max;
+import static java.lang.Math.min;
+
+public class Main {
+    public static void main(String[] args) {
+        System.out.println(Helper.clamp(5));
+    }
+}
+
+// Helper clamps values
+class Helper {
+    static int clamp(int value) {
+        return max(0, min(value, 3));
+    }
+}
@@ -0,0 +1,7 @@
+import sun.misc.*;
+
+public class Main {
+    public static void main(String[] args) throws Exception {
+        System.out.println(Unsafe.class.getName());
+    }
+}
@@ -0,0 +1,10 @@
+///usr/bin/env jbang "$0" "$@" ; exit $?
+package org.apache.beam.examples;
+
+import java.util.Arrays;
+
+public class WordCount {
+    public static void main(String[] args) {
+        System.out.println(Arrays.asList(args));
+    }
+}
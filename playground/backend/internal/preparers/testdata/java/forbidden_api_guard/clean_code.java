@@ -0,0 +1,5 @@
+class Class {
+    public static void main(String[] args) {
+        System.out.println("Hello World!");
+    }
+}
\ No newline at end of file
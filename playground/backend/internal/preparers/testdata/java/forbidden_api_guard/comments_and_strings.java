@@ -0,0 +1,10 @@
+// import sun.misc.Unsafe;
+
+class Class {
+    /* java.nio.file.Files.write(path, bytes)
+       sun.reflect.Reflection */
+    public static void main(String[] args) {
+        System.out.println("sun.misc.Unsafe is forbidden");
+        char c = '"'; String s = "sun.misc.Unsafe\" ";
+    }
+}
\ No newline at end of file
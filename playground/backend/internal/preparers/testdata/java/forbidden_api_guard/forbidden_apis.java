@@ -0,0 +1,8 @@
+import sun.misc.Unsafe;
+
+class Class {
+    public static void main(String[] args) throws Exception {
+        java.nio.file.Files.write(java.nio.file.Paths.get("/tmp/file"), new byte[0]);
+        Object o = sun.reflect.Reflection.getCallerClass();
+    }
+}
\ No newline at end of file
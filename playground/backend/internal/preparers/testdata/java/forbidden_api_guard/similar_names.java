@@ -0,0 +1,8 @@
+import sun.misc.UnsafeWrapper;
+
+class Class {
+    public static void main(String[] args) {
+        my.sun.misc.Unsafe.run();
+        java.nio.file.Files.writeString(null, null);
+    }
+}
\ No newline at end of file
@@ -0,0 +1,10 @@
+import java.nio.file.Files;
+import java.nio.file.Paths;
+
+class Class {
+    public static void main(String[] args) throws Exception {
+        Files.write(Paths.get("/tmp/file"), new byte[0]);
+        Files.readAllLines(Paths.get("/tmp/file"));
+        new ProcessBuilder("ls").start();
+    }
+}
@@ -0,0 +1,9 @@
+import static java.nio.file.Files.*;
+import static java.nio.file.Paths.get;
+
+class Class {
+    public static void main(String[] args) throws Exception {
+        write(get("/tmp/file"), new byte[0]);
+        System.out.write(0);
+    }
+}
@@ -0,0 +1,9 @@
+import java.lang.reflect.*;
+import java.nio.file.*;
+
+class Class {
+    public static void main(String[] args) throws Exception {
+        Method method = Class.class.getMethod("main", String[].class);
+        Files.write(Paths.get("/tmp/file"), new byte[0]);
+    }
+}
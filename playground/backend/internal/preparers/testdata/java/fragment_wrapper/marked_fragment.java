@@ -0,0 +1,16 @@
+import java.util.Arrays;
+import java.util.List;
+
+public class Tutorial {
+    static List<String> words() {
+        return Arrays.asList("a", "b");
+    }
+
+    public static void main(String[] args) {
+        // BEGIN
+        List<String> words = Arrays.asList("hello", "fragment");
+        System.out.println(String.join(" ", words));
+        // END
+        System.out.println(words());
+    }
+}
@@ -0,0 +1,14 @@
+public class Tutorial {
+    /*
+    // BEGIN
+    */
+    public static void main(String[] args) {
+        // BEGIN
+        String usage = """
+            // END
+            """;
+        System.out.println(usage);
+        // END
+        System.out.println("// END");
+    }
+}
@@ -0,0 +1,6 @@
+public class Tutorial {
+    public static void main(String[] args) {
+        System.out.println("Hello");
+        // END
+    }
+}
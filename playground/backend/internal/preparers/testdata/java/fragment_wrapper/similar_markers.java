@@ -0,0 +1,7 @@
+public class Tutorial {
+    public static void main(String[] args) {
+        // BEGINNER notes: the code prints the greeting
+        System.out.println("Hello");
+        // ENDING
+    }
+}
@@ -0,0 +1,7 @@
+package org.apache.beam.learning.katas.intro.hello;
+
+public class Task {
+    public static void main(String[] args) {
+        System.out.println("Hello Beam");
+    }
+}
@@ -0,0 +1,3 @@
+@Deprecated(since = "9")
+module legacy {
+}
\ No newline at end of file
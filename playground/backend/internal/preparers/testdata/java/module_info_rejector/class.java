@@ -0,0 +1,6 @@
+// module foo { }
+class Module {
+    String module = "module bar {";
+    void module() {
+    }
+}
\ No newline at end of file
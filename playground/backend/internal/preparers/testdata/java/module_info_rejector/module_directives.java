@@ -0,0 +1,10 @@
+import java.util.List;
+
+requires java.sql;
+exports com.example.app;
+
+class Main {
+    public static void main(String[] args) {
+        System.out.println(List.of("requires"));
+    }
+}
@@ -0,0 +1,12 @@
+module org.apache.beam.examples {
+    requires org.apache.beam.sdk;
+    exports org.apache.beam.examples;
+}
+
+package org.apache.beam.examples;
+
+public class WordCount {
+    public static void main(String[] args) {
+        System.out.println("module info");
+    }
+}
\ No newline at end of file
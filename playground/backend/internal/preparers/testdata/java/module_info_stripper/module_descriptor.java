@@ -0,0 +1,6 @@
+import java.sql.Driver;
+
+open module com.example.app {
+    requires java.sql;
+    uses Driver;
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+requires org.apache.beam.sdk;
+requires transitive java.sql;
+
+import java.util.Arrays;
+
+public class WordCount {
+    static String requires = "exports";
+
+    public static void main(String[] args) {
+        System.out.println(Arrays.asList(requires));
+    }
+}
\ No newline at end of file
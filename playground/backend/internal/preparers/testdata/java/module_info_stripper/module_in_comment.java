@@ -0,0 +1,11 @@
+/*
+ * module org.apache.beam.examples {
+ *     requires org.apache.beam.sdk;
+ * }
+ */
+// requires java.sql;
+public class WordCount {
+    public static void main(String[] args) {
+        System.out.println("module org.apache.beam.examples {");
+    }
+}
\ No newline at end of file
@@ -0,0 +1,5 @@
+class Class {
+    public static void main(String[] args) throws Exception {
+        new Thread(() -> System.out.print("Hello")).start();
+    }
+}
\ No newline at end of file
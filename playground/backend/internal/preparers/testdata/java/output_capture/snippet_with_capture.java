@@ -0,0 +1,10 @@
+class Class {
+    public static void main(String[] args) throws Exception {
+        try {
+        new Thread(() -> System.out.print("Hello")).start();
+        } finally {
+            System.out.flush();
+            System.err.flush();
+        }
+    }
+}
\ No newline at end of file
@@ -0,0 +1,6 @@
+class Class {
+    public static void main(String[] args) {
+        System.out.print("Hello");
+        System.out.flush();
+    }
+}
\ No newline at end of file
@@ -0,0 +1,4 @@
+package org.apache.beam.examples;
+
+class Class {
+}
\ No newline at end of file
@@ -0,0 +1,12 @@
+package a.b.c;
+
+import a.b.c.Helper;
+
+class Class {
+    public static void main(String[] args) {
+        // a.b.c.Helper is next to the code
+        a.b.c.Helper helper = new a.b.c.Helper();
+        a.b.cd.Other other = new a.b.cd.Other();
+        System.out.println(helper.name() + "a.b.c.Helper");
+    }
+}
@@ -0,0 +1,7 @@
+package a.b.c;
+
+class Helper {
+    String name() {
+        return "helper";
+    }
+}
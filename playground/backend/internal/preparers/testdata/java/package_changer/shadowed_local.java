@@ -0,0 +1,25 @@
+package org.example;
+
+import static org.example.Utils.*;
+
+import java.util.List;
+import java.util.function.Function;
+
+public class Main {
+    public static void main(String[] args) {
+        String format = format(1);
+        int first = 1, describe = 2;
+        System.out.println(format + describe + PREFIX);
+        List<String> values = List.of(format, describe(format));
+        Function<Integer, String> formatter = x -> format(x);
+        values.forEach(PREFIX -> System.out.println(PREFIX));
+        for (String[] PREFIX : List.of(args)) {
+            System.out.println(PREFIX.length);
+        }
+        print(first);
+    }
+
+    static void print(int format) {
+        System.out.println(format(format) + PREFIX);
+    }
+}
@@ -0,0 +1,17 @@
+package org.example;
+
+class Utils {
+    static final String PREFIX = "> ";
+
+    static String format(int x) {
+        return PREFIX + x;
+    }
+
+    static String describe(String value) {
+        return PREFIX + value;
+    }
+
+    public static void main(String[] args) {
+        System.out.println(format(1));
+    }
+}
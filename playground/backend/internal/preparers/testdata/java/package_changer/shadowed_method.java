@@ -0,0 +1,13 @@
+package org.example;
+
+import static org.example.Utils.*;
+
+public class Main {
+    static String describe(String value) {
+        return "Main " + value;
+    }
+
+    public static void main(String[] args) {
+        System.out.println(describe(format(1)) + PREFIX);
+    }
+}
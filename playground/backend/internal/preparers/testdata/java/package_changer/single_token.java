@@ -0,0 +1,4 @@
+package examples;
+
+class Class {
+}
\ No newline at end of file
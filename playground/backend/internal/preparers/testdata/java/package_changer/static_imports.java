@@ -0,0 +1,12 @@
+package org.example;
+
+import static org.example.Utils.greet;
+import static org.example.Constants.*;
+import static java.lang.Math.max;
+import org.example.util.Strings;
+
+class Class {
+    public static void main(String[] args) {
+        System.out.println(greet(Strings.trim(NAME)) + max(1, 2));
+    }
+}
@@ -0,0 +1,5 @@
+package org.example;
+
+class Constants {
+    static final String NAME = "org.example.Utils";
+}
@@ -0,0 +1,7 @@
+package org.example;
+
+class Utils {
+    static String greet(String name) {
+        return "Hello " + name;
+    }
+}
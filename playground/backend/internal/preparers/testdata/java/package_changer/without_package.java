@@ -0,0 +1,4 @@
+import java.util.List;
+
+class Class {
+}
\ No newline at end of file
@@ -0,0 +1,6 @@
+class Class {
+    // Random random = new Random();
+    public static void main(String[] args) {
+        System.out.println("new Random()"); /* new Random() */
+    }
+}
\ No newline at end of file
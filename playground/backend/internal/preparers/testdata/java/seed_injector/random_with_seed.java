@@ -0,0 +1,6 @@
+class Class {
+    public static void main(String[] args) {
+        Random random = new Random(123);
+        SecureRandom secure = new SecureRandom();
+    }
+}
\ No newline at end of file
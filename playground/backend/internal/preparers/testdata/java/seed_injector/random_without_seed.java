@@ -0,0 +1,8 @@
+import java.util.Random;
+
+class Class {
+    public static void main(String[] args) {
+        Random random = new Random();
+        java.util.Random other = new java.util.Random( );
+    }
+}
\ No newline at end of file
@@ -0,0 +1,3 @@
+public \uu0063lass Class {
+    String path = "C:\\u0070ath";
+}
\ No newline at end of file
@@ -0,0 +1 @@
+package com.foo;\u000aimport java.util.List;
\ No newline at end of file
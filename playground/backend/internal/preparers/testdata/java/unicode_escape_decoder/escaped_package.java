@@ -0,0 +1,4 @@
+\u0070ackage com.foo;
+
+class Class {
+}
\ No newline at end of file
@@ -0,0 +1,3 @@
+class Class {
+    String quote = "\u0022";
+}
\ No newline at end of file
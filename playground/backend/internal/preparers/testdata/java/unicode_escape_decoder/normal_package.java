@@ -0,0 +1,4 @@
+package com.foo;
+
+class Class {
+}
\ No newline at end of file
@@ -0,0 +1,9 @@
+\u0070ackage com.foo;
+
+import org.junit.Test;
+
+public class WordCountTest {
+    @Test
+    public void testCount() {
+    }
+}
@@ -0,0 +1,10 @@
+import org.junit.Test;
+
+public class WordCountTest {
+    @Test
+    public void testCount() {
+    }
+}
+
+public class WordCounter {
+}
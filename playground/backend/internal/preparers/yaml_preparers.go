@@ -0,0 +1,149 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+const (
+	placeholderPattern    = `\{\{\s*(\w+)\s*\}\}`
+	yamlOptionsKeyPattern = `(?m)^options\s*:`
+	defaultYamlOptions    = "options:\n  runner: DirectRunner\n"
+	outputDirPlaceholder  = "output_dir"
+	yamlFileMode          = 0600
+)
+
+var (
+	placeholderRegexp    = regexp.MustCompile(placeholderPattern)
+	yamlOptionsKeyRegexp = regexp.MustCompile(yamlOptionsKeyPattern)
+)
+
+//YamlPreparersBuilder facet of PreparersBuilder
+type YamlPreparersBuilder struct {
+	PreparersBuilder
+}
+
+//YamlPreparers chains to type *PreparersBuilder and returns a *YamlPreparersBuilder
+func (builder *PreparersBuilder) YamlPreparers() *YamlPreparersBuilder {
+	return &YamlPreparersBuilder{*builder}
+}
+
+//WithPlaceholderSubstitution adds preparer to replace {{ name }} placeholders with values of placeholders
+func (builder *YamlPreparersBuilder) WithPlaceholderSubstitution(placeholders map[string]string) *YamlPreparersBuilder {
+	placeholderSubstitutor := Preparer{
+		Name:        PlaceholderSubstitutorName,
+		Prepare:     substitutePlaceholders,
+		Args:        []interface{}{builder.filePath, placeholders, builder.logger},
+		Transform:   substitutePlaceholdersTransform(placeholders),
+		Description: "substituted placeholders",
+	}
+	builder.AddPreparer(placeholderSubstitutor)
+	return builder
+}
+
+//WithDefaultOptions adds preparer to add options to the pipeline which doesn't declare own options
+func (builder *YamlPreparersBuilder) WithDefaultOptions(options string) *YamlPreparersBuilder {
+	defaultOptionsInjector := Preparer{
+		Name:        DefaultOptionsInjectorName,
+		Prepare:     injectDefaultOptions,
+		Args:        []interface{}{builder.filePath, options, builder.logger},
+		Description: "added the default pipeline options",
+	}
+	builder.AddPreparer(defaultOptionsInjector)
+	return builder
+}
+
+// GetYamlPreparers returns preparation methods that should be applied to Beam YAML pipelines
+func GetYamlPreparers(builder *PreparersBuilder) {
+	builder.YamlPreparers().
+		WithPlaceholderSubstitution(YamlPlaceholders(builder.filePath)).
+		WithDefaultOptions(defaultYamlOptions)
+}
+
+// YamlRegistry returns preparers of Beam YAML pipelines which can be skipped or forced by name
+func YamlRegistry() Registry {
+	return Registry{
+		PlaceholderSubstitutorName: func(builder *PreparersBuilder) {
+			builder.YamlPreparers().WithPlaceholderSubstitution(YamlPlaceholders(builder.filePath))
+		},
+		DefaultOptionsInjectorName: func(builder *PreparersBuilder) { builder.YamlPreparers().WithDefaultOptions(defaultYamlOptions) },
+		LineEndingNormalizerName:   func(builder *PreparersBuilder) { builder.WithLineEndingNormalizer(LineEndingLf) },
+		Utf8ValidatorName:          func(builder *PreparersBuilder) { builder.WithUtf8Validator() },
+		IncludeResolverName:        func(builder *PreparersBuilder) { builder.WithIncludeResolver(builder.codeDir()) },
+	}
+}
+
+// YamlPlaceholders returns values of placeholders which are available for the pipeline by filePath.
+// {{ output_dir }} is the folder of the pipeline, so written files are removed with the pipeline.
+func YamlPlaceholders(filePath string) map[string]string {
+	return map[string]string{
+		outputDirPlaceholder: filepath.Dir(filePath),
+	}
+}
+
+// substitutePlaceholders processes file by filePath and replaces placeholders with their values
+func substitutePlaceholders(args ...interface{}) error {
+	filePath := args[0].(string)
+	placeholders := args[1].(map[string]string)
+	log := loggerFromArgs(args, 2)
+
+	return transformFile(filePath, []LineTransform{substitutePlaceholdersTransform(placeholders)}, log)
+}
+
+// substitutePlaceholdersTransform returns LineTransform which replaces placeholders with their values.
+// Unknown placeholders are kept as they are.
+func substitutePlaceholdersTransform(placeholders map[string]string) LineTransform {
+	return func(line string) (string, error) {
+		if !placeholderRegexp.MatchString(line) {
+			return line, nil
+		}
+		return placeholderRegexp.ReplaceAllStringFunc(line, func(placeholder string) string {
+			name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+			if value, ok := placeholders[name]; ok {
+				return value
+			}
+			return placeholder
+		}), nil
+	}
+}
+
+// injectDefaultOptions processes file by filePath and appends options if the pipeline has no top-level options key
+func injectDefaultOptions(args ...interface{}) error {
+	filePath := args[0].(string)
+	options := args[1].(string)
+	log := loggerFromArgs(args, 2)
+
+	code, err := readSourceFile(filePath)
+	if err != nil {
+		log.Errorf("Preparation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return err
+	}
+	if yamlOptionsKeyRegexp.Match(code) {
+		return nil
+	}
+	if len(code) > 0 && code[len(code)-1] != '\n' {
+		code = append(code, newLinePattern...)
+	}
+	code = append(code, options...)
+	if err = os.WriteFile(filePath, code, yamlFileMode); err != nil {
+		log.Errorf("Preparation: Error during write \"%s\" to file: %s, err: %s\n", options, filePath, err.Error())
+		return err
+	}
+	return nil
+}
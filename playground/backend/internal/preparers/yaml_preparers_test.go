@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preparers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_substitutePlaceholders(t *testing.T) {
+	placeholders := map[string]string{"output_dir": "/tmp/pipeline"}
+	tests := []struct {
+		name     string
+		code     string
+		wantCode string
+	}{
+		{
+			name:     "known placeholders",
+			code:     "- type: WriteToText\n  config:\n    path: \"{{ output_dir }}/out\"\n    prefix: {{output_dir}}",
+			wantCode: "- type: WriteToText\n  config:\n    path: \"/tmp/pipeline/out\"\n    prefix: /tmp/pipeline",
+		},
+		{
+			// Test that placeholders without values are left for the user to see in the error
+			name:     "unknown placeholder",
+			code:     "path: {{ input_dir }}/in",
+			wantCode: "path: {{ input_dir }}/in",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "pipeline.yaml")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			if err := substitutePlaceholders(filePath, placeholders); err != nil {
+				t.Errorf("substitutePlaceholders() unexpected error = %v", err)
+			}
+			data, _ := os.ReadFile(filePath)
+			if string(data) != tt.wantCode {
+				t.Errorf("substitutePlaceholders() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_injectDefaultOptions(t *testing.T) {
+	pipeline := "pipeline:\n  transforms:\n    - type: Create\n      config:\n        elements: [1, 2, 3]"
+	tests := []struct {
+		name     string
+		code     string
+		wantCode string
+	}{
+		{
+			name:     "without options",
+			code:     pipeline,
+			wantCode: pipeline + "\n" + defaultYamlOptions,
+		},
+		{
+			name:     "with options",
+			code:     "options:\n  streaming: false\n" + pipeline,
+			wantCode: "options:\n  streaming: false\n" + pipeline,
+		},
+		{
+			// Test that options of a transform are not taken as the pipeline options
+			name:     "with nested options",
+			code:     pipeline + "\n      options:\n        key: value\n",
+			wantCode: pipeline + "\n      options:\n        key: value\n" + defaultYamlOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "pipeline.yaml")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			if err := injectDefaultOptions(filePath, defaultYamlOptions); err != nil {
+				t.Errorf("injectDefaultOptions() unexpected error = %v", err)
+			}
+			data, _ := os.ReadFile(filePath)
+			if string(data) != tt.wantCode {
+				t.Errorf("injectDefaultOptions() code = {%v}, wantCode {%v}", string(data), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGetYamlPreparers(t *testing.T) {
+	builder := NewPreparersBuilder("MOCK_FILEPATH")
+	GetYamlPreparers(builder)
+	if got := builder.Build().GetPreparers(); len(*got) != 2 {
+		t.Errorf("GetYamlPreparers() returns %v Preparers, want %v", len(*got), 2)
+	}
+}
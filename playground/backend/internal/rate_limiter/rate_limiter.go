@@ -0,0 +1,241 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rate_limiter
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ClientKeyHeader is a metadata key which is used by clients to send their key
+	ClientKeyHeader    = "x-client-key"
+	forwardedForHeader = "x-forwarded-for"
+	retryAfterHeader   = "retry-after"
+	gcInterval         = time.Minute
+	unknownClient      = "unknown"
+)
+
+// bucket keeps tokens of the client
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// RateLimiter limits requests of each client using token buckets.
+// Each client has a bucket with burst tokens which is refilled with rate tokens per second.
+// Each request takes one token; request without tokens is rejected.
+type RateLimiter struct {
+	mu             sync.Mutex
+	rate           float64
+	burst          float64
+	clientKeys     map[string]bool
+	exemptions     map[string]bool
+	trustedProxies []*net.IPNet
+	buckets        map[string]*bucket
+	now            func() time.Time
+}
+
+// New creates RateLimiter and starts garbage collection of stale buckets until ctx is done.
+// clientKeys are keys which clients are allowed to use as their identity instead of IP address.
+// exemptions are client keys or IP addresses which aren't limited.
+// trustedProxies are networks of proxies in front of the server whose x-forwarded-for header is trusted.
+func New(ctx context.Context, rate float64, burst int, clientKeys, exemptions []string, trustedProxies []*net.IPNet) *RateLimiter {
+	rl := &RateLimiter{
+		rate:           rate,
+		burst:          float64(burst),
+		clientKeys:     toSet(clientKeys),
+		exemptions:     toSet(exemptions),
+		trustedProxies: trustedProxies,
+		buckets:        make(map[string]*bucket),
+		now:            time.Now,
+	}
+	go rl.startGC(ctx)
+	return rl
+}
+
+// Allow takes a token from the client's bucket.
+// If there are no tokens returns false and duration after which a token will be available.
+func (rl *RateLimiter) Allow(client string) (bool, time.Duration) {
+	if rl.exemptions[client] {
+		return true, 0
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.now()
+	b, ok := rl.buckets[client]
+	if !ok {
+		b = &bucket{tokens: rl.burst, lastRefill: now}
+		rl.buckets[client] = b
+	}
+	rl.refill(b, now)
+	if b.tokens < 1 {
+		retryAfter := time.Duration((1 - b.tokens) / rl.rate * float64(time.Second))
+		return false, retryAfter
+	}
+	b.tokens--
+	return true, 0
+}
+
+// UnaryInterceptor returns grpc.UnaryServerInterceptor which limits calls of methods.
+// Other methods aren't limited. Rejected calls receive codes.ResourceExhausted with the retry delay.
+func (rl *RateLimiter) UnaryInterceptor(methods ...string) grpc.UnaryServerInterceptor {
+	limitedMethods := toSet(methods)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !limitedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		client := rl.clientIdentity(ctx)
+		if allowed, retryAfter := rl.Allow(client); !allowed {
+			return nil, resourceExhaustedError(ctx, retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// clientIdentity returns the key sent by the client if it is a known or exempted key, otherwise returns the client's IP address.
+// IP address is taken from x-forwarded-for header only if the call comes from a trusted proxy,
+// because any other caller can put any address to the header.
+func (rl *RateLimiter) clientIdentity(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range md.Get(ClientKeyHeader) {
+		if rl.clientKeys[key] || rl.exemptions[key] {
+			return key
+		}
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return unknownClient
+	}
+	peerAddress, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		peerAddress = p.Addr.String()
+	}
+	if !rl.isTrustedProxy(peerAddress) {
+		return peerAddress
+	}
+	if forwardedAddress := rl.forwardedFor(md); forwardedAddress != "" {
+		return forwardedAddress
+	}
+	return peerAddress
+}
+
+// forwardedFor returns the address of the client from x-forwarded-for header of the call which comes from a trusted proxy.
+// Each proxy appends the address of its caller to the header, so the right-most address which isn't a trusted proxy
+// is the client. Addresses on the left of it are sent by the client and can't be trusted.
+// If all addresses are trusted proxies, returns the left-most one.
+func (rl *RateLimiter) forwardedFor(md metadata.MD) string {
+	var hops []string
+	for _, forwardedFor := range md.Get(forwardedForHeader) {
+		for _, hop := range strings.Split(forwardedFor, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !rl.isTrustedProxy(hops[i]) {
+			return hops[i]
+		}
+	}
+	if len(hops) > 0 {
+		return hops[0]
+	}
+	return ""
+}
+
+// isTrustedProxy returns true if the address belongs to one of trusted proxies
+func (rl *RateLimiter) isTrustedProxy(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, network := range rl.trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// refill adds tokens to the bucket according to the time passed since the last refill
+func (rl *RateLimiter) refill(b *bucket, now time.Time) {
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.tokens = math.Min(rl.burst, b.tokens+elapsed*rl.rate)
+	b.lastRefill = now
+}
+
+func (rl *RateLimiter) startGC(ctx context.Context) {
+	ticker := time.NewTicker(gcInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			rl.removeStaleBuckets()
+		}
+	}
+}
+
+// removeStaleBuckets removes buckets which are refilled completely.
+// Such buckets are the same as new ones, so they don't need to be kept.
+func (rl *RateLimiter) removeStaleBuckets() {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	now := rl.now()
+	for client, b := range rl.buckets {
+		rl.refill(b, now)
+		if b.tokens >= rl.burst {
+			delete(rl.buckets, client)
+		}
+	}
+}
+
+// resourceExhaustedError returns codes.ResourceExhausted error with the retry delay.
+// The retry delay is also sent as the retry-after header in seconds.
+func resourceExhaustedError(ctx context.Context, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, fmt.Sprint(seconds)))
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("Too many requests, retry after %d seconds", seconds))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			set[value] = true
+		}
+	}
+	return set
+}
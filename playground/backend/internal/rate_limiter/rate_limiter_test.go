@@ -0,0 +1,333 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rate_limiter
+
+import (
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+	"time"
+)
+
+const (
+	limitedMethod   = "/api.v1.PlaygroundService/RunCode"
+	unlimitedMethod = "/api.v1.PlaygroundService/CheckStatus"
+)
+
+// fakeClock is used to control the time of the RateLimiter
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestRateLimiter(t *testing.T, rate float64, burst int, clientKeys, exemptions []string, trustedProxies ...string) (*RateLimiter, *fakeClock) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	clock := &fakeClock{now: time.Now()}
+	var networks []*net.IPNet
+	for _, trustedProxy := range trustedProxies {
+		_, network, err := net.ParseCIDR(trustedProxy)
+		if err != nil {
+			t.Fatalf("wrong trusted proxy %s: %s", trustedProxy, err)
+		}
+		networks = append(networks, network)
+	}
+	rl := New(ctx, rate, burst, clientKeys, exemptions, networks)
+	rl.now = clock.Now
+	return rl, clock
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	tests := []struct {
+		name string
+		// steps is a sequence of requests: each step waits for the duration and then sends requests of the client
+		steps []step
+	}{
+		{
+			name: "burst is allowed, next request is rejected",
+			steps: []step{
+				{client: "client", requests: 3, wantAllowed: 3},
+				{client: "client", requests: 1, wantAllowed: 0, wantRetryAfter: 500 * time.Millisecond},
+			},
+		},
+		{
+			name: "bucket is refilled over time",
+			steps: []step{
+				{client: "client", requests: 3, wantAllowed: 3},
+				{wait: 250 * time.Millisecond, client: "client", requests: 1, wantAllowed: 0, wantRetryAfter: 250 * time.Millisecond},
+				{wait: 750 * time.Millisecond, client: "client", requests: 3, wantAllowed: 2},
+				{wait: 10 * time.Second, client: "client", requests: 4, wantAllowed: 3},
+			},
+		},
+		{
+			name: "distinct clients have distinct buckets",
+			steps: []step{
+				{client: "client_1", requests: 4, wantAllowed: 3},
+				{client: "client_2", requests: 4, wantAllowed: 3},
+				{wait: 500 * time.Millisecond, client: "client_1", requests: 2, wantAllowed: 1},
+			},
+		},
+		{
+			name: "exempted client isn't limited",
+			steps: []step{
+				{client: "health_check", requests: 100, wantAllowed: 100},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl, clock := newTestRateLimiter(t, 2, 3, nil, []string{"health_check"})
+			for i, s := range tt.steps {
+				clock.Add(s.wait)
+				allowed := 0
+				var retryAfter time.Duration
+				for j := 0; j < s.requests; j++ {
+					ok, after := rl.Allow(s.client)
+					if ok {
+						allowed++
+					} else {
+						retryAfter = after
+					}
+				}
+				if allowed != s.wantAllowed {
+					t.Errorf("step %d: Allow() allowed %d requests, want %d", i, allowed, s.wantAllowed)
+				}
+				if s.wantRetryAfter != 0 && retryAfter != s.wantRetryAfter {
+					t.Errorf("step %d: Allow() retry after %s, want %s", i, retryAfter, s.wantRetryAfter)
+				}
+			}
+		})
+	}
+}
+
+type step struct {
+	wait           time.Duration
+	client         string
+	requests       int
+	wantAllowed    int
+	wantRetryAfter time.Duration
+}
+
+func TestRateLimiter_removeStaleBuckets(t *testing.T) {
+	rl, clock := newTestRateLimiter(t, 1, 2, nil, nil)
+	rl.Allow("stale_client")
+	clock.Add(time.Second)
+	rl.Allow("active_client")
+	rl.Allow("active_client")
+	clock.Add(time.Second)
+
+	rl.removeStaleBuckets()
+
+	if _, ok := rl.buckets["stale_client"]; ok {
+		t.Errorf("removeStaleBuckets() didn't remove refilled bucket")
+	}
+	if _, ok := rl.buckets["active_client"]; !ok {
+		t.Errorf("removeStaleBuckets() removed bucket which isn't refilled")
+	}
+}
+
+func TestRateLimiter_UnaryInterceptor(t *testing.T) {
+	rl, _ := newTestRateLimiter(t, 1, 1, []string{"known_key"}, []string{"frontend_key"}, "172.16.0.0/12")
+	interceptor := rl.UnaryInterceptor(limitedMethod)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "OK", nil
+	}
+	peerCtx := func(ip string, md metadata.MD) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234}})
+		return metadata.NewIncomingContext(ctx, md)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+	}{
+		{
+			name:     "first call of the client",
+			ctx:      peerCtx("10.0.0.1", nil),
+			method:   limitedMethod,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "second call of the client is rejected",
+			ctx:      peerCtx("10.0.0.1", nil),
+			method:   limitedMethod,
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:     "not limited method",
+			ctx:      peerCtx("10.0.0.1", nil),
+			method:   unlimitedMethod,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "another client",
+			ctx:      peerCtx("10.0.0.2", nil),
+			method:   limitedMethod,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "client behind trusted proxy",
+			ctx:      peerCtx("172.16.0.1", metadata.Pairs(forwardedForHeader, "192.168.0.1, 172.16.0.2")),
+			method:   limitedMethod,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "client behind trusted proxy with spoofed left-most address",
+			ctx:      peerCtx("172.16.0.1", metadata.Pairs(forwardedForHeader, "192.168.0.2, 192.168.0.1")),
+			method:   limitedMethod,
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:     "spoofed x-forwarded-for of untrusted peer",
+			ctx:      peerCtx("10.0.0.1", metadata.Pairs(forwardedForHeader, "192.168.0.3")),
+			method:   limitedMethod,
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:     "client with known key",
+			ctx:      peerCtx("10.0.0.1", metadata.Pairs(ClientKeyHeader, "known_key")),
+			method:   limitedMethod,
+			wantCode: codes.OK,
+		},
+		{
+			name:     "client with unknown key is identified by IP",
+			ctx:      peerCtx("10.0.0.2", metadata.Pairs(ClientKeyHeader, "unknown_key")),
+			method:   limitedMethod,
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:     "exempted client",
+			ctx:      peerCtx("10.0.0.1", metadata.Pairs(ClientKeyHeader, "frontend_key")),
+			method:   limitedMethod,
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("UnaryInterceptor() code = %s, want %s", st.Code(), tt.wantCode)
+			}
+			if tt.wantCode != codes.ResourceExhausted {
+				return
+			}
+			for _, detail := range st.Details() {
+				if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.RetryDelay.AsDuration() > 0 {
+					return
+				}
+			}
+			t.Errorf("UnaryInterceptor() error doesn't contain the retry delay: %v", st.Details())
+		})
+	}
+}
+
+func TestRateLimiter_clientIdentity(t *testing.T) {
+	rl, _ := newTestRateLimiter(t, 1, 1, []string{"known_key"}, []string{"10.0.0.9"}, "172.16.0.0/12", "fd00::/8")
+	peerCtx := func(address string, md metadata.MD) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(address), Port: 1234}})
+		return metadata.NewIncomingContext(ctx, md)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "peer without x-forwarded-for",
+			ctx:  peerCtx("10.0.0.1", nil),
+			want: "10.0.0.1",
+		},
+		{
+			name: "spoofed x-forwarded-for of untrusted peer",
+			ctx:  peerCtx("10.0.0.1", metadata.Pairs(forwardedForHeader, "192.168.0.1")),
+			want: "10.0.0.1",
+		},
+		{
+			name: "spoofed exempted address of untrusted peer",
+			ctx:  peerCtx("10.0.0.1", metadata.Pairs(forwardedForHeader, "10.0.0.9")),
+			want: "10.0.0.1",
+		},
+		{
+			name: "trusted proxy",
+			ctx:  peerCtx("172.16.0.1", metadata.Pairs(forwardedForHeader, "192.168.0.1")),
+			want: "192.168.0.1",
+		},
+		{
+			name: "chain of trusted proxies",
+			ctx:  peerCtx("172.16.0.1", metadata.Pairs(forwardedForHeader, "192.168.0.1, 172.16.0.3, 172.16.0.2")),
+			want: "192.168.0.1",
+		},
+		{
+			name: "addresses sent by the client are skipped",
+			ctx:  peerCtx("172.16.0.1", metadata.Pairs(forwardedForHeader, "10.0.0.9, 1.2.3.4, 192.168.0.1")),
+			want: "192.168.0.1",
+		},
+		{
+			name: "several x-forwarded-for headers",
+			ctx:  peerCtx("172.16.0.1", metadata.Pairs(forwardedForHeader, "10.0.0.9", forwardedForHeader, "192.168.0.1, 172.16.0.2")),
+			want: "192.168.0.1",
+		},
+		{
+			name: "all addresses are trusted proxies",
+			ctx:  peerCtx("172.16.0.1", metadata.Pairs(forwardedForHeader, "172.16.0.3, 172.16.0.2")),
+			want: "172.16.0.3",
+		},
+		{
+			name: "trusted proxy without x-forwarded-for",
+			ctx:  peerCtx("172.16.0.1", nil),
+			want: "172.16.0.1",
+		},
+		{
+			name: "trusted IPv6 proxy",
+			ctx:  peerCtx("fd00::1", metadata.Pairs(forwardedForHeader, "2001:db8::1")),
+			want: "2001:db8::1",
+		},
+		{
+			name: "known key",
+			ctx:  peerCtx("10.0.0.1", metadata.Pairs(ClientKeyHeader, "known_key", forwardedForHeader, "192.168.0.1")),
+			want: "known_key",
+		},
+		{
+			name: "without peer",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs(forwardedForHeader, "192.168.0.1")),
+			want: unknownClient,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rl.clientIdentity(tt.ctx); got != tt.want {
+				t.Errorf("clientIdentity() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run_history
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"sync"
+)
+
+// LocalStore is an in-memory implementation of Store. The run history is lost when the server restarts.
+type LocalStore struct {
+	sync.RWMutex
+	retention int
+	records   map[string][]*pb.RunRecord
+}
+
+// NewLocalStore returns the in-memory Store which keeps retention records for each example
+func NewLocalStore(retention int) *LocalStore {
+	return &LocalStore{
+		retention: retention,
+		records:   make(map[string][]*pb.RunRecord),
+	}
+}
+
+func (ls *LocalStore) Add(ctx context.Context, record *pb.RunRecord) error {
+	ls.Lock()
+	defer ls.Unlock()
+	records := append([]*pb.RunRecord{record}, ls.records[record.ExampleId]...)
+	if len(records) > ls.retention {
+		records = records[:ls.retention]
+	}
+	ls.records[record.ExampleId] = records
+	return nil
+}
+
+func (ls *LocalStore) List(ctx context.Context, exampleId string, limit int) ([]*pb.RunRecord, error) {
+	ls.RLock()
+	defer ls.RUnlock()
+	records := ls.records[exampleId]
+	if limit <= 0 {
+		return []*pb.RunRecord{}, nil
+	}
+	if limit < len(records) {
+		records = records[:limit]
+	}
+	return append([]*pb.RunRecord{}, records...), nil
+}
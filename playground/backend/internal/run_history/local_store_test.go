@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run_history
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention int
+		added     []*pb.RunRecord
+		exampleId string
+		limit     int
+		want      []*pb.RunRecord
+	}{
+		{
+			name:      "records of the example, the most recent first",
+			retention: 10,
+			added: []*pb.RunRecord{
+				{PipelineUuid: "1", ExampleId: "SDK_JAVA/Example", Status: pb.Status_STATUS_FINISHED},
+				{PipelineUuid: "2", ExampleId: "SDK_JAVA/Other", Status: pb.Status_STATUS_FINISHED},
+				{PipelineUuid: "3", ExampleId: "SDK_JAVA/Example", Status: pb.Status_STATUS_RUN_ERROR, ErrorSummary: "error"},
+			},
+			exampleId: "SDK_JAVA/Example",
+			limit:     10,
+			want: []*pb.RunRecord{
+				{PipelineUuid: "3", ExampleId: "SDK_JAVA/Example", Status: pb.Status_STATUS_RUN_ERROR, ErrorSummary: "error"},
+				{PipelineUuid: "1", ExampleId: "SDK_JAVA/Example", Status: pb.Status_STATUS_FINISHED},
+			},
+		},
+		{
+			name:      "records over the retention are removed",
+			retention: 2,
+			added: []*pb.RunRecord{
+				{PipelineUuid: "1", ExampleId: "SDK_JAVA/Example"},
+				{PipelineUuid: "2", ExampleId: "SDK_JAVA/Example"},
+				{PipelineUuid: "3", ExampleId: "SDK_JAVA/Example"},
+			},
+			exampleId: "SDK_JAVA/Example",
+			limit:     10,
+			want: []*pb.RunRecord{
+				{PipelineUuid: "3", ExampleId: "SDK_JAVA/Example"},
+				{PipelineUuid: "2", ExampleId: "SDK_JAVA/Example"},
+			},
+		},
+		{
+			name:      "records over the limit aren't returned",
+			retention: 10,
+			added: []*pb.RunRecord{
+				{PipelineUuid: "1", ExampleId: "SDK_JAVA/Example"},
+				{PipelineUuid: "2", ExampleId: "SDK_JAVA/Example"},
+			},
+			exampleId: "SDK_JAVA/Example",
+			limit:     1,
+			want:      []*pb.RunRecord{{PipelineUuid: "2", ExampleId: "SDK_JAVA/Example"}},
+		},
+		{
+			name:      "example without records",
+			retention: 10,
+			exampleId: "SDK_JAVA/Example",
+			limit:     10,
+			want:      []*pb.RunRecord{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := NewLocalStore(tt.retention)
+			for _, record := range tt.added {
+				if err := store.Add(ctx, record); err != nil {
+					t.Fatalf("Add() error = %v", err)
+				}
+			}
+			got, err := store.List(ctx, tt.exampleId, tt.limit)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("List() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
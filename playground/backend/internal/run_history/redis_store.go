@@ -0,0 +1,85 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run_history
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v8"
+)
+
+// keyPrefix is the prefix of keys of lists which keep records of examples
+const keyPrefix = "run_history:"
+
+// RedisStore is a Redis implementation of Store. Records of each example are kept in the list,
+// so the run history outlives restarts of the server.
+type RedisStore struct {
+	*redis.Client
+	retention int
+}
+
+// NewRedisStore returns the Redis Store which keeps retention records for each example.
+// In case of problem with connection to Redis returns error.
+func NewRedisStore(ctx context.Context, addr string, retention int) (*RedisStore, error) {
+	rs := RedisStore{redis.NewClient(&redis.Options{Addr: addr}), retention}
+	if _, err := rs.Ping(ctx).Result(); err != nil {
+		logger.Errorf("Redis Run History: connect to Redis: error during Ping operation, err: %s\n", err.Error())
+		return nil, err
+	}
+	return &rs, nil
+}
+
+func (rs *RedisStore) Add(ctx context.Context, record *pb.RunRecord) error {
+	recordMarsh, err := json.Marshal(record)
+	if err != nil {
+		logger.Errorf("Redis Run History: add record: error during marshal record, err: %s\n", err.Error())
+		return err
+	}
+	key := keyPrefix + record.ExampleId
+	if _, err = rs.LPush(ctx, key, recordMarsh).Result(); err != nil {
+		logger.Errorf("Redis Run History: add record: error during LPush operation for key: %s, err: %s\n", key, err.Error())
+		return err
+	}
+	if _, err = rs.LTrim(ctx, key, 0, int64(rs.retention-1)).Result(); err != nil {
+		logger.Errorf("Redis Run History: add record: error during LTrim operation for key: %s, err: %s\n", key, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (rs *RedisStore) List(ctx context.Context, exampleId string, limit int) ([]*pb.RunRecord, error) {
+	records := []*pb.RunRecord{}
+	if limit <= 0 {
+		return records, nil
+	}
+	key := keyPrefix + exampleId
+	values, err := rs.LRange(ctx, key, 0, int64(limit-1)).Result()
+	if err != nil {
+		logger.Errorf("Redis Run History: list records: error during LRange operation for key: %s, err: %s\n", key, err.Error())
+		return nil, err
+	}
+	for _, value := range values {
+		record := new(pb.RunRecord)
+		if err = json.Unmarshal([]byte(value), record); err != nil {
+			logger.Errorf("Redis Run History: list records: error during unmarshal record, err: %s\n", err.Error())
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
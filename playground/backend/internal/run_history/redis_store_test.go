@@ -0,0 +1,119 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run_history
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redismock/v8"
+	"testing"
+)
+
+func TestRedisStore_Add(t *testing.T) {
+	record := &pb.RunRecord{PipelineUuid: "1", ExampleId: "SDK_JAVA/Example", Status: pb.Status_STATUS_FINISHED}
+	key := keyPrefix + record.ExampleId
+	recordMarsh, _ := json.Marshal(record)
+	client, mock := redismock.NewClientMock()
+	tests := []struct {
+		name    string
+		mocks   func()
+		wantErr bool
+	}{
+		{
+			name: "error during LPush operation",
+			mocks: func() {
+				mock.ExpectLPush(key, recordMarsh).SetErr(fmt.Errorf("MOCK_ERROR"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "record is added and records over the retention are removed",
+			mocks: func() {
+				mock.ExpectLPush(key, recordMarsh).SetVal(1)
+				mock.ExpectLTrim(key, 0, 1).SetVal("OK")
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			rs := &RedisStore{client, 2}
+			if err := rs.Add(context.Background(), record); (err != nil) != tt.wantErr {
+				t.Errorf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestRedisStore_List(t *testing.T) {
+	record := &pb.RunRecord{PipelineUuid: "1", ExampleId: "SDK_JAVA/Example", Status: pb.Status_STATUS_FINISHED}
+	key := keyPrefix + record.ExampleId
+	recordMarsh, _ := json.Marshal(record)
+	client, mock := redismock.NewClientMock()
+	tests := []struct {
+		name    string
+		mocks   func()
+		limit   int
+		want    []*pb.RunRecord
+		wantErr bool
+	}{
+		{
+			name: "error during LRange operation",
+			mocks: func() {
+				mock.ExpectLRange(key, 0, 9).SetErr(fmt.Errorf("MOCK_ERROR"))
+			},
+			limit:   10,
+			wantErr: true,
+		},
+		{
+			name: "records are returned",
+			mocks: func() {
+				mock.ExpectLRange(key, 0, 9).SetVal([]string{string(recordMarsh)})
+			},
+			limit: 10,
+			want:  []*pb.RunRecord{record},
+		},
+		{
+			name:  "zero limit",
+			mocks: func() {},
+			limit: 0,
+			want:  []*pb.RunRecord{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mocks()
+			rs := &RedisStore{client, 2}
+			got, err := rs.List(context.Background(), record.ExampleId, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("List() got = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
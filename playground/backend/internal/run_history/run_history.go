@@ -0,0 +1,44 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run_history
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"context"
+)
+
+// maxErrorSummaryLength is the max number of characters of the error output which are kept in the record
+const maxErrorSummaryLength = 500
+
+// Store is used to keep the run history of examples of the catalog, so it's known when the example succeeded last time
+// and with which versions. Records of each example are kept the most recent first,
+// and the oldest records are removed when the number of records of the example exceeds the retention.
+type Store interface {
+	// Add adds the record to the run history of its example.
+	Add(ctx context.Context, record *pb.RunRecord) error
+
+	// List returns at most limit of the most recent records of the example, the most recent record first.
+	List(ctx context.Context, exampleId string, limit int) ([]*pb.RunRecord, error)
+}
+
+// ErrorSummary returns the beginning of the error output which is kept in the record
+func ErrorSummary(errorOutput string) string {
+	summary := []rune(errorOutput)
+	if len(summary) <= maxErrorSummaryLength {
+		return errorOutput
+	}
+	return string(summary[:maxErrorSummaryLength]) + "..."
+}
@@ -0,0 +1,47 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package run_history
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestErrorSummary(t *testing.T) {
+	tests := []struct {
+		name        string
+		errorOutput string
+		want        string
+	}{
+		{
+			name:        "short error output",
+			errorOutput: "error: cannot find symbol",
+			want:        "error: cannot find symbol",
+		},
+		{
+			name:        "long error output",
+			errorOutput: strings.Repeat("ошибка ", 100),
+			want:        string([]rune(strings.Repeat("ошибка ", 100))[:maxErrorSummaryLength]) + "...",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorSummary(tt.errorOutput); got != tt.want {
+				t.Errorf("ErrorSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
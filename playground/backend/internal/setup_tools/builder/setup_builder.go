@@ -20,8 +20,13 @@ import (
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/preparers"
+	"beam.apache.org/playground/backend/internal/test_report"
 	"beam.apache.org/playground/backend/internal/utils"
 	"fmt"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -30,8 +35,15 @@ import (
 const (
 	javaLogConfigFileName        = "logging.properties"
 	javaLogConfigFilePlaceholder = "{logConfigFile}"
+	yamlPipelineFileFlag         = "--yaml_pipeline_file="
+	outputFolderName             = "output"
+	scratchFolderName            = "scratch"
+	junitXmlFlag                 = "--junitxml="
 )
 
+// classpathFlags are flags of java and kotlin commands which are followed by the classpath
+var classpathFlags = map[string]bool{"-cp": true, "-classpath": true, "--class-path": true}
+
 // Validator return executor with set args for validator
 func Validator(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs) (*executors.ExecutorBuilder, error) {
 	sdk := sdkEnv.ApacheBeamSdk
@@ -46,22 +58,33 @@ func Validator(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs) (*ex
 	return &builder, err
 }
 
-// Preparer return executor with set args for preparer
-func Preparer(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs, valResults *sync.Map) (*executors.ExecutorBuilder, error) {
+// Preparer return executor with set args for preparer and the summary of changes which preparers make to the code
+func Preparer(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs, valResults *sync.Map, overrides preparers.Overrides, log *logger.Entry) (*executors.ExecutorBuilder, *preparers.RunSummary, error) {
 	sdk := sdkEnv.ApacheBeamSdk
-	prep, err := utils.GetPreparers(sdk, paths.AbsoluteSourceFilePath, valResults)
+	// outputs of the code are redirected to the folder of the pipeline which is deleted after the run
+	options := utils.PreparersOptions{
+		InjectRandomSeed:       sdkEnv.InjectRandomSeed(),
+		IoSubstitutions:        sdkEnv.IoSubstitutions(),
+		OutputDir:              filepath.Join(paths.AbsoluteBaseFolderPath, outputFolderName),
+		IncludeDir:             paths.AbsoluteSourceFileFolderPath,
+		RejectInteractiveInput: sdkEnv.RejectInteractiveInput(),
+		RejectJavaModules:      sdkEnv.RejectJavaModules(),
+		Overrides:              overrides,
+	}
+	prep, summary, err := utils.GetPreparers(sdk, paths.AbsoluteSourceFilePath, valResults, options, log)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	builder := executors.NewExecutorBuilder().
 		WithPreparer().
 		WithSdkPreparers(prep).
 		ExecutorBuilder
-	return &builder, err
+	return &builder, summary, err
 }
 
-// Compiler return executor with set args for compiler
-func Compiler(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs) *executors.ExecutorBuilder {
+// Compiler return executor with set args for compiler.
+// Returns error if there are no files to compile, e.g. when preparers removed them.
+func Compiler(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs) (*executors.ExecutorBuilder, error) {
 	sdk := sdkEnv.ApacheBeamSdk
 	executorConfig := sdkEnv.ExecutorConfig
 	builder := executors.NewExecutorBuilder().
@@ -73,19 +96,37 @@ func Compiler(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs) *exec
 		ExecutorBuilder
 
 	switch sdk {
-	case pb.Sdk_SDK_JAVA:
-		builder.
+	case pb.Sdk_SDK_JAVA: // code can be split into several files by preparers
+		files := GetFilesFromFolder(paths.AbsoluteSourceFileFolderPath, fs_tool.JavaSourceFileExtension)
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no %s files to compile in %s", fs_tool.JavaSourceFileExtension, paths.AbsoluteSourceFileFolderPath)
+		}
+		args := append(append([]string{}, executorConfig.CompileArgs...), files[1:]...)
+		builder = builder.
 			WithCompiler().
-			WithFileName(GetFirstFileFromFolder(paths.AbsoluteSourceFileFolderPath))
+			WithArgs(args).
+			WithFileName(files[0]).
+			ExecutorBuilder
+	case pb.Sdk_SDK_KOTLIN: // all Kotlin files of the pipeline are compiled together like Java ones
+		files := GetFilesFromFolder(paths.AbsoluteSourceFileFolderPath, fs_tool.KotlinSourceFileExtension)
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no %s files to compile in %s", fs_tool.KotlinSourceFileExtension, paths.AbsoluteSourceFileFolderPath)
+		}
+		args := append(append([]string{}, executorConfig.CompileArgs...), files[1:]...)
+		builder = builder.
+			WithCompiler().
+			WithArgs(args).
+			WithFileName(files[0]).
+			ExecutorBuilder
 	}
-	return &builder
+	return &builder, nil
 }
 
 // Runner return executor with set args for runner
 func Runner(paths *fs_tool.LifeCyclePaths, pipelineOptions string, sdkEnv *environment.BeamEnvs) (*executors.ExecutorBuilder, error) {
 	sdk := sdkEnv.ApacheBeamSdk
 
-	if sdk == pb.Sdk_SDK_JAVA {
+	if sdk == pb.Sdk_SDK_JAVA || sdk == pb.Sdk_SDK_KOTLIN {
 		pipelineOptions = utils.ReplaceSpacesWithEquals(pipelineOptions)
 	}
 	executorConfig := sdkEnv.ExecutorConfig
@@ -96,13 +137,28 @@ func Runner(paths *fs_tool.LifeCyclePaths, pipelineOptions string, sdkEnv *envir
 		WithArgs(executorConfig.RunArgs).
 		WithPipelineOptions(strings.Split(pipelineOptions, " ")).
 		ExecutorBuilder
+	sandbox, err := executorSandbox(paths, sdkEnv, true)
+	if err != nil {
+		return nil, err
+	}
 
 	switch sdk {
-	case pb.Sdk_SDK_JAVA: // Executable name for java class is known after compilation
-		args := replaceLogPlaceholder(paths, executorConfig)
-		className, err := paths.ExecutableName(paths.AbsoluteExecutableFileFolderPath)
+	case pb.Sdk_SDK_JAVA: // Executable name for java class is the class with main method of prepared sources
+		args := absoluteClasspath(replaceLogPlaceholder(paths, executorConfig), paths.AbsoluteBaseFolderPath, sandbox)
+		className, err := preparers.FindJavaMainClass(paths.AbsoluteSourceFileFolderPath)
+		if err != nil {
+			return nil, err
+		}
+		builder = builder.
+			WithRunner().
+			WithArgs(args).
+			WithExecutableFileName(className).
+			ExecutorBuilder
+	case pb.Sdk_SDK_KOTLIN: // Executable name for kotlin is the class generated for the file with main function
+		args := absoluteClasspath(replaceLogPlaceholder(paths, executorConfig), paths.AbsoluteBaseFolderPath, sandbox)
+		className, err := preparers.FindKotlinMainClass(paths.AbsoluteSourceFileFolderPath)
 		if err != nil {
-			return nil, fmt.Errorf("no executable file name found for JAVA pipeline at %s", paths.AbsoluteExecutableFileFolderPath)
+			return nil, err
 		}
 		builder = builder.
 			WithRunner().
@@ -120,6 +176,14 @@ func Runner(paths *fs_tool.LifeCyclePaths, pipelineOptions string, sdkEnv *envir
 			WithRunner().
 			WithExecutableFileName(paths.AbsoluteExecutableFilePath).
 			ExecutorBuilder
+	case pb.Sdk_SDK_YAML: // YAML pipeline is passed to the Beam YAML main module by the flag
+		builder = builder.
+			WithRunner().
+			WithExecutableFileName(yamlPipelineFileFlag + paths.AbsoluteExecutableFilePath).
+			ExecutorBuilder
+	}
+	if sandbox != nil {
+		builder = builder.WithRunner().WithSandbox(*sandbox).ExecutorBuilder
 	}
 	return &builder, nil
 }
@@ -135,21 +199,82 @@ func TestRunner(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs) (*e
 		WithArgs(executorConfig.TestArgs).
 		WithWorkingDir(paths.AbsoluteSourceFileFolderPath).
 		ExecutorBuilder
+	// go test resolves the package of the test by its working directory, so it's kept
+	sandbox, err := executorSandbox(paths, sdkEnv, sdk != pb.Sdk_SDK_GO)
+	if err != nil {
+		return nil, err
+	}
 
 	switch sdk {
-	case pb.Sdk_SDK_JAVA: // Executable name for java class is known after compilation
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_KOTLIN: // Executable name for java and kotlin classes is known after compilation
 		className, err := paths.ExecutableName(paths.AbsoluteExecutableFileFolderPath)
 		if err != nil {
-			return nil, fmt.Errorf("no executable file name found for JAVA pipeline at %s", paths.AbsoluteExecutableFileFolderPath)
+			return nil, fmt.Errorf("no executable file name found for %s pipeline at %s", sdk, paths.AbsoluteExecutableFileFolderPath)
 		}
 		builder = builder.WithTestRunner().
 			WithExecutableFileName(className).
+			WithArgs(absoluteClasspath(executorConfig.TestArgs, paths.AbsoluteBaseFolderPath, sandbox)).
 			WithWorkingDir(paths.AbsoluteBaseFolderPath).
 			ExecutorBuilder //change directory for unit test
+	case pb.Sdk_SDK_PYTHON: // pytest writes results of tests to the report which is parsed after the run
+		testArgs := append([]string{}, executorConfig.TestArgs...)
+		builder = builder.WithTestRunner().
+			WithArgs(append(testArgs, junitXmlFlag+test_report.ReportFilePath(paths.AbsoluteBaseFolderPath))).
+			ExecutorBuilder
+	}
+	if sandbox != nil {
+		builder = builder.WithTestRunner().WithSandbox(*sandbox).ExecutorBuilder
 	}
 	return &builder, nil
 }
 
+// executorSandbox returns the sandbox of the executed code or nil if the sandbox is off.
+// If inScratchFolder is true the code is run in the scratch folder of the pipeline which is created by the call.
+func executorSandbox(paths *fs_tool.LifeCyclePaths, sdkEnv *environment.BeamEnvs, inScratchFolder bool) (*executors.Sandbox, error) {
+	config := sdkEnv.Sandbox()
+	if config.Mode == "" || config.Mode == environment.SandboxModeOff {
+		return nil, nil
+	}
+	sandbox := &executors.Sandbox{
+		HomeDir: paths.AbsoluteBaseFolderPath,
+		Envs:    config.Envs,
+		Strict:  config.Mode == environment.SandboxModeStrict,
+	}
+	if sandbox.Strict {
+		sandbox.WritablePaths = config.WritablePaths
+	}
+	if inScratchFolder {
+		sandbox.WorkingDir = filepath.Join(paths.AbsoluteBaseFolderPath, scratchFolderName)
+		if err := os.MkdirAll(sandbox.WorkingDir, fs.ModePerm); err != nil {
+			return nil, err
+		}
+	}
+	return sandbox, nil
+}
+
+// absoluteClasspath returns args with relative entries of the classpath resolved against dir if the code is sandboxed,
+// since the sandboxed code is run in the scratch folder instead of dir
+func absoluteClasspath(args []string, dir string, sandbox *executors.Sandbox) []string {
+	if sandbox == nil || sandbox.WorkingDir == "" {
+		return args
+	}
+	resolved := make([]string, len(args))
+	for i, arg := range args {
+		resolved[i] = arg
+		if i == 0 || !classpathFlags[args[i-1]] {
+			continue
+		}
+		entries := strings.Split(arg, ":")
+		for j, entry := range entries {
+			if entry != "" && !filepath.IsAbs(entry) {
+				entries[j] = filepath.Join(dir, entry)
+			}
+		}
+		resolved[i] = strings.Join(entries, ":")
+	}
+	return resolved
+}
+
 // replaceLogPlaceholder replaces placeholder for log for JAVA SDK
 func replaceLogPlaceholder(paths *fs_tool.LifeCyclePaths, executorConfig *environment.ExecutorConfig) []string {
 	args := make([]string, 0)
@@ -165,6 +290,11 @@ func replaceLogPlaceholder(paths *fs_tool.LifeCyclePaths, executorConfig *enviro
 
 // GetFirstFileFromFolder return a name of the first file in a specified folder
 func GetFirstFileFromFolder(folderAbsolutePath string) string {
-	files, _ := filepath.Glob(fmt.Sprintf("%s/*%s", folderAbsolutePath, fs_tool.JavaSourceFileExtension))
-	return files[0]
+	return GetFilesFromFolder(folderAbsolutePath, fs_tool.JavaSourceFileExtension)[0]
+}
+
+// GetFilesFromFolder return names of all files with the extension in a specified folder
+func GetFilesFromFolder(folderAbsolutePath, extension string) []string {
+	files, _ := filepath.Glob(filepath.Join(folderAbsolutePath, "*"+extension))
+	return files
 }
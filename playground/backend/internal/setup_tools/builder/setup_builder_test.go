@@ -20,10 +20,14 @@ import (
 	"beam.apache.org/playground/backend/internal/environment"
 	"beam.apache.org/playground/backend/internal/executors"
 	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/preparers"
+	"beam.apache.org/playground/backend/internal/test_report"
 	"beam.apache.org/playground/backend/internal/utils"
 	"beam.apache.org/playground/backend/internal/validators"
 	"fmt"
 	"github.com/google/uuid"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync"
@@ -47,7 +51,7 @@ func setup() {
 		CompileCmd:  "MOCK_COMPILE_CMD",
 		CompileArgs: []string{"MOCK_COMPILE_ARG"},
 	}
-	sdkEnv = environment.NewBeamEnvs(sdk, executorConfig, "", 0)
+	sdkEnv = environment.NewBeamEnvs(sdk, executorConfig, "", 0, environment.BeamEnvsOptions{})
 }
 
 func TestValidator(t *testing.T) {
@@ -59,7 +63,7 @@ func TestValidator(t *testing.T) {
 		WithValidator().
 		WithSdkValidators(vals)
 
-	wrongSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, sdkEnv.ExecutorConfig, "", 0)
+	wrongSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, sdkEnv.ExecutorConfig, "", 0, environment.BeamEnvsOptions{})
 
 	type args struct {
 		paths  *fs_tool.LifeCyclePaths
@@ -116,8 +120,9 @@ func TestPreparer(t *testing.T) {
 	validationResults := sync.Map{}
 	validationResults.Store(validators.UnitTestValidatorName, false)
 	validationResults.Store(validators.KatasValidatorName, false)
+	validationResults.Store(validators.JavaModuleValidatorName, false)
 
-	prep, err := utils.GetPreparers(sdkEnv.ApacheBeamSdk, paths.AbsoluteSourceFilePath, &validationResults)
+	prep, _, err := utils.GetPreparers(sdkEnv.ApacheBeamSdk, paths.AbsoluteSourceFilePath, &validationResults, utils.PreparersOptions{OutputDir: filepath.Join(paths.AbsoluteBaseFolderPath, outputFolderName), IncludeDir: paths.AbsoluteSourceFileFolderPath}, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -126,7 +131,7 @@ func TestPreparer(t *testing.T) {
 		WithPreparer().
 		WithSdkPreparers(prep)
 
-	wrongSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, sdkEnv.ExecutorConfig, "", 0)
+	wrongSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_UNSPECIFIED, sdkEnv.ExecutorConfig, "", 0, environment.BeamEnvsOptions{})
 
 	type args struct {
 		paths           fs_tool.LifeCyclePaths
@@ -159,7 +164,7 @@ func TestPreparer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := Preparer(&tt.args.paths, tt.args.sdkEnv, tt.args.valResults)
+			got, _, err := Preparer(&tt.args.paths, tt.args.sdkEnv, tt.args.valResults, preparers.Overrides{}, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Preparer() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -205,7 +210,10 @@ func TestCompiler(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := Compiler(tt.args.paths, tt.args.sdkEnv)
+			got, err := Compiler(tt.args.paths, tt.args.sdkEnv)
+			if err != nil {
+				t.Fatalf("Compiler() unexpected error = %v", err)
+			}
 			if !reflect.DeepEqual(fmt.Sprint(got.Build()), fmt.Sprint(tt.want.Build())) {
 				t.Errorf("Compiler() = %v, want %v", got.Build(), tt.want.Build())
 			}
@@ -213,6 +221,58 @@ func TestCompiler(t *testing.T) {
 	}
 }
 
+func TestCompilerKotlin(t *testing.T) {
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_KOTLIN, uuid.New(), t.TempDir())
+	if err := lc.CreateFolders(); err != nil {
+		t.Fatalf("CreateFolders() unexpected error = %v", err)
+	}
+	helperFile := filepath.Join(lc.Paths.AbsoluteSourceFileFolderPath, "Helper.kt")
+	mainFile := filepath.Join(lc.Paths.AbsoluteSourceFileFolderPath, "Main.kt")
+	for _, file := range []string{helperFile, mainFile} {
+		if err := os.WriteFile(file, []byte("package main\n"), 0600); err != nil {
+			t.Fatalf("WriteFile() unexpected error = %v", err)
+		}
+	}
+	executorConfig := &environment.ExecutorConfig{
+		CompileCmd:  "kotlinc",
+		CompileArgs: []string{"-d", "bin", "-classpath"},
+	}
+	kotlinSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_KOTLIN, executorConfig, "", 0, environment.BeamEnvsOptions{})
+	// Test that all Kotlin files of the pipeline are passed to the compiler
+	want := executors.NewExecutorBuilder().
+		WithCompiler().
+		WithCommand(executorConfig.CompileCmd).
+		WithWorkingDir(lc.Paths.AbsoluteBaseFolderPath).
+		WithArgs(append(append([]string{}, executorConfig.CompileArgs...), mainFile)).
+		WithFileName(helperFile).
+		Build()
+
+	got, err := Compiler(&lc.Paths, kotlinSdkEnv)
+	if err != nil {
+		t.Fatalf("Compiler() unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(fmt.Sprint(got.Build()), fmt.Sprint(want)) {
+		t.Errorf("Compiler() got = %v, want %v", got.Build(), want)
+	}
+}
+
+func TestCompilerWithoutSourceFiles(t *testing.T) {
+	executorConfig := &environment.ExecutorConfig{CompileCmd: "compile", CompileArgs: []string{"-d", "bin"}}
+	for _, sdk := range []pb.Sdk{pb.Sdk_SDK_JAVA, pb.Sdk_SDK_KOTLIN} {
+		t.Run(sdk.String(), func(t *testing.T) {
+			lc, _ := fs_tool.NewLifeCycle(sdk, uuid.New(), t.TempDir())
+			if err := lc.CreateFolders(); err != nil {
+				t.Fatalf("CreateFolders() unexpected error = %v", err)
+			}
+			sdkEnv := environment.NewBeamEnvs(sdk, executorConfig, "", 0, environment.BeamEnvsOptions{})
+			// Test that the compiler isn't built when there are no files to compile
+			if _, err := Compiler(&lc.Paths, sdkEnv); err == nil {
+				t.Errorf("Compiler() error = nil, want the error about missing files")
+			}
+		})
+	}
+}
+
 func TestRunnerBuilder(t *testing.T) {
 	wantExecutor := executors.NewExecutorBuilder().
 		WithRunner().
@@ -253,12 +313,146 @@ func TestRunnerBuilder(t *testing.T) {
 	}
 }
 
+func TestRunnerBuilderYaml(t *testing.T) {
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_YAML, uuid.New(), "")
+	executorConfig := &environment.ExecutorConfig{
+		RunCmd:  "python3",
+		RunArgs: []string{"-m", "apache_beam.yaml.main"},
+	}
+	yamlSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_YAML, executorConfig, "", 0, environment.BeamEnvsOptions{})
+	// Test that the pipeline file is passed to the Beam YAML main module by the flag
+	want := executors.NewExecutorBuilder().
+		WithRunner().
+		WithExecutableFileName(yamlPipelineFileFlag + lc.Paths.AbsoluteExecutableFilePath).
+		WithWorkingDir(lc.Paths.AbsoluteBaseFolderPath).
+		WithCommand(executorConfig.RunCmd).
+		WithArgs(executorConfig.RunArgs).
+		WithPipelineOptions(strings.Split("", " ")).
+		Build()
+
+	got, err := Runner(&lc.Paths, "", yamlSdkEnv)
+	if err != nil {
+		t.Fatalf("Runner() unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(fmt.Sprint(got.Build()), fmt.Sprint(want)) {
+		t.Errorf("Runner() got = %v, want %v", got.Build(), want)
+	}
+}
+
+func TestRunnerBuilderSandbox(t *testing.T) {
+	lc, _ := fs_tool.NewLifeCycle(pb.Sdk_SDK_PYTHON, uuid.New(), t.TempDir())
+	executorConfig := &environment.ExecutorConfig{RunCmd: "python3", TestCmd: "pytest"}
+	sandbox := environment.SandboxConfig{Envs: []string{"HTTP_PROXY"}, WritablePaths: []string{"/opt/playground/cache"}}
+	scratchFolder := filepath.Join(lc.Paths.AbsoluteBaseFolderPath, scratchFolderName)
+	tests := []struct {
+		name        string
+		mode        string
+		wantSandbox *executors.Sandbox
+	}{
+		{
+			// Test case with the sandbox which is off.
+			// As a result, want to receive the runner without the sandbox.
+			name:        "sandbox is off",
+			mode:        environment.SandboxModeOff,
+			wantSandbox: nil,
+		},
+		{
+			// Test case with the scrubbed environment of the code.
+			// As a result, want to receive the runner in the scratch folder, writable paths aren't restricted.
+			name:        "scrubbed sandbox",
+			mode:        environment.SandboxModeScrubbed,
+			wantSandbox: &executors.Sandbox{HomeDir: lc.Paths.AbsoluteBaseFolderPath, WorkingDir: scratchFolder, Envs: sandbox.Envs},
+		},
+		{
+			// Test case with the strict sandbox.
+			// As a result, want to receive the runner which can write only to the folder of the pipeline and writable paths.
+			name:        "strict sandbox",
+			mode:        environment.SandboxModeStrict,
+			wantSandbox: &executors.Sandbox{HomeDir: lc.Paths.AbsoluteBaseFolderPath, WorkingDir: scratchFolder, Envs: sandbox.Envs, WritablePaths: sandbox.WritablePaths, Strict: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sandbox.Mode = tt.mode
+			sandboxSdkEnv := environment.NewBeamEnvs(pb.Sdk_SDK_PYTHON, executorConfig, "", 0, environment.BeamEnvsOptions{Sandbox: sandbox})
+			want := executors.NewExecutorBuilder().
+				WithRunner().
+				WithExecutableFileName(lc.Paths.AbsoluteExecutableFilePath).
+				WithWorkingDir(lc.Paths.AbsoluteBaseFolderPath).
+				WithCommand(executorConfig.RunCmd).
+				WithArgs(executorConfig.RunArgs).
+				WithPipelineOptions(strings.Split("", " "))
+			wantTest := executors.NewExecutorBuilder().
+				WithTestRunner().
+				WithExecutableFileName(lc.Paths.AbsoluteExecutableFilePath).
+				WithCommand(executorConfig.TestCmd).
+				WithArgs(append(executorConfig.TestArgs, junitXmlFlag+test_report.ReportFilePath(lc.Paths.AbsoluteBaseFolderPath))).
+				WithWorkingDir(lc.Paths.AbsoluteSourceFileFolderPath)
+			if tt.wantSandbox != nil {
+				want = want.WithSandbox(*tt.wantSandbox)
+				wantTest = wantTest.WithSandbox(*tt.wantSandbox)
+			}
+
+			got, err := Runner(&lc.Paths, "", sandboxSdkEnv)
+			if err != nil {
+				t.Fatalf("Runner() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(fmt.Sprint(got.Build()), fmt.Sprint(want.Build())) {
+				t.Errorf("Runner() got = %v, want %v", got.Build(), want.Build())
+			}
+			gotTest, err := TestRunner(&lc.Paths, sandboxSdkEnv)
+			if err != nil {
+				t.Fatalf("TestRunner() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(fmt.Sprint(gotTest.Build()), fmt.Sprint(wantTest.Build())) {
+				t.Errorf("TestRunner() got = %v, want %v", gotTest.Build(), wantTest.Build())
+			}
+			if _, err = os.Stat(scratchFolder); tt.wantSandbox != nil && err != nil {
+				t.Errorf("Runner() scratch folder isn't created: %v", err)
+			}
+		})
+	}
+}
+
+func Test_absoluteClasspath(t *testing.T) {
+	args := []string{"-cp", "bin:/opt/apache/beam/jars/beam.jar", "-Djava.util.logging.config.file=/logging.properties"}
+	tests := []struct {
+		name    string
+		sandbox *executors.Sandbox
+		want    []string
+	}{
+		{
+			name:    "code isn't sandboxed",
+			sandbox: nil,
+			want:    args,
+		},
+		{
+			// Test that the classpath is kept if the code is run in its folder
+			name:    "code is sandboxed in its folder",
+			sandbox: &executors.Sandbox{HomeDir: "/pipeline"},
+			want:    args,
+		},
+		{
+			name:    "code is sandboxed in the scratch folder",
+			sandbox: &executors.Sandbox{HomeDir: "/pipeline", WorkingDir: "/pipeline/scratch"},
+			want:    []string{"-cp", "/pipeline/bin:/opt/apache/beam/jars/beam.jar", "-Djava.util.logging.config.file=/logging.properties"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := absoluteClasspath(args, "/pipeline", tt.sandbox); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("absoluteClasspath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTestRunner(t *testing.T) {
 	wantExecutor := executors.NewExecutorBuilder().
 		WithTestRunner().
 		WithExecutableFileName(paths.AbsoluteExecutableFilePath).
 		WithCommand(sdkEnv.ExecutorConfig.TestCmd).
-		WithArgs(sdkEnv.ExecutorConfig.TestArgs).
+		WithArgs(append(sdkEnv.ExecutorConfig.TestArgs, junitXmlFlag+test_report.ReportFilePath(paths.AbsoluteBaseFolderPath))).
 		WithWorkingDir(paths.AbsoluteSourceFileFolderPath)
 
 	type args struct {
@@ -21,6 +21,7 @@ import (
 	"beam.apache.org/playground/backend/internal/logger"
 	"bufio"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	"io"
 	"os"
@@ -34,75 +35,109 @@ const (
 	javaLogFilePlaceholder = "{logFilePath}"
 	goModFileName          = "go.mod"
 	goSumFileName          = "go.sum"
+	sdkField               = "sdk"
+	stageField             = "stage"
+	setupStage             = "Setup"
 )
 
 // Setup returns fs_tool.LifeCycle.
-// Also, prepares files and folders needed to code processing according to sdk
-func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir, pipelinesFolder, preparedModDir string) (*fs_tool.LifeCycle, error) {
+// Also, prepares files and folders needed to code processing according to sdk.
+// The workspace is taken from the pool if there is a ready one, otherwise it is created.
+func Setup(sdk pb.Sdk, code string, pipelineId uuid.UUID, workingDir, pipelinesFolder, preparedModDir string, pool *WorkspacePool) (*fs_tool.LifeCycle, error) {
+	log := logger.WithPipelineId(pipelineId.String()).WithFields(logger.Fields{sdkField: sdk.String(), stageField: setupStage})
+
+	lc := pool.Acquire(pipelineId, log)
+	if lc == nil {
+		var err error
+		if lc, err = createWorkspace(sdk, pipelineId, workingDir, pipelinesFolder, preparedModDir, log); err != nil {
+			return nil, err
+		}
+		if err = bindWorkspace(sdk, lc, log); err != nil {
+			lc.DeleteFolders()
+			return nil, err
+		}
+	}
+
+	// create file with code
+	err := lc.CreateSourceCodeFile(code)
+	if err != nil {
+		log.Errorf("RunCode(): CreateSourceCodeFile(): %s\n", err.Error())
+		lc.DeleteFolders()
+		return nil, errors.New("error during create file with code")
+	}
+	return lc, nil
+}
+
+// createWorkspace creates folders and files of the sdk which don't depend on the pipeline
+func createWorkspace(sdk pb.Sdk, pipelineId uuid.UUID, workingDir, pipelinesFolder, preparedModDir string, log *logger.Entry) (*fs_tool.LifeCycle, error) {
 	// create file system service
 	lc, err := fs_tool.NewLifeCycle(sdk, pipelineId, filepath.Join(workingDir, pipelinesFolder))
 	if err != nil {
-		logger.Errorf("%s: error during create new life cycle: %s\n", pipelineId, err.Error())
+		log.Errorf("error during create new life cycle: %s\n", err.Error())
 		return nil, errors.New("error during create a new file system")
 	}
 
 	// create folders
 	err = lc.CreateFolders()
 	if err != nil {
-		logger.Errorf("%s: error during create folders: %s\n", pipelineId, err.Error())
+		log.Errorf("error during create folders: %s\n", err.Error())
 		return nil, errors.New("error during prepare necessary folders")
 	}
 
 	// copy necessary files
 	switch sdk {
 	case pb.Sdk_SDK_GO:
-		if err = prepareGoFiles(lc, preparedModDir, pipelineId); err != nil {
+		if err = prepareGoFiles(lc, preparedModDir, log); err != nil {
 			lc.DeleteFolders()
 			return nil, errors.New("error during create necessary files for the Go sdk")
 		}
 	case pb.Sdk_SDK_JAVA:
-		if err = prepareJavaFiles(lc, workingDir, pipelineId); err != nil {
+		if err = prepareJavaFiles(lc, workingDir, log); err != nil {
 			lc.DeleteFolders()
 			return nil, errors.New("error during create necessary files for the Java sdk")
 		}
+	case pb.Sdk_SDK_KOTLIN: // Kotlin pipelines run on the JVM with the same log config as Java ones
+		if err = prepareJavaFiles(lc, workingDir, log); err != nil {
+			lc.DeleteFolders()
+			return nil, errors.New("error during create necessary files for the Kotlin sdk")
+		}
 	}
+	return lc, nil
+}
 
-	// create file with code
-	err = lc.CreateSourceCodeFile(code)
-	if err != nil {
-		logger.Errorf("%s: RunCode(): CreateSourceCodeFile(): %s\n", pipelineId, err.Error())
-		lc.DeleteFolders()
-		return nil, errors.New("error during create file with code")
+// bindWorkspace updates files of the workspace which refer to paths of the pipeline
+func bindWorkspace(sdk pb.Sdk, lc *fs_tool.LifeCycle, log *logger.Entry) error {
+	switch sdk {
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_KOTLIN:
+		if err := updateJavaLogConfigFile(lc.Paths); err != nil {
+			log.Errorf("error during updating logging.properties file: %s\n", err.Error())
+			return fmt.Errorf("error during create necessary files for the %s sdk", sdk)
+		}
 	}
-	return lc, nil
+	return nil
 }
 
 // prepareGoFiles prepares file for Go environment.
 // Copy go.mod and go.sum file from /path/to/preparedModDir to /path/to/workingDir/pipelinesFolder/{pipelineId}
-func prepareGoFiles(lc *fs_tool.LifeCycle, preparedModDir string, pipelineId uuid.UUID) error {
+func prepareGoFiles(lc *fs_tool.LifeCycle, preparedModDir string, log *logger.Entry) error {
 	if err := lc.CopyFile(goModFileName, preparedModDir, lc.Paths.AbsoluteBaseFolderPath); err != nil {
-		logger.Errorf("%s: error during copying %s file: %s\n", pipelineId, goModFileName, err.Error())
+		log.Errorf("error during copying %s file: %s\n", goModFileName, err.Error())
 		return err
 	}
 	if err := lc.CopyFile(goSumFileName, preparedModDir, lc.Paths.AbsoluteBaseFolderPath); err != nil {
-		logger.Errorf("%s: error during copying %s file: %s\n", pipelineId, goSumFileName, err.Error())
+		log.Errorf("error during copying %s file: %s\n", goSumFileName, err.Error())
 		return err
 	}
 	return nil
 }
 
 // prepareJavaFiles prepares file for Java environment.
-// Copy log config file from /path/to/workingDir to /path/to/workingDir/pipelinesFolder/{pipelineId}
-//	and update this file according to pipeline.
-func prepareJavaFiles(lc *fs_tool.LifeCycle, workingDir string, pipelineId uuid.UUID) error {
+// Copy log config file from /path/to/workingDir to /path/to/workingDir/pipelinesFolder/{pipelineId}.
+// The file is updated according to pipeline by bindWorkspace.
+func prepareJavaFiles(lc *fs_tool.LifeCycle, workingDir string, log *logger.Entry) error {
 	err := lc.CopyFile(javaLogConfigFileName, workingDir, lc.Paths.AbsoluteBaseFolderPath)
 	if err != nil {
-		logger.Errorf("%s: error during copying logging.properties file: %s\n", pipelineId, err.Error())
-		return err
-	}
-	err = updateJavaLogConfigFile(lc.Paths)
-	if err != nil {
-		logger.Errorf("%s: error during updating logging.properties file: %s\n", pipelineId, err.Error())
+		log.Errorf("error during copying logging.properties file: %s\n", err.Error())
 		return err
 	}
 	return nil
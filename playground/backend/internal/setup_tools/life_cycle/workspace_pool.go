@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package life_cycle
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"context"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+)
+
+const workspacePoolStage = "WorkspacePool"
+
+// WorkspacePool keeps workspaces of pipelines which are created in advance with folders and files of the sdk
+// which don't depend on the pipeline. A workspace is renamed after the pipeline when it is acquired
+// and a new workspace is created instead of it in the background.
+// A nil *WorkspacePool is valid and has no workspaces.
+type WorkspacePool struct {
+	sdk             pb.Sdk
+	workingDir      string
+	pipelinesFolder string
+	preparedModDir  string
+	workspaces      chan *fs_tool.LifeCycle
+	refills         chan struct{}
+}
+
+// NewWorkspacePool creates WorkspacePool with size workspaces which are created in the background until ctx is done.
+// Workspaces which are left in the pool are deleted when ctx is done.
+func NewWorkspacePool(ctx context.Context, sdk pb.Sdk, size int, workingDir, pipelinesFolder, preparedModDir string) *WorkspacePool {
+	pool := &WorkspacePool{
+		sdk:             sdk,
+		workingDir:      workingDir,
+		pipelinesFolder: pipelinesFolder,
+		preparedModDir:  preparedModDir,
+		workspaces:      make(chan *fs_tool.LifeCycle, size),
+		refills:         make(chan struct{}, size),
+	}
+	for i := 0; i < size; i++ {
+		pool.refills <- struct{}{}
+	}
+	go pool.fill(ctx)
+	return pool
+}
+
+// Acquire returns a workspace from the pool which is renamed after the pipeline and requests a new workspace.
+// Returns nil if the pool is empty or the workspace couldn't be bound to the pipeline,
+// in the last case the workspace is deleted.
+func (pool *WorkspacePool) Acquire(pipelineId uuid.UUID, log *logger.Entry) *fs_tool.LifeCycle {
+	if pool == nil {
+		return nil
+	}
+	defer pool.requestRefill()
+	var pooled *fs_tool.LifeCycle
+	select {
+	case pooled = <-pool.workspaces:
+	default:
+		return nil
+	}
+
+	lc, err := fs_tool.NewLifeCycle(pool.sdk, pipelineId, filepath.Join(pool.workingDir, pool.pipelinesFolder))
+	if err != nil {
+		log.Errorf("error during create new life cycle: %s\n", err.Error())
+		pooled.DeleteFolders()
+		return nil
+	}
+	if err = os.Rename(pooled.Paths.AbsoluteBaseFolderPath, lc.Paths.AbsoluteBaseFolderPath); err != nil {
+		log.Errorf("error during rename the workspace from the pool: %s\n", err.Error())
+		pooled.DeleteFolders()
+		return nil
+	}
+	if err = bindWorkspace(pool.sdk, lc, log); err != nil {
+		lc.DeleteFolders()
+		return nil
+	}
+	return lc
+}
+
+// requestRefill requests a new workspace if the pool isn't going to be full
+func (pool *WorkspacePool) requestRefill() {
+	select {
+	case pool.refills <- struct{}{}:
+	default:
+	}
+}
+
+// fill creates workspaces on requests until ctx is done, then deletes workspaces which are left in the pool
+func (pool *WorkspacePool) fill(ctx context.Context) {
+	log := logger.WithFields(logger.Fields{sdkField: pool.sdk.String(), stageField: workspacePoolStage})
+	defer pool.drain()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-pool.refills:
+		}
+		lc, err := createWorkspace(pool.sdk, uuid.New(), pool.workingDir, pool.pipelinesFolder, pool.preparedModDir, log)
+		if err != nil {
+			continue
+		}
+		select {
+		case pool.workspaces <- lc:
+		case <-ctx.Done():
+			lc.DeleteFolders()
+			return
+		}
+	}
+}
+
+// drain deletes all workspaces which are left in the pool
+func (pool *WorkspacePool) drain() {
+	for {
+		select {
+		case lc := <-pool.workspaces:
+			lc.DeleteFolders()
+		default:
+			return
+		}
+	}
+}
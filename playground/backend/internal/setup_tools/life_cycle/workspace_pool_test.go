@@ -0,0 +1,196 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package life_cycle
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"context"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// setupPoolWorkingDir creates the working directory with the Java log config file
+func setupPoolWorkingDir(tb testing.TB) string {
+	dir := tb.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, javaLogConfigFileName), []byte("pattern="+javaLogFilePlaceholder+"\n"), 0600); err != nil {
+		tb.Fatalf("error during test setup: %s", err.Error())
+	}
+	return dir
+}
+
+// waitForWorkspaces waits until the pool has count ready workspaces
+func waitForWorkspaces(tb testing.TB, pool *WorkspacePool, count int) {
+	for deadline := time.Now().Add(5 * time.Second); len(pool.workspaces) < count; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			tb.Fatalf("pool has %d workspaces, want %d", len(pool.workspaces), count)
+		}
+	}
+}
+
+func TestWorkspacePool_Acquire(t *testing.T) {
+	dir := setupPoolWorkingDir(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool := NewWorkspacePool(ctx, pb.Sdk_SDK_JAVA, 1, dir, pipelinesFolder, "")
+	waitForWorkspaces(t, pool, 1)
+
+	// Test that the workspace from the pool is renamed after the pipeline and bound to it
+	pipelineId := uuid.New()
+	lc, err := Setup(pb.Sdk_SDK_JAVA, "class Main {}", pipelineId, dir, pipelinesFolder, "", pool)
+	if err != nil {
+		t.Fatalf("Setup() unexpected error = %v", err)
+	}
+	if want := filepath.Join(dir, pipelinesFolder, pipelineId.String()); lc.Paths.AbsoluteBaseFolderPath != want {
+		t.Errorf("Setup() base folder = %v, want %v", lc.Paths.AbsoluteBaseFolderPath, want)
+	}
+	if code, _ := os.ReadFile(lc.Paths.AbsoluteSourceFilePath); string(code) != "class Main {}" {
+		t.Errorf("Setup() code = %s, want %s", code, "class Main {}")
+	}
+	logConfig, _ := os.ReadFile(filepath.Join(lc.Paths.AbsoluteBaseFolderPath, javaLogConfigFileName))
+	if !strings.Contains(string(logConfig), lc.Paths.AbsoluteLogFilePath) {
+		t.Errorf("Setup() log config = %s, want the log file of the pipeline %s", logConfig, lc.Paths.AbsoluteLogFilePath)
+	}
+	// Test that the pool is refilled
+	waitForWorkspaces(t, pool, 1)
+}
+
+func TestWorkspacePool_AcquireEmpty(t *testing.T) {
+	// Test that nil pool has no workspaces
+	var pool *WorkspacePool
+	if lc := pool.Acquire(uuid.New(), nil); lc != nil {
+		t.Errorf("Acquire() = %v, want nil", lc)
+	}
+}
+
+func TestWorkspacePool_AcquireConcurrently(t *testing.T) {
+	dir := setupPoolWorkingDir(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	size := 4
+	pool := NewWorkspacePool(ctx, pb.Sdk_SDK_PYTHON, size, dir, pipelinesFolder, "")
+	waitForWorkspaces(t, pool, size)
+
+	// Test that each workspace is handed out to a single pipeline
+	var mu sync.Mutex
+	var acquired []*fs_tool.LifeCycle
+	var wg sync.WaitGroup
+	for i := 0; i < 2*size; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if lc := pool.Acquire(uuid.New(), nil); lc != nil {
+				mu.Lock()
+				acquired = append(acquired, lc)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(acquired) < size {
+		t.Errorf("Acquire() returns %d workspaces, want at least %d", len(acquired), size)
+	}
+	folders := map[string]bool{}
+	for _, lc := range acquired {
+		if folders[lc.Paths.AbsoluteBaseFolderPath] {
+			t.Errorf("Acquire() returns the workspace %s twice", lc.Paths.AbsoluteBaseFolderPath)
+		}
+		folders[lc.Paths.AbsoluteBaseFolderPath] = true
+		if _, err := os.Stat(lc.Paths.AbsoluteSourceFileFolderPath); err != nil {
+			t.Errorf("Acquire() workspace %s has no source folder", lc.Paths.AbsoluteBaseFolderPath)
+		}
+	}
+}
+
+func TestWorkspacePool_AcquireFailure(t *testing.T) {
+	dir := setupPoolWorkingDir(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pool := NewWorkspacePool(ctx, pb.Sdk_SDK_PYTHON, 1, dir, pipelinesFolder, "")
+	waitForWorkspaces(t, pool, 1)
+	pooled := <-pool.workspaces
+	pool.workspaces <- pooled
+
+	// Test that the workspace is deleted if it can't be renamed after the pipeline
+	pipelineId := uuid.New()
+	occupied := filepath.Join(dir, pipelinesFolder, pipelineId.String())
+	if err := os.MkdirAll(filepath.Join(occupied, "file"), 0700); err != nil {
+		t.Fatalf("error during test setup: %s", err.Error())
+	}
+	if lc := pool.Acquire(pipelineId, nil); lc != nil {
+		t.Errorf("Acquire() = %v, want nil", lc)
+	}
+	if _, err := os.Stat(pooled.Paths.AbsoluteBaseFolderPath); !os.IsNotExist(err) {
+		t.Errorf("Acquire() keeps the workspace %s", pooled.Paths.AbsoluteBaseFolderPath)
+	}
+}
+
+func TestWorkspacePool_drain(t *testing.T) {
+	dir := setupPoolWorkingDir(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := NewWorkspacePool(ctx, pb.Sdk_SDK_PYTHON, 2, dir, pipelinesFolder, "")
+	waitForWorkspaces(t, pool, 2)
+
+	// Test that workspaces are deleted when the pool is stopped
+	cancel()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		entries, _ := os.ReadDir(filepath.Join(dir, pipelinesFolder))
+		if len(entries) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("pool keeps %d workspaces after it is stopped", len(entries))
+		}
+	}
+}
+
+// BenchmarkSetup measures the time of Setup which precedes the preparation of the code with and without the pool
+func BenchmarkSetup(b *testing.B) {
+	dir := setupPoolWorkingDir(b)
+	b.Run("without pool", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			lc, err := Setup(pb.Sdk_SDK_JAVA, "class Main {}", uuid.New(), dir, pipelinesFolder, "", nil)
+			if err != nil {
+				b.Fatalf("Setup() unexpected error = %v", err)
+			}
+			b.StopTimer()
+			lc.DeleteFolders()
+			b.StartTimer()
+		}
+	})
+	b.Run("with pool", func(b *testing.B) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		pool := NewWorkspacePool(ctx, pb.Sdk_SDK_JAVA, 1, dir, pipelinesFolder, "")
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			waitForWorkspaces(b, pool, 1)
+			b.StartTimer()
+			lc, err := Setup(pb.Sdk_SDK_JAVA, "class Main {}", uuid.New(), dir, pipelinesFolder, "", pool)
+			if err != nil {
+				b.Fatalf("Setup() unexpected error = %v", err)
+			}
+			b.StopTimer()
+			lc.DeleteFolders()
+			b.StartTimer()
+		}
+	})
+}
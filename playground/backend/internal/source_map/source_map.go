@@ -0,0 +1,55 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source_map
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SourceMap maps line numbers of prepared files to line numbers of the original code
+type SourceMap struct {
+	// Files maps names of prepared files to maps from their line numbers to line numbers of the original code.
+	// Names differ from the original one if the code is renamed or split into several files by preparers.
+	Files map[string]map[int]int `json:"files"`
+}
+
+// Translate returns output of the compiler or the run with line numbers of prepared files replaced with original ones.
+// References of files are recognized in formats of javac and stack traces ("Main.java:12"), go and kotlinc
+// ("main.go:12:5", "Main.kt: (12, 5)") and python tracebacks ("main.py", line 12). Lines of other files are kept.
+func (sourceMap *SourceMap) Translate(output string) string {
+	if sourceMap == nil || len(sourceMap.Files) == 0 {
+		return output
+	}
+	fileNames := make([]string, 0, len(sourceMap.Files))
+	for fileName := range sourceMap.Files {
+		fileNames = append(fileNames, regexp.QuoteMeta(fileName))
+	}
+	sort.Strings(fileNames)
+	lineReference := regexp.MustCompile(fmt.Sprintf(`(^|[^\w.-])(%s)(:|: \(|", line )(\d+)`, strings.Join(fileNames, "|")))
+	return lineReference.ReplaceAllStringFunc(output, func(reference string) string {
+		match := lineReference.FindStringSubmatch(reference)
+		line, err := strconv.Atoi(match[4])
+		original, ok := sourceMap.Files[match[2]][line]
+		if err != nil || !ok {
+			return reference
+		}
+		return match[1] + match[2] + match[3] + strconv.Itoa(original)
+	})
+}
@@ -0,0 +1,88 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source_map
+
+import (
+	"testing"
+)
+
+func TestSourceMap_Translate(t *testing.T) {
+	sourceMap := &SourceMap{Files: map[string]map[int]int{"Main.java": map[int]int{1: 1, 2: 1, 3: 2, 4: 3}}}
+	tests := []struct {
+		name      string
+		sourceMap *SourceMap
+		output    string
+		want      string
+	}{
+		{
+			name:      "javac error",
+			sourceMap: sourceMap,
+			output:    "/tmp/7b2940ca/src/Main.java:4: error: cannot find symbol\n        foo();\n        ^",
+			want:      "/tmp/7b2940ca/src/Main.java:3: error: cannot find symbol\n        foo();\n        ^",
+		},
+		{
+			name:      "java stack trace",
+			sourceMap: sourceMap,
+			output:    "Exception in thread \"main\" java.lang.ArithmeticException: / by zero\n\tat Main.main(Main.java:3)",
+			want:      "Exception in thread \"main\" java.lang.ArithmeticException: / by zero\n\tat Main.main(Main.java:2)",
+		},
+		{
+			name:      "go error",
+			sourceMap: &SourceMap{Files: map[string]map[int]int{"main.go": map[int]int{12: 10}}},
+			output:    "# command-line-arguments\n./main.go:12:5: undefined: foo",
+			want:      "# command-line-arguments\n./main.go:10:5: undefined: foo",
+		},
+		{
+			name:      "kotlinc error",
+			sourceMap: &SourceMap{Files: map[string]map[int]int{"Main.kt": map[int]int{7: 5}}},
+			output:    "Main.kt:7:9: error: unresolved reference: foo\ne: /tmp/src/Main.kt: (7, 9): unresolved reference: foo",
+			want:      "Main.kt:5:9: error: unresolved reference: foo\ne: /tmp/src/Main.kt: (5, 9): unresolved reference: foo",
+		},
+		{
+			name:      "python traceback",
+			sourceMap: &SourceMap{Files: map[string]map[int]int{"main.py": map[int]int{11: 3}}},
+			output:    "Traceback (most recent call last):\n  File \"/tmp/src/main.py\", line 11, in <module>\n    x = 1 / 0\nZeroDivisionError: division by zero",
+			want:      "Traceback (most recent call last):\n  File \"/tmp/src/main.py\", line 3, in <module>\n    x = 1 / 0\nZeroDivisionError: division by zero",
+		},
+		{
+			// Test that lines of each file are replaced with lines of its own map
+			name:      "several files",
+			sourceMap: &SourceMap{Files: map[string]map[int]int{"Main.java": {4: 3}, "Helper.java": {3: 9}}},
+			output:    "/tmp/src/Main.java:4: error: cannot find symbol\n/tmp/src/Helper.java:3: error: cannot find symbol",
+			want:      "/tmp/src/Main.java:3: error: cannot find symbol\n/tmp/src/Helper.java:9: error: cannot find symbol",
+		},
+		{
+			// Test that lines of other files and lines which aren't in the map are kept
+			name:      "other files",
+			sourceMap: sourceMap,
+			output:    "OtherMain.java:4: error\nHelper.java:2: error\nMain.java:40: error",
+			want:      "OtherMain.java:4: error\nHelper.java:2: error\nMain.java:40: error",
+		},
+		{
+			name:      "no source map",
+			sourceMap: nil,
+			output:    "Main.java:4: error",
+			want:      "Main.java:4: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sourceMap.Translate(tt.output); got != tt.want {
+				t.Errorf("Translate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	truncationMarkerFormat = "\n[output truncated after %d bytes]\n"
+	hardLimitMarkerFormat  = "\n[run stopped after %d bytes of output]\n"
+)
+
+// TruncatingWriter is used to limit the output stream of the run step.
+// It writes at most Limit bytes to Writer and discards the rest of the stream, so the process keeps running.
+// Once the stream exceeds Limit the truncation marker is written and OnTruncate is called.
+// Once the stream exceeds HardLimit OnHardLimit is called, e.g. to stop the process.
+// Non-positive Limit or HardLimit means that the stream isn't limited.
+type TruncatingWriter struct {
+	Writer      io.Writer
+	Limit       int64
+	HardLimit   int64
+	OnTruncate  func()
+	OnHardLimit func()
+
+	mu               sync.Mutex
+	received         int64
+	truncated        bool
+	hardLimitReached bool
+}
+
+// Write writes the part of p which fits into Limit to Writer.
+// Returns len(p) even if p is discarded, so the process which writes the stream isn't failed because of the limit.
+// In case Writer returns an error - returns (0, error).
+func (tw *TruncatingWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	kept := tw.received
+	tw.received += int64(len(p))
+	if tw.Limit <= 0 || tw.received <= tw.Limit {
+		if _, err := tw.Writer.Write(p); err != nil {
+			return 0, err
+		}
+		return len(p), tw.checkHardLimit()
+	}
+	if !tw.truncated {
+		tw.truncated = true
+		// the output is cut at the start of a rune, so the kept part stays valid UTF-8
+		end := tw.Limit - kept
+		for end > 0 && !utf8.RuneStart(p[end]) {
+			end--
+		}
+		data := append(append([]byte{}, p[:end]...), fmt.Sprintf(truncationMarkerFormat, tw.Limit)...)
+		if _, err := tw.Writer.Write(data); err != nil {
+			return 0, err
+		}
+		if tw.OnTruncate != nil {
+			tw.OnTruncate()
+		}
+	}
+	return len(p), tw.checkHardLimit()
+}
+
+// Truncated returns true if the stream exceeded Limit
+func (tw *TruncatingWriter) Truncated() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.truncated
+}
+
+// checkHardLimit writes the marker and calls OnHardLimit once when the stream exceeds HardLimit
+func (tw *TruncatingWriter) checkHardLimit() error {
+	if tw.HardLimit <= 0 || tw.received <= tw.HardLimit || tw.hardLimitReached {
+		return nil
+	}
+	tw.hardLimitReached = true
+	if _, err := fmt.Fprintf(tw.Writer, hardLimitMarkerFormat, tw.HardLimit); err != nil {
+		return err
+	}
+	if tw.OnHardLimit != nil {
+		tw.OnHardLimit()
+	}
+	return nil
+}
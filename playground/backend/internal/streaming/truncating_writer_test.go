@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streaming
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTruncatingWriter_Write(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int64
+		chunks        []string
+		want          string
+		wantTruncated bool
+	}{
+		{
+			name:          "output within the limit",
+			limit:         10,
+			chunks:        []string{"12345", "67890"},
+			want:          "1234567890",
+			wantTruncated: false,
+		},
+		{
+			name:          "output exceeds the limit inside a chunk",
+			limit:         8,
+			chunks:        []string{"12345", "67890", "12345"},
+			want:          "12345678" + fmt.Sprintf(truncationMarkerFormat, 8),
+			wantTruncated: true,
+		},
+		{
+			// Test that the output is cut at the start of a rune, so truncated output is valid UTF-8
+			name:          "output is cut inside a rune",
+			limit:         4,
+			chunks:        []string{"abc", "ж", "d"},
+			want:          "abc" + fmt.Sprintf(truncationMarkerFormat, 4),
+			wantTruncated: true,
+		},
+		{
+			name:          "not limited output",
+			limit:         0,
+			chunks:        []string{"12345", "67890"},
+			want:          "1234567890",
+			wantTruncated: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			truncations := 0
+			tw := &TruncatingWriter{Writer: &out, Limit: tt.limit, OnTruncate: func() { truncations++ }}
+			for _, chunk := range tt.chunks {
+				n, err := tw.Write([]byte(chunk))
+				if err != nil || n != len(chunk) {
+					t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(chunk))
+				}
+			}
+			if out.String() != tt.want {
+				t.Errorf("Write() output = %q, want %q", out.String(), tt.want)
+			}
+			if tw.Truncated() != tt.wantTruncated {
+				t.Errorf("Truncated() = %t, want %t", tw.Truncated(), tt.wantTruncated)
+			}
+			if wantTruncations := map[bool]int{true: 1, false: 0}[tt.wantTruncated]; truncations != wantTruncations {
+				t.Errorf("OnTruncate is called %d times, want %d", truncations, wantTruncations)
+			}
+		})
+	}
+}
+
+// Test that the output which is streamed far past the limit keeps the memory bounded
+// and that stdout and stderr are accounted independently.
+func TestTruncatingWriter_WriteStream(t *testing.T) {
+	const limit = 1024
+	var stdout, stderr bytes.Buffer
+	stdoutWriter := &TruncatingWriter{Writer: &stdout, Limit: limit}
+	stderrWriter := &TruncatingWriter{Writer: &stderr, Limit: limit}
+	chunk := []byte(strings.Repeat("x", 100) + "\n")
+	for i := 0; i < 100000; i++ {
+		if _, err := stdoutWriter.Write(chunk); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if _, err := stderrWriter.Write([]byte("error\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	marker := fmt.Sprintf(truncationMarkerFormat, limit)
+	if stdout.Len() != limit+len(marker) {
+		t.Errorf("stdout length = %d, want %d", stdout.Len(), limit+len(marker))
+	}
+	if !strings.HasSuffix(stdout.String(), marker) || strings.Count(stdout.String(), marker) != 1 {
+		t.Errorf("stdout should end with the single truncation marker, got %q", stdout.String()[limit:])
+	}
+	if !stdoutWriter.Truncated() {
+		t.Errorf("stdout should be truncated")
+	}
+	if stderr.String() != "error\n" || stderrWriter.Truncated() {
+		t.Errorf("stderr = %q, truncated = %t, want not truncated stderr", stderr.String(), stderrWriter.Truncated())
+	}
+}
+
+func TestTruncatingWriter_WriteHardLimit(t *testing.T) {
+	var out bytes.Buffer
+	stops := 0
+	tw := &TruncatingWriter{Writer: &out, Limit: 4, HardLimit: 10, OnHardLimit: func() { stops++ }}
+	for i := 0; i < 5; i++ {
+		if _, err := tw.Write([]byte("123")); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	want := "1231" + fmt.Sprintf(truncationMarkerFormat, 4) + fmt.Sprintf(hardLimitMarkerFormat, 10)
+	if out.String() != want {
+		t.Errorf("Write() output = %q, want %q", out.String(), want)
+	}
+	if stops != 1 {
+		t.Errorf("OnHardLimit is called %d times, want 1", stops)
+	}
+}
@@ -0,0 +1,96 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test_report
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"regexp"
+	"strings"
+)
+
+var (
+	goTestRunRegexp    = regexp.MustCompile(`^=== RUN\s+(\S+)`)
+	goTestResultRegexp = regexp.MustCompile(`^(\s*)--- (PASS|FAIL|SKIP): (\S+) \(`)
+	// goPackageResultRegexp matches the result of the package, e.g. "ok  	pkg	0.01s" or "FAIL	pkg [build failed]"
+	goPackageResultRegexp = regexp.MustCompile(`^(ok|FAIL|\?)\s+(\S+)(?:\s+\[(.+)\])?`)
+	// goTestTrailerRegexp matches lines which are printed by go test after results of tests
+	goTestTrailerRegexp = regexp.MustCompile(`^(PASS|FAIL|exit status \d+)$`)
+)
+
+// parseGoTestOutput returns results of top-level tests from the output of "go test -v".
+// Output lines of a test and its subtests are the failure message of the test, goroutine dumps of panics are cut.
+// Returns nil if the output doesn't contain the result of the package.
+func parseGoTestOutput(output string) *pb.TestReport {
+	report := &pb.TestReport{}
+	outputs := map[string][]string{}
+	var failed []string
+	current := ""
+	packageResult := false
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if match := goTestRunRegexp.FindStringSubmatch(line); match != nil {
+			current = topLevelTest(match[1])
+			continue
+		}
+		if match := goTestResultRegexp.FindStringSubmatch(line); match != nil {
+			current = topLevelTest(match[3])
+			if match[1] != "" {
+				// results of subtests are included in the result of their top-level test
+				continue
+			}
+			switch match[2] {
+			case "PASS":
+				report.Passed++
+			case "FAIL":
+				report.Failed++
+				failed = append(failed, current)
+			case "SKIP":
+				report.Skipped++
+			}
+			continue
+		}
+		if match := goPackageResultRegexp.FindStringSubmatch(line); match != nil {
+			packageResult = true
+			if match[1] == "FAIL" && match[3] != "" {
+				// the package isn't built or set up, so its tests aren't run
+				report.Failed++
+				report.Failures = append(report.Failures, &pb.TestFailure{Name: match[2], Message: match[3]})
+			}
+			current = ""
+			continue
+		}
+		if goTestTrailerRegexp.MatchString(line) || current == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "goroutine ") {
+			current = ""
+			continue
+		}
+		outputs[current] = append(outputs[current], strings.TrimSpace(line))
+	}
+	if !packageResult {
+		return nil
+	}
+	for _, name := range failed {
+		report.Failures = append(report.Failures, &pb.TestFailure{Name: name, Message: failureMessage(outputs[name])})
+	}
+	return report
+}
+
+// topLevelTest returns the name of the top-level test of the test or the subtest
+func topLevelTest(name string) string {
+	return strings.SplitN(name, "/", 2)[0]
+}
@@ -0,0 +1,88 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test_report
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	junitSuccessRegexp  = regexp.MustCompile(`^OK \((\d+) tests?\)`)
+	junitFailureRegexp  = regexp.MustCompile(`^Tests run: (\d+),\s+Failures: (\d+)`)
+	junitFailuresRegexp = regexp.MustCompile(`^There (?:was|were) \d+ failures?:`)
+	// junitTestRegexp matches the header of the failure, e.g. "1) testAdd(org.example.CalculatorTest)"
+	junitTestRegexp = regexp.MustCompile(`^\d+\) (\w+)\((.+)\)$`)
+	// junitProgressRegexp matches the progress of JUnitCore: "." for each test, "E" for each failure and "I" for each ignored test
+	junitProgressRegexp = regexp.MustCompile(`^[.EI]+$`)
+)
+
+// parseJUnitOutput returns results of tests from the output of JUnitCore.
+// The failure message of the test is the message of the exception without the stack trace.
+// Returns nil if the output doesn't contain the summary of the run.
+func parseJUnitOutput(output string) *pb.TestReport {
+	report := &pb.TestReport{}
+	summary := false
+	ignored := 0
+	var failure *pb.TestFailure
+	var message []string
+	inFailures, inStackTrace := false, false
+	finishFailure := func() {
+		if failure != nil {
+			failure.Message = failureMessage(message)
+			report.Failures = append(report.Failures, failure)
+		}
+		failure, message = nil, nil
+	}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case junitProgressRegexp.MatchString(line) && !inFailures:
+			ignored += strings.Count(line, "I")
+		case junitFailuresRegexp.MatchString(line):
+			inFailures = true
+		case junitSuccessRegexp.MatchString(line):
+			run, _ := strconv.Atoi(junitSuccessRegexp.FindStringSubmatch(line)[1])
+			report.Passed, summary = int32(run), true
+		case junitFailureRegexp.MatchString(line):
+			match := junitFailureRegexp.FindStringSubmatch(line)
+			run, _ := strconv.Atoi(match[1])
+			failed, _ := strconv.Atoi(match[2])
+			report.Passed, report.Failed, summary = int32(run-failed), int32(failed), true
+			finishFailure()
+			inFailures = false
+		case inFailures && junitTestRegexp.MatchString(line):
+			finishFailure()
+			match := junitTestRegexp.FindStringSubmatch(line)
+			failure, inStackTrace = &pb.TestFailure{Name: match[2] + "." + match[1]}, false
+		case inFailures && line == "FAILURES!!!":
+			finishFailure()
+		case failure != nil && !inStackTrace:
+			if strings.HasPrefix(strings.TrimSpace(line), "at ") {
+				inStackTrace = true
+				continue
+			}
+			message = append(message, line)
+		}
+	}
+	if !summary {
+		return nil
+	}
+	report.Skipped = int32(ignored)
+	return report
+}
@@ -0,0 +1,97 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test_report
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"encoding/xml"
+	"os"
+	"strings"
+)
+
+// junitXmlReport is the JUnit XML report. The root element is either <testsuites> or a single <testsuite>.
+type junitXmlReport struct {
+	Suites []junitXmlReport `xml:"testsuite"`
+	Cases  []junitXmlCase   `xml:"testcase"`
+}
+
+type junitXmlCase struct {
+	Name      string           `xml:"name,attr"`
+	ClassName string           `xml:"classname,attr"`
+	Failure   *junitXmlProblem `xml:"failure"`
+	Error     *junitXmlProblem `xml:"error"`
+	Skipped   *junitXmlProblem `xml:"skipped"`
+}
+
+type junitXmlProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// parseJUnitXmlReport returns results of tests from the JUnit XML report by reportFilePath, e.g. "pytest --junitxml".
+// Tests with errors, e.g. errors of fixtures or of the collection of tests, are counted as failed.
+// Returns nil if the report doesn't exist or isn't valid.
+func parseJUnitXmlReport(reportFilePath string) *pb.TestReport {
+	data, err := os.ReadFile(reportFilePath)
+	if err != nil {
+		return nil
+	}
+	root := junitXmlReport{}
+	if err = xml.Unmarshal(data, &root); err != nil {
+		return nil
+	}
+	report := &pb.TestReport{}
+	root.addTo(report)
+	return report
+}
+
+// addTo adds results of test cases of the report and its suites to the report
+func (r junitXmlReport) addTo(report *pb.TestReport) {
+	for _, suite := range r.Suites {
+		suite.addTo(report)
+	}
+	for _, testCase := range r.Cases {
+		problem := testCase.Failure
+		if problem == nil {
+			problem = testCase.Error
+		}
+		switch {
+		case problem != nil:
+			report.Failed++
+			report.Failures = append(report.Failures, &pb.TestFailure{Name: testCase.fullName(), Message: problem.message()})
+		case testCase.Skipped != nil:
+			report.Skipped++
+		default:
+			report.Passed++
+		}
+	}
+}
+
+// fullName returns the name of the test with its class, e.g. "test_module.test_name"
+func (c junitXmlCase) fullName() string {
+	if c.ClassName == "" {
+		return c.Name
+	}
+	return c.ClassName + "." + c.Name
+}
+
+// message returns the message of the failure or the beginning of its details if there is no message
+func (p junitXmlProblem) message() string {
+	if p.Message != "" {
+		return p.Message
+	}
+	return failureMessage(strings.Split(strings.TrimSpace(p.Text), "\n"))
+}
@@ -0,0 +1,62 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test_report
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// reportFileName is the name of the machine-readable report which is written by the test runner to the folder of the pipeline
+	reportFileName = "test_report.xml"
+	// maxMessageLines is the max number of lines of the failure message of the test, stack traces are cut
+	maxMessageLines = 20
+)
+
+// ReportFilePath returns the path of the machine-readable report of the test runner in the folder of the pipeline
+func ReportFilePath(baseFolderPath string) string {
+	return filepath.Join(baseFolderPath, reportFileName)
+}
+
+// Parse returns results of the unit test run of the sdk.
+// Results of pytest are read from the JUnit XML report by reportFilePath, results of JUnit and go test are parsed
+// from the output of the run. If results can't be parsed the report only has the ParseFailed flag.
+func Parse(sdk pb.Sdk, output, reportFilePath string) *pb.TestReport {
+	var report *pb.TestReport
+	switch sdk {
+	case pb.Sdk_SDK_JAVA, pb.Sdk_SDK_KOTLIN:
+		report = parseJUnitOutput(output)
+	case pb.Sdk_SDK_GO:
+		report = parseGoTestOutput(output)
+	case pb.Sdk_SDK_PYTHON:
+		report = parseJUnitXmlReport(reportFilePath)
+	}
+	if report == nil {
+		return &pb.TestReport{ParseFailed: true}
+	}
+	report.TestsRun = report.Passed + report.Failed + report.Skipped
+	return report
+}
+
+// failureMessage returns trimmed lines of the failure message without empty lines at the start and the end
+func failureMessage(lines []string) string {
+	if len(lines) > maxMessageLines {
+		lines = lines[:maxMessageLines]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,238 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test_report
+
+import (
+	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const (
+	junitPassedOutput = `JUnit version 4.13.1
+...I
+Time: 0.012
+
+OK (3 tests)
+
+`
+	junitFailedOutput = `JUnit version 4.13.1
+..E.E
+Time: 0.015
+There were 2 failures:
+1) testSubtract(org.example.CalculatorTest)
+java.lang.AssertionError: expected:<1> but was:<2>
+	at org.junit.Assert.fail(Assert.java:89)
+	at org.example.CalculatorTest.testSubtract(CalculatorTest.java:21)
+2) testDivide(org.example.CalculatorTest)
+java.lang.ArithmeticException: / by zero
+	at org.example.Calculator.divide(Calculator.java:9)
+
+FAILURES!!!
+Tests run: 3,  Failures: 2
+
+`
+	junitErroredOutput = `JUnit version 4.13.1
+.E
+Time: 0.003
+There was 1 failure:
+1) initializationError(org.example.CalculatorTest)
+java.lang.Exception: No runnable methods
+	at org.junit.runners.BlockJUnit4ClassRunner.validateInstanceMethods(BlockJUnit4ClassRunner.java:191)
+
+FAILURES!!!
+Tests run: 1,  Failures: 1
+
+`
+	goPassedOutput = `=== RUN   TestAdd
+--- PASS: TestAdd (0.00s)
+=== RUN   TestSubtract
+=== RUN   TestSubtract/positive
+=== RUN   TestSubtract/negative
+--- PASS: TestSubtract (0.00s)
+    --- PASS: TestSubtract/positive (0.00s)
+    --- PASS: TestSubtract/negative (0.00s)
+=== RUN   TestDivide
+    calculator_test.go:31: not implemented
+--- SKIP: TestDivide (0.00s)
+PASS
+ok  	command-line-arguments	0.003s
+`
+	goFailedOutput = `=== RUN   TestAdd
+--- PASS: TestAdd (0.00s)
+=== RUN   TestSubtract
+=== RUN   TestSubtract/positive
+    calculator_test.go:24: got 2, want 1
+--- FAIL: TestSubtract (0.00s)
+    --- FAIL: TestSubtract/positive (0.00s)
+=== RUN   TestDivide
+--- FAIL: TestDivide (0.00s)
+panic: runtime error: integer divide by zero [recovered]
+	panic: runtime error: integer divide by zero
+
+goroutine 7 [running]:
+testing.tRunner.func1.2({0x4f5e40, 0x5d3c20})
+	/usr/local/go/src/testing/testing.go:1209 +0x24e
+FAIL	command-line-arguments	0.004s
+FAIL
+`
+	goErroredOutput = `# command-line-arguments [command-line-arguments.test]
+./calculator_test.go:12:2: undefined: Multiply
+FAIL	command-line-arguments [build failed]
+FAIL
+`
+	pytestPassedReport = `<?xml version="1.0" encoding="utf-8"?>
+<testsuites><testsuite name="pytest" errors="0" failures="0" skipped="1" tests="3">
+<testcase classname="test_calculator" name="test_add" time="0.001"/>
+<testcase classname="test_calculator" name="test_subtract" time="0.001"/>
+<testcase classname="test_calculator" name="test_divide" time="0.000"><skipped type="pytest.skip" message="not implemented">test_calculator.py:12: not implemented</skipped></testcase>
+</testsuite></testsuites>`
+	pytestFailedReport = `<?xml version="1.0" encoding="utf-8"?>
+<testsuites><testsuite name="pytest" errors="0" failures="1" skipped="0" tests="2">
+<testcase classname="test_calculator" name="test_add" time="0.001"/>
+<testcase classname="test_calculator" name="test_subtract" time="0.001"><failure message="assert 2 == 1">def test_subtract():
+&gt;       assert subtract(3, 1) == 1
+E       assert 2 == 1</failure></testcase>
+</testsuite></testsuites>`
+	pytestErroredReport = `<?xml version="1.0" encoding="utf-8"?>
+<testsuite name="pytest" errors="1" failures="0" skipped="0" tests="1">
+<testcase classname="" name="test_calculator"><error message="collection failure">ImportError while importing test module 'test_calculator.py'.
+E   ModuleNotFoundError: No module named 'calculator'</error></testcase>
+</testsuite>`
+)
+
+func TestParse(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeReport := func(name, report string) string {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(report), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	type args struct {
+		sdk            pb.Sdk
+		output         string
+		reportFilePath string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *pb.TestReport
+	}{
+		{
+			name: "JUnit passed suite",
+			args: args{sdk: pb.Sdk_SDK_JAVA, output: junitPassedOutput},
+			want: &pb.TestReport{TestsRun: 4, Passed: 3, Skipped: 1},
+		},
+		{
+			name: "JUnit failed suite",
+			args: args{sdk: pb.Sdk_SDK_JAVA, output: junitFailedOutput},
+			want: &pb.TestReport{TestsRun: 3, Passed: 1, Failed: 2, Failures: []*pb.TestFailure{
+				{Name: "org.example.CalculatorTest.testSubtract", Message: "java.lang.AssertionError: expected:<1> but was:<2>"},
+				{Name: "org.example.CalculatorTest.testDivide", Message: "java.lang.ArithmeticException: / by zero"},
+			}},
+		},
+		{
+			name: "JUnit errored suite",
+			args: args{sdk: pb.Sdk_SDK_KOTLIN, output: junitErroredOutput},
+			want: &pb.TestReport{TestsRun: 1, Failed: 1, Failures: []*pb.TestFailure{
+				{Name: "org.example.CalculatorTest.initializationError", Message: "java.lang.Exception: No runnable methods"},
+			}},
+		},
+		{
+			name: "JUnit truncated output",
+			args: args{sdk: pb.Sdk_SDK_JAVA, output: "JUnit version 4.13.1\n..E"},
+			want: &pb.TestReport{ParseFailed: true},
+		},
+		{
+			name: "go test passed suite",
+			args: args{sdk: pb.Sdk_SDK_GO, output: goPassedOutput},
+			want: &pb.TestReport{TestsRun: 3, Passed: 2, Skipped: 1},
+		},
+		{
+			name: "go test failed suite",
+			args: args{sdk: pb.Sdk_SDK_GO, output: goFailedOutput},
+			want: &pb.TestReport{TestsRun: 3, Passed: 1, Failed: 2, Failures: []*pb.TestFailure{
+				{Name: "TestSubtract", Message: "calculator_test.go:24: got 2, want 1"},
+				{Name: "TestDivide", Message: "panic: runtime error: integer divide by zero [recovered]\npanic: runtime error: integer divide by zero"},
+			}},
+		},
+		{
+			name: "go test errored suite",
+			args: args{sdk: pb.Sdk_SDK_GO, output: goErroredOutput},
+			want: &pb.TestReport{TestsRun: 1, Failed: 1, Failures: []*pb.TestFailure{
+				{Name: "command-line-arguments", Message: "build failed"},
+			}},
+		},
+		{
+			name: "go test truncated output",
+			args: args{sdk: pb.Sdk_SDK_GO, output: "=== RUN   TestAdd\n--- PASS: TestAdd (0.00s)\n"},
+			want: &pb.TestReport{ParseFailed: true},
+		},
+		{
+			name: "pytest passed suite",
+			args: args{sdk: pb.Sdk_SDK_PYTHON, reportFilePath: writeReport("passed.xml", pytestPassedReport)},
+			want: &pb.TestReport{TestsRun: 3, Passed: 2, Skipped: 1},
+		},
+		{
+			name: "pytest failed suite",
+			args: args{sdk: pb.Sdk_SDK_PYTHON, reportFilePath: writeReport("failed.xml", pytestFailedReport)},
+			want: &pb.TestReport{TestsRun: 2, Passed: 1, Failed: 1, Failures: []*pb.TestFailure{
+				{Name: "test_calculator.test_subtract", Message: "assert 2 == 1"},
+			}},
+		},
+		{
+			name: "pytest errored suite",
+			args: args{sdk: pb.Sdk_SDK_PYTHON, reportFilePath: writeReport("errored.xml", pytestErroredReport)},
+			want: &pb.TestReport{TestsRun: 1, Failed: 1, Failures: []*pb.TestFailure{
+				{Name: "test_calculator", Message: "collection failure"},
+			}},
+		},
+		{
+			name: "pytest invalid report",
+			args: args{sdk: pb.Sdk_SDK_PYTHON, reportFilePath: writeReport("invalid.xml", "<testsuites><testsuite>")},
+			want: &pb.TestReport{ParseFailed: true},
+		},
+		{
+			name: "pytest report doesn't exist",
+			args: args{sdk: pb.Sdk_SDK_PYTHON, reportFilePath: filepath.Join(tmpDir, "missing.xml")},
+			want: &pb.TestReport{ParseFailed: true},
+		},
+		{
+			name: "unsupported sdk",
+			args: args{sdk: pb.Sdk_SDK_SCIO, output: junitPassedOutput},
+			want: &pb.TestReport{ParseFailed: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.args.sdk, tt.args.output, tt.args.reportFilePath)
+			if got.String() != tt.want.String() {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportFilePath(t *testing.T) {
+	want := filepath.Join("executable_files", "id", reportFileName)
+	if got := ReportFilePath(filepath.Join("executable_files", "id")); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReportFilePath() = %v, want %v", got, want)
+	}
+}
@@ -17,6 +17,8 @@ package utils
 
 import (
 	pb "beam.apache.org/playground/backend/internal/api/v1"
+	"beam.apache.org/playground/backend/internal/environment"
+	"beam.apache.org/playground/backend/internal/logger"
 	"beam.apache.org/playground/backend/internal/preparers"
 	"beam.apache.org/playground/backend/internal/validators"
 	"fmt"
@@ -24,28 +26,125 @@ import (
 	"sync"
 )
 
-// GetPreparers returns slice of preparers.Preparer according to sdk
-func GetPreparers(sdk pb.Sdk, filepath string, valResults *sync.Map) (*[]preparers.Preparer, error) {
+// PreparersOptions contains settings of preparers of the code processing:
+// - InjectRandomSeed: adds preparers which make the output of the code with randomness reproducible
+// - IoSubstitutions: if they aren't empty adds preparers which replace inputs of Java and Python code with local stand-ins
+// - OutputDir: if it isn't empty adds preparers which redirect outputs of Java, Python and Go code to OutputDir
+// - IncludeDir: if it isn't empty adds the preparer which replaces @include directives of the code with files from IncludeDir
+// - RejectInteractiveInput: the code which reads the standard input is rejected, otherwise the warning is added
+// - RejectJavaModules: the Java code which declares a module is rejected, otherwise the module declaration is removed
+// - Overrides: preparers which are skipped or added regardless of the code type
+type PreparersOptions struct {
+	InjectRandomSeed       bool
+	IoSubstitutions        environment.IoSubstitutions
+	OutputDir              string
+	IncludeDir             string
+	RejectInteractiveInput bool
+	RejectJavaModules      bool
+	Overrides              preparers.Overrides
+}
+
+// GetPreparers returns slice of preparers.Preparer according to sdk and preparers.RunSummary which contains
+// warnings about skipped preparers required by the code processing and is filled while preparers are applied.
+// The code of any sdk is checked to be valid UTF-8 before other preparers.
+// Preparers which find reads of the standard input are added before others for Java, Kotlin, Python and Go code, so lines
+// of reads match the code of the user. If Java code declares a module, the module declaration is removed from the code
+// before other preparers unless options reject such code.
+func GetPreparers(sdk pb.Sdk, filepath string, valResults *sync.Map, options PreparersOptions, log *logger.Entry) (*[]preparers.Preparer, *preparers.RunSummary, error) {
 	isUnitTest, ok := valResults.Load(validators.UnitTestValidatorName)
 	if !ok {
-		return nil, fmt.Errorf("GetPreparers:: No information about unit test validation result")
+		return nil, nil, fmt.Errorf("GetPreparers:: No information about unit test validation result")
+	}
+	builder := preparers.NewPreparersBuilder(filepath).WithLogger(log).WithSkipped(options.Overrides.Skip).WithUtf8Validator().WithSourceMap()
+	if options.IncludeDir != "" {
+		builder.WithIncludeResolver(options.IncludeDir)
 	}
-	builder := preparers.NewPreparersBuilder(filepath)
 	switch sdk {
 	case pb.Sdk_SDK_JAVA:
 		isKata, ok := valResults.Load(validators.KatasValidatorName)
 		if !ok {
-			return nil, fmt.Errorf("GetPreparers:: No information about katas validation result")
+			return nil, nil, fmt.Errorf("GetPreparers:: No information about katas validation result")
 		}
+		isModule, ok := valResults.Load(validators.JavaModuleValidatorName)
+		if !ok {
+			return nil, nil, fmt.Errorf("GetPreparers:: No information about java module validation result")
+		}
+		if isModule.(bool) && !options.RejectJavaModules {
+			builder.JavaPreparers().WithModuleInfoStripper()
+		}
+		builder.JavaPreparers().WithStdinChecker(options.RejectInteractiveInput)
 		preparers.GetJavaPreparers(builder, isUnitTest.(bool), isKata.(bool))
+		if options.InjectRandomSeed {
+			builder.JavaPreparers().WithSeedInjector()
+		}
+		if hasIoSubstitutions(options.IoSubstitutions) {
+			builder.JavaPreparers().WithIoSubstitutor(options.IoSubstitutions.Paths, options.IoSubstitutions.BigQueryTables)
+		}
+		if options.OutputDir != "" {
+			builder.JavaPreparers().WithOutputPathRewriter(preparers.DefaultOutputPlaceholder, options.OutputDir, options.IoSubstitutions.OutputPaths...)
+		}
 	case pb.Sdk_SDK_GO:
+		builder.GoPreparers().WithStdinChecker(options.RejectInteractiveInput)
 		preparers.GetGoPreparers(builder, isUnitTest.(bool))
+		if options.OutputDir != "" {
+			builder.GoPreparers().WithOutputPathRewriter(preparers.DefaultOutputPlaceholder, options.OutputDir, options.IoSubstitutions.OutputPaths...)
+		}
 	case pb.Sdk_SDK_PYTHON:
+		builder.PythonPreparers().WithStdinChecker(options.RejectInteractiveInput)
 		preparers.GetPythonPreparers(builder)
+		if hasIoSubstitutions(options.IoSubstitutions) {
+			builder.PythonPreparers().WithIoSubstitutor(options.IoSubstitutions.Paths, options.IoSubstitutions.BigQueryTables)
+		}
+		if options.OutputDir != "" {
+			builder.PythonPreparers().WithOutputPathRewriter(preparers.DefaultOutputPlaceholder, options.OutputDir, options.IoSubstitutions.OutputPaths...)
+		}
+	case pb.Sdk_SDK_YAML:
+		preparers.GetYamlPreparers(builder)
+	case pb.Sdk_SDK_KOTLIN:
+		isKata, ok := valResults.Load(validators.KatasValidatorName)
+		if !ok {
+			return nil, nil, fmt.Errorf("GetPreparers:: No information about katas validation result")
+		}
+		builder.KotlinPreparers().WithStdinChecker(options.RejectInteractiveInput)
+		preparers.GetKotlinPreparers(builder, isUnitTest.(bool), isKata.(bool))
+	default:
+		return nil, nil, fmt.Errorf("incorrect sdk: %s", sdk)
+	}
+	registry, _ := GetPreparersRegistry(sdk)
+	builder.WithForced(registry, options.Overrides.Force)
+	return builder.Build().GetPreparers(), builder.Summary(), nil
+}
+
+// hasIoSubstitutions checks that there is at least one local stand-in of inputs
+func hasIoSubstitutions(ioSubstitutions environment.IoSubstitutions) bool {
+	return len(ioSubstitutions.Paths) != 0 || len(ioSubstitutions.BigQueryTables) != 0
+}
+
+// GetPreparersRegistry returns preparers.Registry of preparers which can be skipped or forced for the sdk
+func GetPreparersRegistry(sdk pb.Sdk) (preparers.Registry, error) {
+	switch sdk {
+	case pb.Sdk_SDK_JAVA:
+		return preparers.JavaRegistry(), nil
+	case pb.Sdk_SDK_GO:
+		return preparers.GoRegistry(), nil
+	case pb.Sdk_SDK_PYTHON:
+		return preparers.PythonRegistry(), nil
+	case pb.Sdk_SDK_YAML:
+		return preparers.YamlRegistry(), nil
+	case pb.Sdk_SDK_KOTLIN:
+		return preparers.KotlinRegistry(), nil
 	default:
 		return nil, fmt.Errorf("incorrect sdk: %s", sdk)
 	}
-	return builder.Build().GetPreparers(), nil
+}
+
+// ValidatePreparerOverrides checks that overrides contain only preparers which are known for the sdk
+func ValidatePreparerOverrides(sdk pb.Sdk, overrides preparers.Overrides) error {
+	registry, err := GetPreparersRegistry(sdk)
+	if err != nil {
+		return err
+	}
+	return registry.Validate(overrides)
 }
 
 // ReplaceSpacesWithEquals prepares pipelineOptions by replacing spaces between option and them value to equals.
@@ -31,6 +31,10 @@ func GetValidators(sdk pb.Sdk, filepath string) (*[]validators.Validator, error)
 		val = validators.GetGoValidators(filepath)
 	case pb.Sdk_SDK_PYTHON:
 		val = validators.GetPyValidators(filepath)
+	case pb.Sdk_SDK_YAML:
+		val = validators.GetYamlValidators(filepath)
+	case pb.Sdk_SDK_KOTLIN:
+		val = validators.GetKotlinValidators(filepath)
 	default:
 		return nil, fmt.Errorf("incorrect sdk: %s", sdk)
 	}
@@ -18,6 +18,7 @@ package validators
 import (
 	"beam.apache.org/playground/backend/internal/fs_tool"
 	"beam.apache.org/playground/backend/internal/logger"
+	"beam.apache.org/playground/backend/internal/preparers"
 	"io/ioutil"
 	"strings"
 )
@@ -26,6 +27,8 @@ const (
 	javaExtension       = ".java"
 	javaUnitTestPattern = "@Test"
 	javaKatasPattern    = "org.apache.beam.learning.katas"
+	// JavaModuleValidatorName is the name of the validator which checks that the code declares a JPMS module
+	JavaModuleValidatorName = "JavaModule"
 )
 
 // GetJavaValidators return validators methods that should be applied to Java code
@@ -39,6 +42,11 @@ func GetJavaValidators(filePath string) *[]Validator {
 		Args:      validatorArgs,
 		Name:      "Valid path",
 	}
+	moduleValidator := Validator{
+		Validator: checkIsJavaModule,
+		Args:      validatorArgs,
+		Name:      JavaModuleValidatorName,
+	}
 	unitTestValidator := Validator{
 		Validator: checkIsUnitTestJava,
 		Args:      validatorArgs,
@@ -49,7 +57,7 @@ func GetJavaValidators(filePath string) *[]Validator {
 		Args:      validatorArgs,
 		Name:      KatasValidatorName,
 	}
-	validators := []Validator{pathCheckerValidator, unitTestValidator, katasValidator}
+	validators := []Validator{pathCheckerValidator, moduleValidator, unitTestValidator, katasValidator}
 	return &validators
 }
 
@@ -71,6 +79,17 @@ func checkIsKataJava(args ...interface{}) (bool, error) {
 	return ok, nil
 }
 
+//checkIsJavaModule checks if the code declares a module or contains module directives outside comments and strings
+func checkIsJavaModule(args ...interface{}) (bool, error) {
+	filePath := args[0].(string)
+	code, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		logger.Errorf("Validation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return false, err
+	}
+	return preparers.DeclaresJavaModule(string(code)), nil
+}
+
 func checkPipelineType(args ...interface{}) (bool, error) {
 	filePath := args[0].(string)
 	pattern := args[2].(string)
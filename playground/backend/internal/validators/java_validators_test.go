@@ -16,6 +16,8 @@
 package validators
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -119,6 +121,56 @@ func TestCheckIsKataJava(t *testing.T) {
 	}
 }
 
+func TestCheckIsJavaModule(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			// Test if the whole code is module-info.java
+			name: "if module descriptor",
+			code: "module org.apache.beam.examples {\n    requires org.apache.beam.sdk;\n    exports org.apache.beam.examples;\n}",
+			want: true,
+		},
+		{
+			// Test if module-info.java precedes the class
+			name: "if module before class",
+			code: "module org.apache.beam.examples {\n    requires org.apache.beam.sdk;\n}\n\n" + javaCode,
+			want: true,
+		},
+		{
+			// Test if the module is declared only in comments
+			name: "if module in comment",
+			code: "/* module org.apache.beam.examples {\n    requires org.apache.beam.sdk;\n} */\n" + javaCode,
+			want: false,
+		},
+		{
+			// Test if code doesn't declare a module
+			name: "if not module",
+			code: javaCode,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), javaCodePath)
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			got, err := checkIsJavaModule(filePath, javaExtension)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkIsJavaModule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("checkIsJavaModule() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // getValidatorsArgs returns array of received arguments for validators
 func getValidatorsArgs(args ...interface{}) []interface{} {
 	preparedArgs := make([]interface{}, 3)
@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	kotlinExtension       = ".kt"
+	kotlinUnitTestPattern = "@Test"
+)
+
+// kotlinTestImports are prefixes of imports of test frameworks which are supported by the JUnit runner
+var kotlinTestImports = []string{"import kotlin.test", "import org.junit"}
+
+// GetKotlinValidators return validators methods that should be applied to Kotlin code
+// The last validator should check that the code is unit tests or not
+func GetKotlinValidators(filePath string) *[]Validator {
+	validatorArgs := make([]interface{}, 2)
+	validatorArgs[0] = filePath
+	validatorArgs[1] = kotlinExtension
+	pathCheckerValidator := Validator{
+		Validator: fs_tool.CheckPathIsValid,
+		Args:      validatorArgs,
+		Name:      "Valid path",
+	}
+	unitTestValidator := Validator{
+		Validator: checkIsUnitTestKotlin,
+		Args:      validatorArgs,
+		Name:      UnitTestValidatorName,
+	}
+	katasValidator := Validator{
+		Validator: checkIsKataKotlin,
+		Args:      validatorArgs,
+		Name:      KatasValidatorName,
+	}
+	validators := []Validator{pathCheckerValidator, unitTestValidator, katasValidator}
+	return &validators
+}
+
+//checkIsUnitTestKotlin checks if the pipeline is a UnitTest of kotlin.test or JUnit
+func checkIsUnitTestKotlin(args ...interface{}) (bool, error) {
+	filePath := args[0].(string)
+	code, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		logger.Errorf("Validation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return false, err
+	}
+	if !strings.Contains(string(code), kotlinUnitTestPattern) {
+		return false, nil
+	}
+	for _, testImport := range kotlinTestImports {
+		if strings.Contains(string(code), testImport) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+//checkIsKataKotlin checks if the pipeline is a kata
+func checkIsKataKotlin(args ...interface{}) (bool, error) {
+	return checkPipelineType(append(args, javaKatasPattern)...)
+}
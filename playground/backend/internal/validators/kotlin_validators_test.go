@@ -0,0 +1,67 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_checkIsUnitTestKotlin(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{
+			name: "kotlin.test unit test",
+			code: "import kotlin.test.Test\nimport kotlin.test.assertEquals\n\nclass TaskTest {\n    @Test\n    fun test() {\n        assertEquals(2, 1 + 1)\n    }\n}\n",
+			want: true,
+		},
+		{
+			name: "JUnit unit test",
+			code: "import org.junit.Test\n\nclass TaskTest {\n    @Test\n    fun test() {}\n}\n",
+			want: true,
+		},
+		{
+			// Test that an annotation of another framework isn't taken as a unit test
+			name: "annotation without test framework",
+			code: "annotation class Test\n\n@Test\nfun main() {}\n",
+			want: false,
+		},
+		{
+			name: "code",
+			code: "fun main() {\n    println(\"Hello World!\")\n}\n",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "code.kt")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			got, err := checkIsUnitTestKotlin(filePath, kotlinExtension)
+			if err != nil {
+				t.Fatalf("checkIsUnitTestKotlin() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("checkIsUnitTestKotlin() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"beam.apache.org/playground/backend/internal/fs_tool"
+	"beam.apache.org/playground/backend/internal/logger"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+)
+
+const (
+	yamlExtension             = ".yaml"
+	yamlPipelineKey           = "pipeline"
+	YamlPipelineValidatorName = "YamlPipeline"
+)
+
+// GetYamlValidators return validators methods that should be applied to Beam YAML pipelines
+func GetYamlValidators(filePath string) *[]Validator {
+	validatorArgs := make([]interface{}, 2)
+	validatorArgs[0] = filePath
+	validatorArgs[1] = yamlExtension
+	pathCheckerValidator := Validator{
+		Validator: fs_tool.CheckPathIsValid,
+		Args:      validatorArgs,
+		Name:      "Valid path",
+	}
+	pipelineValidator := Validator{
+		Validator: checkIsYamlPipeline,
+		Args:      validatorArgs,
+		Name:      YamlPipelineValidatorName,
+	}
+	unitTestValidator := Validator{
+		Validator: checkIsUnitTestYaml,
+		Args:      validatorArgs,
+		Name:      UnitTestValidatorName,
+	}
+	validators := []Validator{pathCheckerValidator, pipelineValidator, unitTestValidator}
+	return &validators
+}
+
+// checkIsYamlPipeline checks that the file is a YAML document with the top-level pipeline key
+func checkIsYamlPipeline(args ...interface{}) (bool, error) {
+	filePath := args[0].(string)
+	code, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		logger.Errorf("Validation: Error during open file: %s, err: %s\n", filePath, err.Error())
+		return false, err
+	}
+	var document map[string]interface{}
+	if err = yaml.Unmarshal(code, &document); err != nil {
+		return false, fmt.Errorf("invalid YAML: %s", err.Error())
+	}
+	if _, ok := document[yamlPipelineKey]; !ok {
+		return false, fmt.Errorf("YAML pipeline should contain the top-level \"%s\" key", yamlPipelineKey)
+	}
+	return true, nil
+}
+
+// checkIsUnitTestYaml returns false since Beam YAML pipelines have no unit tests
+func checkIsUnitTestYaml(args ...interface{}) (bool, error) {
+	return false, nil
+}
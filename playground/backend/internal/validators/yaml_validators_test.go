@@ -0,0 +1,69 @@
+// Licensed to the Apache Software Foundation (ASF) under one or more
+// contributor license agreements.  See the NOTICE file distributed with
+// this work for additional information regarding copyright ownership.
+// The ASF licenses this file to You under the Apache License, Version 2.0
+// (the "License"); you may not use this file except in compliance with
+// the License.  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validators
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_checkIsYamlPipeline(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "pipeline",
+			code: "pipeline:\n  transforms:\n    - type: Create\n      config:\n        elements: [1, 2, 3]\n    - type: LogForTesting\n      input: Create\n",
+			want: true,
+		},
+		{
+			name:    "without pipeline key",
+			code:    "transforms:\n  - type: Create\n",
+			wantErr: true,
+		},
+		{
+			// Test that the pipeline key of a nested mapping isn't taken as the top-level one
+			name:    "nested pipeline key",
+			code:    "options:\n  pipeline: DirectRunner\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid yaml",
+			code:    "pipeline:\n  transforms: [\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "pipeline.yaml")
+			if err := os.WriteFile(filePath, []byte(tt.code), 0600); err != nil {
+				t.Fatalf("error during test setup: %s", err.Error())
+			}
+			got, err := checkIsYamlPipeline(filePath, yamlExtension)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkIsYamlPipeline() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("checkIsYamlPipeline() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}